@@ -0,0 +1,138 @@
+// mailsink adalah server SMTP palsu untuk mencoba laporan terjadwal channel email di lokal.
+// Semua email diterima tanpa autentikasi dan disimpan sebagai file .eml, tidak ada yang diteruskan.
+//
+//	go run ./cmd/mailsink -addr :2525 -dir mailsink
+//	SMTP_HOST=localhost SMTP_PORT=2525 go run .
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"log"
+	"mime"
+	"net"
+	"net/mail"
+	"net/textproto"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+var counter atomic.Int64
+
+func main() {
+	addr := flag.String("addr", ":2525", "alamat listen SMTP")
+	dir := flag.String("dir", "mailsink", "folder penyimpanan email")
+	flag.Parse()
+
+	if err := os.MkdirAll(*dir, 0o755); err != nil {
+		log.Fatal("gagal membuat folder: ", err)
+	}
+
+	ln, err := net.Listen("tcp", *addr)
+	if err != nil {
+		log.Fatal("gagal listen: ", err)
+	}
+	log.Printf("mailsink listen di %s, email disimpan di %s", *addr, *dir)
+
+	for {
+		conn, err := ln.Accept()
+		if err != nil {
+			log.Println("gagal menerima koneksi:", err)
+			continue
+		}
+		go handle(conn, *dir)
+	}
+}
+
+func handle(conn net.Conn, dir string) {
+	defer conn.Close()
+	tp := textproto.NewConn(conn)
+
+	var from string
+	var to []string
+	reply := func(code int, msg string) {
+		tp.PrintfLine("%d %s", code, msg)
+	}
+
+	reply(220, "mailsink siap")
+	for {
+		conn.SetDeadline(time.Now().Add(5 * time.Minute))
+		line, err := tp.ReadLine()
+		if err != nil {
+			return
+		}
+		cmd, arg, _ := strings.Cut(line, " ")
+
+		switch strings.ToUpper(cmd) {
+		case "HELO":
+			reply(250, "mailsink")
+		case "EHLO":
+			//tanpa STARTTLS dan AUTH, jadi client tidak mencoba TLS
+			tp.PrintfLine("250-mailsink")
+			reply(250, "8BITMIME")
+		case "MAIL":
+			from, to = address(arg), nil
+			reply(250, "OK")
+		case "RCPT":
+			to = append(to, address(arg))
+			reply(250, "OK")
+		case "DATA":
+			if len(to) == 0 {
+				reply(503, "RCPT dulu")
+				continue
+			}
+			reply(354, "akhiri dengan <CRLF>.<CRLF>")
+			data, err := tp.ReadDotBytes()
+			if err != nil {
+				return
+			}
+			name, err := save(dir, data)
+			if err != nil {
+				log.Println("gagal menyimpan email:", err)
+				reply(451, "gagal menyimpan")
+				continue
+			}
+			log.Printf("email dari %s ke %s: %q -> %s", from, strings.Join(to, ", "), subject(data), name)
+			from, to = "", nil
+			reply(250, "OK")
+		case "RSET":
+			from, to = "", nil
+			reply(250, "OK")
+		case "NOOP":
+			reply(250, "OK")
+		case "QUIT":
+			reply(221, "bye")
+			return
+		default:
+			reply(502, "perintah tidak didukung")
+		}
+	}
+}
+
+// address mengambil alamat dari argumen "FROM:<a@b>" / "TO:<a@b>".
+func address(arg string) string {
+	_, addr, _ := strings.Cut(arg, ":")
+	addr, _, _ = strings.Cut(strings.TrimSpace(addr), " ")
+	return strings.Trim(addr, "<>")
+}
+
+func save(dir string, data []byte) (string, error) {
+	name := filepath.Join(dir, fmt.Sprintf("%s-%d.eml", time.Now().Format("20060102-150405"), counter.Add(1)))
+	return name, os.WriteFile(name, data, 0o644)
+}
+
+func subject(data []byte) string {
+	msg, err := mail.ReadMessage(bytes.NewReader(data))
+	if err != nil {
+		return ""
+	}
+	decoded, err := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+	if err != nil {
+		return msg.Header.Get("Subject")
+	}
+	return decoded
+}
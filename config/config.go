@@ -0,0 +1,125 @@
+package config
+
+import (
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+type Config struct {
+	Port                   string        `mapstructure:"PORT"`
+	DBConn                 string        `mapstructure:"DB_CONN"`
+	PriceSchedulerInterval time.Duration `mapstructure:"PRICE_SCHEDULER_INTERVAL"`
+	UploadDir              string        `mapstructure:"UPLOAD_DIR"`
+	MaxImageSize           int64         `mapstructure:"MAX_IMAGE_SIZE"`
+	MaxImportSize          int64         `mapstructure:"MAX_IMPORT_SIZE"`
+	// kalau true, semua stock adjustment harus di-approve dulu sebelum stok berubah
+	StockAdjustmentRequiresApproval bool `mapstructure:"STOCK_ADJUSTMENT_REQUIRES_APPROVAL"`
+	// user (header X-User) yang boleh approve/reject stock adjustment, dipisah koma
+	StockAdjustmentApprovers []string `mapstructure:"STOCK_ADJUSTMENT_APPROVERS"`
+	// jumlah hari penjualan yang dipakai untuk menghitung saran reorder
+	ReorderWindowDays int `mapstructure:"REORDER_WINDOW_DAYS"`
+	// kosong berarti notifikasi stok menipis tidak dikirim
+	LowStockWebhookURL string `mapstructure:"LOW_STOCK_WEBHOOK_URL"`
+	// zona waktu toko untuk batas hari di laporan (nama IANA, misalnya Asia/Jakarta), bisa ditimpa per outlet
+	StoreTimezone string `mapstructure:"STORE_TIMEZONE"`
+	// nama toko di kop laporan pdf, tenant selain default memakai nama tenant-nya
+	ReportBrandName string `mapstructure:"REPORT_BRAND_NAME"`
+	// seberapa sering scheduler mengecek laporan terjadwal yang sudah waktunya jalan
+	ReportSchedulerInterval time.Duration `mapstructure:"REPORT_SCHEDULER_INTERVAL"`
+	// folder penyimpanan hasil laporan terjadwal, tidak disajikan lewat /uploads
+	ReportDir string `mapstructure:"REPORT_DIR"`
+	// folder tujuan channel directory, kosong berarti channel tersebut dimatikan
+	ReportDeliveryDir string `mapstructure:"REPORT_DELIVERY_DIR"`
+	// host yang boleh dipakai channel webhook, dipisah koma (".example.com" untuk semua subdomain).
+	// Kosong berarti semua host publik boleh, alamat private/loopback/link-local selalu ditolak
+	ReportWebhookAllowedHosts []string `mapstructure:"REPORT_WEBHOOK_ALLOWED_HOSTS"`
+	// server SMTP untuk channel email, kosong berarti channel email dimatikan
+	SMTPHost     string `mapstructure:"SMTP_HOST"`
+	SMTPPort     int    `mapstructure:"SMTP_PORT"`
+	SMTPUsername string `mapstructure:"SMTP_USERNAME"`
+	SMTPPassword string `mapstructure:"SMTP_PASSWORD"`
+	SMTPFrom     string `mapstructure:"SMTP_FROM"`
+	// mode SaaS. Kalau false semua request masuk ke tenant default
+	MultiTenant bool `mapstructure:"MULTI_TENANT"`
+	// domain utama untuk membaca kode tenant dari subdomain, kosong berarti hanya lewat token/header
+	TenantBaseDomain string `mapstructure:"TENANT_BASE_DOMAIN"`
+	// default true: tenant hanya ditentukan lewat api token, header X-Tenant/subdomain hanya petunjuk
+	// dan ditolak 403 kalau tidak cocok dengan tenant pemilik token. Set false hanya untuk development lokal
+	TenantRequireToken bool `mapstructure:"TENANT_REQUIRE_TOKEN"`
+	// batas koneksi database untuk semua tenant sekaligus, dan berapa lama koneksi idle tenant dibiarkan terbuka
+	TenantPoolMaxConns    int           `mapstructure:"TENANT_POOL_MAX_CONNS"`
+	TenantPoolIdleTimeout time.Duration `mapstructure:"TENANT_POOL_IDLE_TIMEOUT"`
+	// kunci untuk endpoint provisioning tenant, kosong berarti endpoint tersebut dimatikan
+	AdminAPIKey string `mapstructure:"ADMIN_API_KEY"`
+}
+
+func Load() Config {
+	viper.AutomaticEnv()
+	viper.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+	viper.SetDefault("PRICE_SCHEDULER_INTERVAL", time.Minute)
+	viper.SetDefault("UPLOAD_DIR", "uploads")
+	viper.SetDefault("MAX_IMAGE_SIZE", 5<<20)
+	viper.SetDefault("MAX_IMPORT_SIZE", 20<<20)
+	viper.SetDefault("REORDER_WINDOW_DAYS", 30)
+	viper.SetDefault("STORE_TIMEZONE", "Asia/Jakarta")
+	viper.SetDefault("REPORT_BRAND_NAME", "Kasir")
+	viper.SetDefault("REPORT_SCHEDULER_INTERVAL", time.Minute)
+	viper.SetDefault("REPORT_DIR", "reports")
+	viper.SetDefault("SMTP_PORT", 587)
+	viper.SetDefault("SMTP_FROM", "kasir@localhost")
+	viper.SetDefault("TENANT_REQUIRE_TOKEN", true)
+	viper.SetDefault("TENANT_POOL_MAX_CONNS", 50)
+	viper.SetDefault("TENANT_POOL_IDLE_TIMEOUT", 2*time.Minute)
+
+	if _, err := os.Stat(".env"); err == nil {
+		viper.SetConfigFile(".env")
+		_ = viper.ReadInConfig()
+	}
+
+	return Config{
+		Port:                   viper.GetString("PORT"),
+		DBConn:                 viper.GetString("DB_CONN"),
+		PriceSchedulerInterval: viper.GetDuration("PRICE_SCHEDULER_INTERVAL"),
+		UploadDir:              viper.GetString("UPLOAD_DIR"),
+		MaxImageSize:           viper.GetInt64("MAX_IMAGE_SIZE"),
+		MaxImportSize:          viper.GetInt64("MAX_IMPORT_SIZE"),
+
+		StockAdjustmentRequiresApproval: viper.GetBool("STOCK_ADJUSTMENT_REQUIRES_APPROVAL"),
+		StockAdjustmentApprovers:        splitList(viper.GetString("STOCK_ADJUSTMENT_APPROVERS")),
+		ReorderWindowDays:               viper.GetInt("REORDER_WINDOW_DAYS"),
+		LowStockWebhookURL:              viper.GetString("LOW_STOCK_WEBHOOK_URL"),
+		StoreTimezone:                   viper.GetString("STORE_TIMEZONE"),
+		ReportBrandName:                 viper.GetString("REPORT_BRAND_NAME"),
+		ReportSchedulerInterval:         viper.GetDuration("REPORT_SCHEDULER_INTERVAL"),
+		ReportDir:                       viper.GetString("REPORT_DIR"),
+		ReportDeliveryDir:               viper.GetString("REPORT_DELIVERY_DIR"),
+		ReportWebhookAllowedHosts:       splitList(viper.GetString("REPORT_WEBHOOK_ALLOWED_HOSTS")),
+
+		SMTPHost:     viper.GetString("SMTP_HOST"),
+		SMTPPort:     viper.GetInt("SMTP_PORT"),
+		SMTPUsername: viper.GetString("SMTP_USERNAME"),
+		SMTPPassword: viper.GetString("SMTP_PASSWORD"),
+		SMTPFrom:     viper.GetString("SMTP_FROM"),
+
+		MultiTenant:        viper.GetBool("MULTI_TENANT"),
+		TenantBaseDomain:   viper.GetString("TENANT_BASE_DOMAIN"),
+		TenantRequireToken: viper.GetBool("TENANT_REQUIRE_TOKEN"),
+		AdminAPIKey:        viper.GetString("ADMIN_API_KEY"),
+
+		TenantPoolMaxConns:    viper.GetInt("TENANT_POOL_MAX_CONNS"),
+		TenantPoolIdleTimeout: viper.GetDuration("TENANT_POOL_IDLE_TIMEOUT"),
+	}
+}
+
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,204 @@
+package cron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Parser cron 5 field (menit jam tanggal bulan hari) tanpa dependency tambahan. Mendukung *, daftar (1,15),
+// rentang (1-5), step (*/15, 8-18/2), nama bulan/hari (jan, mon) dan singkatan @hourly, @daily, @weekly, @monthly.
+// Seperti cron biasa, kalau tanggal dan hari sama-sama dibatasi, jadwal jalan kalau salah satunya cocok.
+
+var macros = map[string]string{
+	"@hourly":   "0 * * * *",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@weekly":   "0 0 * * 0",
+	"@monthly":  "0 0 1 * *",
+	"@yearly":   "0 0 1 1 *",
+}
+
+var (
+	monthNames = map[string]int{"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6, "jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12}
+	dayNames   = map[string]int{"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6}
+)
+
+// Schedule menyimpan nilai yang diizinkan per field sebagai bitmask.
+type Schedule struct {
+	minute, hour, dom, month, dow uint64
+	// true kalau field diawali "*" (termasuk "*/2"), dipakai untuk aturan "salah satu cocok" dan jam DST
+	hourAny, domAny, dowAny bool
+}
+
+func Parse(expr string) (*Schedule, error) {
+	expr = strings.TrimSpace(expr)
+	if m, ok := macros[strings.ToLower(expr)]; ok {
+		expr = m
+	}
+
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("cron harus 5 field (menit jam tanggal bulan hari), dapat %d", len(fields))
+	}
+
+	var s Schedule
+	var err error
+	if s.minute, err = parseField(fields[0], 0, 59, nil); err != nil {
+		return nil, fmt.Errorf("field menit: %w", err)
+	}
+	if s.hour, err = parseField(fields[1], 0, 23, nil); err != nil {
+		return nil, fmt.Errorf("field jam: %w", err)
+	}
+	if s.dom, err = parseField(fields[2], 1, 31, nil); err != nil {
+		return nil, fmt.Errorf("field tanggal: %w", err)
+	}
+	if s.month, err = parseField(fields[3], 1, 12, monthNames); err != nil {
+		return nil, fmt.Errorf("field bulan: %w", err)
+	}
+	//hari 7 juga berarti Minggu
+	if s.dow, err = parseField(fields[4], 0, 7, dayNames); err != nil {
+		return nil, fmt.Errorf("field hari: %w", err)
+	}
+	if s.dow&(1<<7) != 0 {
+		s.dow |= 1
+	}
+	s.hourAny = strings.HasPrefix(fields[1], "*")
+	s.domAny = strings.HasPrefix(fields[2], "*") || fields[2] == "?"
+	s.dowAny = strings.HasPrefix(fields[4], "*") || fields[4] == "?"
+
+	return &s, nil
+}
+
+func parseField(field string, min int, max int, names map[string]int) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		rangePart, step := part, 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			var err error
+			rangePart = part[:i]
+			step, err = strconv.Atoi(part[i+1:])
+			if err != nil || step < 1 {
+				return 0, fmt.Errorf("step %q tidak valid", part[i+1:])
+			}
+		}
+
+		low, high := min, max
+		switch {
+		case rangePart == "*" || rangePart == "?":
+		case strings.Contains(rangePart, "-"):
+			bounds := strings.SplitN(rangePart, "-", 2)
+			var err error
+			if low, err = parseValue(bounds[0], min, max, names); err != nil {
+				return 0, err
+			}
+			if high, err = parseValue(bounds[1], min, max, names); err != nil {
+				return 0, err
+			}
+			if low > high {
+				return 0, fmt.Errorf("rentang %q terbalik", rangePart)
+			}
+		default:
+			var err error
+			if low, err = parseValue(rangePart, min, max, names); err != nil {
+				return 0, err
+			}
+			//"5/10" berarti mulai 5 sampai akhir dengan step 10, "5" saja berarti tepat 5
+			if step == 1 {
+				high = low
+			}
+		}
+
+		for v := low; v <= high; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+	return bits, nil
+}
+
+func parseValue(s string, min int, max int, names map[string]int) (int, error) {
+	if v, ok := names[strings.ToLower(s)]; ok {
+		return v, nil
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("nilai %q tidak valid", s)
+	}
+	if v < min || v > max {
+		return 0, fmt.Errorf("nilai %d di luar rentang %d-%d", v, min, max)
+	}
+	return v, nil
+}
+
+// Next mengembalikan waktu jadwal pertama setelah t, di zona waktu t. Nol kalau tidak ada jadwal dalam
+// 5 tahun ke depan (misalnya 30 Februari). Seperti cron biasa, jadwal dengan jam tertentu yang jatuh di jam
+// yang hilang saat DST maju dijalankan di awal jam berikutnya, dan di jam yang terulang saat DST mundur
+// hanya dijalankan sekali. Jadwal dengan field jam "*" tetap mengikuti waktu yang benar-benar berjalan.
+func (s *Schedule) Next(t time.Time) time.Time {
+	loc := t.Location()
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(5, 0, 0)
+
+	for t.Before(limit) {
+		if s.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if !s.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if s.hour&(1<<uint(t.Hour())) == 0 {
+			//pakai Add, bukan time.Date, supaya jam yang terulang saat DST tetap maju. Truncate(time.Hour)
+			//tidak dipakai karena salah untuk zona dengan offset setengah jam
+			next := t.Add(time.Duration(60-t.Minute()) * time.Minute)
+			if !s.hourAny && next.Day() == t.Day() && s.hour&hourRange(t.Hour()+1, next.Hour()) != 0 {
+				return next
+			}
+			t = next
+			continue
+		}
+		if s.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		if !s.hourAny && repeatedWallClock(t) {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+// hourRange adalah bitmask jam from sampai sebelum to. Kosong kalau tidak ada jam yang terlewat.
+func hourRange(from int, to int) uint64 {
+	var bits uint64
+	for h := from; h < to; h++ {
+		bits |= 1 << uint(h)
+	}
+	return bits
+}
+
+// repeatedWallClock true kalau jam dinding t sudah pernah terjadi sebelumnya karena DST mundur.
+func repeatedWallClock(t time.Time) bool {
+	_, offset := t.Zone()
+	_, earlierOffset := t.Add(-3 * time.Hour).Zone()
+	shift := time.Duration(earlierOffset-offset) * time.Second
+	if shift <= 0 {
+		return false
+	}
+	//jam dinding yang sama sebelum DST mundur masih memakai offset lama
+	_, offset = t.Add(-shift).Zone()
+	return offset == earlierOffset
+}
+
+func (s *Schedule) dayMatches(t time.Time) bool {
+	dom := s.dom&(1<<uint(t.Day())) != 0
+	dow := s.dow&(1<<uint(t.Weekday())) != 0
+	if s.domAny || s.dowAny {
+		return dom && dow
+	}
+	return dom || dow
+}
@@ -0,0 +1,102 @@
+package cron
+
+import (
+	"testing"
+	"time"
+	_ "time/tzdata"
+)
+
+func TestParseInvalid(t *testing.T) {
+	tests := []string{
+		"",
+		"* * * *",
+		"* * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * 32 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"5-1 * * * *",
+		"*/0 * * * *",
+		"*/x * * * *",
+		"a * * * *",
+		"* * * foo *",
+		"* * * * funday",
+		"@every",
+	}
+
+	for _, expr := range tests {
+		if _, err := Parse(expr); err == nil {
+			t.Errorf("Parse(%q) tidak error", expr)
+		}
+	}
+}
+
+func TestNext(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		expr string
+		from time.Time
+		want time.Time
+	}{
+		{"step menit", "*/15 * * * *", date(time.UTC, 2026, 1, 1, 0, 0), date(time.UTC, 2026, 1, 1, 0, 15)},
+		{"step dengan awal", "5/20 * * * *", date(time.UTC, 2026, 1, 1, 0, 30), date(time.UTC, 2026, 1, 1, 0, 45)},
+		{"step dalam rentang", "0 8-18/2 * * *", date(time.UTC, 2026, 1, 1, 9, 0), date(time.UTC, 2026, 1, 1, 10, 0)},
+		{"step dalam rentang lewat batas", "0 8-18/4 * * *", date(time.UTC, 2026, 1, 1, 16, 0), date(time.UTC, 2026, 1, 2, 8, 0)},
+		{"daftar", "0 9,17 * * *", date(time.UTC, 2026, 1, 1, 9, 0), date(time.UTC, 2026, 1, 1, 17, 0)},
+		{"selalu setelah from", "0 9 * * *", date(time.UTC, 2026, 1, 1, 9, 0), date(time.UTC, 2026, 1, 2, 9, 0)},
+		{"detik dibulatkan", "* * * * *", time.Date(2026, 1, 1, 9, 0, 30, 0, time.UTC), date(time.UTC, 2026, 1, 1, 9, 1)},
+		{"nama hari", "0 9 * * mon-fri", date(time.UTC, 2026, 1, 2, 10, 0), date(time.UTC, 2026, 1, 5, 9, 0)},
+		{"nama bulan", "0 0 1 jan,JUL *", date(time.UTC, 2026, 1, 1, 0, 0), date(time.UTC, 2026, 7, 1, 0, 0)},
+		{"7 adalah minggu", "0 0 * * 7", date(time.UTC, 2026, 1, 1, 0, 0), date(time.UTC, 2026, 1, 4, 0, 0)},
+		{"0 adalah minggu", "0 0 * * 0", date(time.UTC, 2026, 1, 1, 0, 0), date(time.UTC, 2026, 1, 4, 0, 0)},
+		{"rentang sampai 7", "0 0 * * 6-7", date(time.UTC, 2026, 1, 1, 0, 0), date(time.UTC, 2026, 1, 3, 0, 0)},
+		{"weekly", "@weekly", date(time.UTC, 2026, 1, 1, 0, 0), date(time.UTC, 2026, 1, 4, 0, 0)},
+		{"monthly", "@monthly", date(time.UTC, 2026, 1, 15, 0, 0), date(time.UTC, 2026, 2, 1, 0, 0)},
+		{"tanggal atau hari", "0 0 13 * fri", date(time.UTC, 2026, 1, 1, 0, 0), date(time.UTC, 2026, 1, 2, 0, 0)},
+		{"step tanggal dan hari", "0 0 */2 * fri", date(time.UTC, 2026, 1, 1, 0, 0), date(time.UTC, 2026, 1, 9, 0, 0)},
+		{"tanggal dan step hari", "0 0 10 * */3", date(time.UTC, 2026, 1, 1, 0, 0), date(time.UTC, 2026, 1, 10, 0, 0)},
+		{"tanggal 31", "0 0 31 * *", date(time.UTC, 2026, 4, 1, 0, 0), date(time.UTC, 2026, 5, 31, 0, 0)},
+		{"29 februari", "0 0 29 2 *", date(time.UTC, 2026, 1, 1, 0, 0), date(time.UTC, 2028, 2, 29, 0, 0)},
+		{"30 februari", "0 0 30 2 *", date(time.UTC, 2026, 1, 1, 0, 0), time.Time{}},
+		{"zona waktu from", "0 8 * * *", date(newYork, 2026, 1, 1, 9, 0), date(newYork, 2026, 1, 2, 8, 0)},
+
+		//2026-03-08 02:00 EST langsung menjadi 03:00 EDT
+		{"dst maju jam hilang", "30 2 * * *", date(newYork, 2026, 3, 8, 0, 0), date(newYork, 2026, 3, 8, 3, 0)},
+		{"dst maju setelah jam hilang", "30 2 * * *", date(newYork, 2026, 3, 8, 3, 0), date(newYork, 2026, 3, 9, 2, 30)},
+		{"dst maju jam lain", "30 3 * * *", date(newYork, 2026, 3, 8, 0, 0), date(newYork, 2026, 3, 8, 3, 30)},
+		{"dst maju tiap 30 menit", "*/30 * * * *", date(newYork, 2026, 3, 8, 1, 45), date(newYork, 2026, 3, 8, 3, 0)},
+
+		//2026-11-01 02:00 EDT kembali menjadi 01:00 EST, jam 01:xx terjadi dua kali
+		{"dst mundur pertama", "30 1 * * *", date(newYork, 2026, 11, 1, 0, 0), time.Date(2026, 11, 1, 5, 30, 0, 0, time.UTC)},
+		{"dst mundur tidak diulang", "30 1 * * *", time.Date(2026, 11, 1, 5, 30, 0, 0, time.UTC).In(newYork), date(newYork, 2026, 11, 2, 1, 30)},
+		{"dst mundur tiap 30 menit", "0,30 * * * *", time.Date(2026, 11, 1, 5, 30, 0, 0, time.UTC).In(newYork), time.Date(2026, 11, 1, 6, 0, 0, 0, time.UTC)},
+		{"dst mundur jam berikutnya", "0 2 * * *", date(newYork, 2026, 11, 1, 0, 0), time.Date(2026, 11, 1, 7, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := Parse(tt.expr)
+			if err != nil {
+				t.Fatalf("Parse(%q): %v", tt.expr, err)
+			}
+			got := s.Next(tt.from)
+			if !got.Equal(tt.want) {
+				t.Errorf("Next(%s) = %s, want %s", tt.from, got, tt.want)
+			}
+			if !got.IsZero() && got.Location() != tt.from.Location() {
+				t.Errorf("zona waktu hasil %s, want %s", got.Location(), tt.from.Location())
+			}
+		})
+	}
+}
+
+func date(loc *time.Location, year int, month time.Month, day int, hour int, minute int) time.Time {
+	return time.Date(year, month, day, hour, minute, 0, 0, loc)
+}
@@ -0,0 +1,25 @@
+CREATE TABLE IF NOT EXISTS product_price_histories (
+    id         SERIAL PRIMARY KEY,
+    product_id INT NOT NULL REFERENCES products(id),
+    old_price  INT NOT NULL,
+    new_price  INT NOT NULL,
+    changed_by VARCHAR(100) NOT NULL,
+    changed_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_product_price_histories_product ON product_price_histories (product_id, changed_at DESC);
+
+CREATE TABLE IF NOT EXISTS scheduled_price_changes (
+    id           SERIAL PRIMARY KEY,
+    product_id   INT NOT NULL REFERENCES products(id),
+    new_price    INT NOT NULL,
+    effective_at TIMESTAMP NOT NULL,
+    status       VARCHAR(20) NOT NULL DEFAULT 'pending',
+    created_by   VARCHAR(100) NOT NULL,
+    created_at   TIMESTAMP NOT NULL DEFAULT NOW(),
+    applied_at   TIMESTAMP,
+    -- alasan kalau status failed
+    error        TEXT NOT NULL DEFAULT ''
+);
+
+CREATE INDEX IF NOT EXISTS idx_scheduled_price_changes_due ON scheduled_price_changes (status, effective_at);
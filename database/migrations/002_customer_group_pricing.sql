@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS customer_groups (
+    id          SERIAL PRIMARY KEY,
+    name        VARCHAR(100) NOT NULL UNIQUE,
+    description TEXT NOT NULL DEFAULT ''
+);
+
+CREATE TABLE IF NOT EXISTS product_price_tiers (
+    id                SERIAL PRIMARY KEY,
+    product_id        INT NOT NULL REFERENCES products(id),
+    customer_group_id INT REFERENCES customer_groups(id),
+    min_quantity      INT NOT NULL DEFAULT 1 CHECK (min_quantity >= 1),
+    price             INT NOT NULL CHECK (price >= 0)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_product_price_tiers_unique
+    ON product_price_tiers (product_id, COALESCE(customer_group_id, 0), min_quantity);
+
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS customer_group_id INT REFERENCES customer_groups(id);
+ALTER TABLE transaction_details ADD COLUMN IF NOT EXISTS unit_price INT NOT NULL DEFAULT 0;
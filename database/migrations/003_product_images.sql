@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS product_images (
+    id            SERIAL PRIMARY KEY,
+    product_id    INT NOT NULL REFERENCES products(id),
+    storage_key   VARCHAR(255) NOT NULL,
+    thumbnail_key VARCHAR(255) NOT NULL,
+    url           VARCHAR(500) NOT NULL,
+    thumbnail_url VARCHAR(500) NOT NULL,
+    content_type  VARCHAR(50) NOT NULL,
+    size_bytes    BIGINT NOT NULL,
+    width         INT NOT NULL,
+    height        INT NOT NULL,
+    is_primary    BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at    TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_product_images_product ON product_images (product_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_product_images_primary ON product_images (product_id) WHERE is_primary;
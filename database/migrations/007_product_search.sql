@@ -0,0 +1,57 @@
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- batas operator <% untuk pencarian produk, default pg_trgm 0.6 terlalu ketat untuk salah ketik.
+-- Berlaku untuk koneksi baru ke database ini.
+DO $$
+BEGIN
+    EXECUTE format('ALTER DATABASE %I SET pg_trgm.word_similarity_threshold = 0.3', current_database());
+END $$;
+
+ALTER TABLE products ADD COLUMN IF NOT EXISTS barcode VARCHAR(64);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_products_barcode ON products (barcode) WHERE barcode IS NOT NULL;
+
+-- search_vector berisi nama (A), sku + barcode (B) dan nama kategori (C). Diisi trigger, bukan kolom generated,
+-- karena nama kategori ada di tabel lain.
+ALTER TABLE products ADD COLUMN IF NOT EXISTS search_vector tsvector;
+
+CREATE OR REPLACE FUNCTION product_search_vector(p_name TEXT, p_sku TEXT, p_barcode TEXT, p_category_id INT) RETURNS tsvector AS $$
+    SELECT setweight(to_tsvector('simple', COALESCE(p_name, '')), 'A') ||
+           setweight(to_tsvector('simple', COALESCE(p_sku, '') || ' ' || COALESCE(p_barcode, '')), 'B') ||
+           setweight(to_tsvector('simple', COALESCE((SELECT name FROM categories WHERE id = p_category_id), '')), 'C')
+$$ LANGUAGE sql STABLE;
+
+CREATE OR REPLACE FUNCTION products_search_vector_trigger() RETURNS trigger AS $$
+BEGIN
+    NEW.search_vector := product_search_vector(NEW.name, NEW.sku, NEW.barcode, NEW.category_id);
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trg_products_search_vector ON products;
+CREATE TRIGGER trg_products_search_vector
+    BEFORE INSERT OR UPDATE OF name, sku, barcode, category_id ON products
+    FOR EACH ROW EXECUTE FUNCTION products_search_vector_trigger();
+
+-- kategori yang diganti namanya ikut mengubah search_vector produknya
+CREATE OR REPLACE FUNCTION categories_search_vector_trigger() RETURNS trigger AS $$
+BEGIN
+    UPDATE products SET search_vector = product_search_vector(name, sku, barcode, category_id)
+    WHERE category_id = NEW.id;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trg_categories_search_vector ON categories;
+CREATE TRIGGER trg_categories_search_vector
+    AFTER UPDATE OF name ON categories
+    FOR EACH ROW WHEN (OLD.name IS DISTINCT FROM NEW.name)
+    EXECUTE FUNCTION categories_search_vector_trigger();
+
+UPDATE products SET search_vector = product_search_vector(name, sku, barcode, category_id)
+WHERE search_vector IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_products_search_vector ON products USING GIN (search_vector);
+CREATE INDEX IF NOT EXISTS idx_products_name_trgm ON products USING GIN (name gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_products_sku_trgm ON products USING GIN (sku gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_categories_name_trgm ON categories USING GIN (name gin_trgm_ops);
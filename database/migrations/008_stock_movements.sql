@@ -0,0 +1,34 @@
+CREATE TABLE IF NOT EXISTS stock_movements (
+    id             BIGSERIAL PRIMARY KEY,
+    product_id     INT NOT NULL REFERENCES products(id),
+    movement_type  VARCHAR(20) NOT NULL,
+    quantity       INT NOT NULL,
+    stock_after    INT NOT NULL,
+    reference_type VARCHAR(30) NOT NULL DEFAULT '',
+    reference_id   INT,
+    note           TEXT NOT NULL DEFAULT '',
+    created_by     VARCHAR(100) NOT NULL,
+    created_at     TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_stock_movements_product ON stock_movements (product_id, created_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_stock_movements_reference ON stock_movements (reference_type, reference_id);
+
+-- ledger bersifat append-only
+CREATE OR REPLACE FUNCTION stock_movements_append_only() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'stock_movements is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trg_stock_movements_append_only ON stock_movements;
+CREATE TRIGGER trg_stock_movements_append_only
+    BEFORE UPDATE OR DELETE ON stock_movements
+    FOR EACH ROW EXECUTE FUNCTION stock_movements_append_only();
+
+-- saldo awal supaya stok yang sudah ada sebelum ledger tetap bisa direkonsiliasi
+INSERT INTO stock_movements (product_id, movement_type, quantity, stock_after, reference_type, note, created_by)
+SELECT p.id, 'adjustment', p.stock, p.stock, 'opening_balance', 'saldo awal ledger', 'system'
+FROM products AS p
+WHERE p.stock <> 0
+  AND NOT EXISTS (SELECT 1 FROM stock_movements AS sm WHERE sm.product_id = p.id);
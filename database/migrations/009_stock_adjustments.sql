@@ -0,0 +1,22 @@
+CREATE TABLE IF NOT EXISTS stock_adjustments (
+    id          SERIAL PRIMARY KEY,
+    status      VARCHAR(20) NOT NULL DEFAULT 'pending',
+    note        TEXT NOT NULL DEFAULT '',
+    created_by  VARCHAR(100) NOT NULL,
+    created_at  TIMESTAMP NOT NULL DEFAULT NOW(),
+    reviewed_by VARCHAR(100) NOT NULL DEFAULT '',
+    reviewed_at TIMESTAMP,
+    review_note TEXT NOT NULL DEFAULT ''
+);
+
+CREATE TABLE IF NOT EXISTS stock_adjustment_lines (
+    id            SERIAL PRIMARY KEY,
+    adjustment_id INT NOT NULL REFERENCES stock_adjustments(id),
+    product_id    INT NOT NULL REFERENCES products(id),
+    quantity      INT NOT NULL CHECK (quantity <> 0),
+    reason        VARCHAR(20) NOT NULL,
+    note          TEXT NOT NULL DEFAULT ''
+);
+
+CREATE INDEX IF NOT EXISTS idx_stock_adjustments_status ON stock_adjustments (status, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_stock_adjustment_lines_adjustment ON stock_adjustment_lines (adjustment_id);
@@ -0,0 +1,43 @@
+CREATE TABLE IF NOT EXISTS stock_takes (
+    id           SERIAL PRIMARY KEY,
+    status       VARCHAR(20) NOT NULL DEFAULT 'open',
+    note         TEXT NOT NULL DEFAULT '',
+    created_by   VARCHAR(100) NOT NULL,
+    created_at   TIMESTAMP NOT NULL DEFAULT NOW(),
+    finalized_by VARCHAR(100) NOT NULL DEFAULT '',
+    finalized_at TIMESTAMP
+);
+
+-- kosong berarti semua kategori
+CREATE TABLE IF NOT EXISTS stock_take_categories (
+    stock_take_id INT NOT NULL REFERENCES stock_takes(id),
+    category_id   INT NOT NULL REFERENCES categories(id),
+    PRIMARY KEY (stock_take_id, category_id)
+);
+
+-- expected_quantity dibekukan saat sesi dimulai, counted_quantity NULL berarti belum dihitung
+CREATE TABLE IF NOT EXISTS stock_take_items (
+    id                SERIAL PRIMARY KEY,
+    stock_take_id     INT NOT NULL REFERENCES stock_takes(id),
+    product_id        INT NOT NULL REFERENCES products(id),
+    expected_quantity INT NOT NULL,
+    counted_quantity  INT,
+    unit_price        INT NOT NULL,
+    updated_at        TIMESTAMP,
+    UNIQUE (stock_take_id, product_id)
+);
+
+-- log setiap input hitungan, satu baris per kiriman dari device
+CREATE TABLE IF NOT EXISTS stock_take_counts (
+    id            BIGSERIAL PRIMARY KEY,
+    stock_take_id INT NOT NULL REFERENCES stock_takes(id),
+    product_id    INT NOT NULL REFERENCES products(id),
+    mode          VARCHAR(10) NOT NULL,
+    quantity      INT NOT NULL,
+    device        VARCHAR(100) NOT NULL DEFAULT '',
+    counted_by    VARCHAR(100) NOT NULL,
+    created_at    TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_stock_takes_status ON stock_takes (status, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_stock_take_counts_take ON stock_take_counts (stock_take_id, product_id);
@@ -0,0 +1,62 @@
+CREATE TABLE IF NOT EXISTS suppliers (
+    id           SERIAL PRIMARY KEY,
+    name         VARCHAR(255) NOT NULL,
+    contact_name VARCHAR(255) NOT NULL DEFAULT '',
+    phone        VARCHAR(50) NOT NULL DEFAULT '',
+    email        VARCHAR(255) NOT NULL DEFAULT '',
+    address      TEXT NOT NULL DEFAULT '',
+    created_at   TIMESTAMP NOT NULL DEFAULT NOW(),
+    archived_at  TIMESTAMP
+);
+
+-- harga pokok rata-rata tertimbang, dihitung ulang setiap barang diterima
+ALTER TABLE products ADD COLUMN IF NOT EXISTS cost_price INT NOT NULL DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS purchase_orders (
+    id            SERIAL PRIMARY KEY,
+    supplier_id   INT NOT NULL REFERENCES suppliers(id),
+    status        VARCHAR(20) NOT NULL DEFAULT 'draft',
+    expected_date DATE,
+    note          TEXT NOT NULL DEFAULT '',
+    total_amount  INT NOT NULL DEFAULT 0,
+    created_by    VARCHAR(100) NOT NULL,
+    created_at    TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at    TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS purchase_order_lines (
+    id                SERIAL PRIMARY KEY,
+    purchase_order_id INT NOT NULL REFERENCES purchase_orders(id),
+    product_id        INT NOT NULL REFERENCES products(id),
+    quantity          INT NOT NULL CHECK (quantity > 0),
+    received_quantity INT NOT NULL DEFAULT 0 CHECK (received_quantity >= 0),
+    unit_cost         INT NOT NULL CHECK (unit_cost >= 0),
+    subtotal          INT NOT NULL,
+    UNIQUE (purchase_order_id, product_id)
+);
+
+-- satu baris per kedatangan barang, PO boleh diterima bertahap
+CREATE TABLE IF NOT EXISTS goods_receipts (
+    id                SERIAL PRIMARY KEY,
+    purchase_order_id INT REFERENCES purchase_orders(id),
+    supplier_id       INT NOT NULL REFERENCES suppliers(id),
+    reference         VARCHAR(100) NOT NULL DEFAULT '',
+    note              TEXT NOT NULL DEFAULT '',
+    total_amount      INT NOT NULL DEFAULT 0,
+    received_by       VARCHAR(100) NOT NULL,
+    received_at       TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS goods_receipt_lines (
+    id               SERIAL PRIMARY KEY,
+    goods_receipt_id INT NOT NULL REFERENCES goods_receipts(id),
+    product_id       INT NOT NULL REFERENCES products(id),
+    quantity         INT NOT NULL CHECK (quantity > 0),
+    unit_cost        INT NOT NULL CHECK (unit_cost >= 0),
+    subtotal         INT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_purchase_orders_status ON purchase_orders (status, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_purchase_orders_supplier ON purchase_orders (supplier_id);
+CREATE INDEX IF NOT EXISTS idx_goods_receipts_purchase_order ON goods_receipts (purchase_order_id);
+CREATE INDEX IF NOT EXISTS idx_goods_receipt_lines_receipt ON goods_receipt_lines (goods_receipt_id);
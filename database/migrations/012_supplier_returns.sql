@@ -0,0 +1,22 @@
+CREATE TABLE IF NOT EXISTS supplier_returns (
+    id           SERIAL PRIMARY KEY,
+    supplier_id  INT NOT NULL REFERENCES suppliers(id),
+    reference    VARCHAR(100) NOT NULL DEFAULT '',
+    note         TEXT NOT NULL DEFAULT '',
+    total_amount INT NOT NULL DEFAULT 0,
+    created_by   VARCHAR(100) NOT NULL,
+    created_at   TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS supplier_return_lines (
+    id                 SERIAL PRIMARY KEY,
+    supplier_return_id INT NOT NULL REFERENCES supplier_returns(id),
+    product_id         INT NOT NULL REFERENCES products(id),
+    quantity           INT NOT NULL CHECK (quantity > 0),
+    unit_cost          INT NOT NULL CHECK (unit_cost >= 0),
+    subtotal           INT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_goods_receipts_supplier ON goods_receipts (supplier_id, received_at DESC);
+CREATE INDEX IF NOT EXISTS idx_supplier_returns_supplier ON supplier_returns (supplier_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_supplier_return_lines_return ON supplier_return_lines (supplier_return_id);
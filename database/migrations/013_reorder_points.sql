@@ -0,0 +1,5 @@
+ALTER TABLE products ADD COLUMN IF NOT EXISTS reorder_point INT NOT NULL DEFAULT 0 CHECK (reorder_point >= 0);
+ALTER TABLE products ADD COLUMN IF NOT EXISTS reorder_quantity INT NOT NULL DEFAULT 0 CHECK (reorder_quantity >= 0);
+
+CREATE INDEX IF NOT EXISTS idx_products_low_stock ON products (category_id) WHERE reorder_point > 0 AND stock <= reorder_point;
+CREATE INDEX IF NOT EXISTS idx_transactions_created_at ON transactions (created_at);
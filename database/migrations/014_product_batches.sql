@@ -0,0 +1,30 @@
+-- stok per batch/lot. products.stock tetap total, selisih dengan jumlah batch adalah stok tanpa batch
+CREATE TABLE IF NOT EXISTS product_batches (
+    id                SERIAL PRIMARY KEY,
+    product_id        INT NOT NULL REFERENCES products(id),
+    batch_number      VARCHAR(100) NOT NULL,
+    expiry_date       DATE,
+    quantity          INT NOT NULL DEFAULT 0 CHECK (quantity >= 0),
+    received_quantity INT NOT NULL DEFAULT 0,
+    created_at        TIMESTAMP NOT NULL DEFAULT NOW(),
+    UNIQUE (product_id, batch_number)
+);
+
+CREATE INDEX IF NOT EXISTS idx_product_batches_fefo ON product_batches (product_id, expiry_date, id) WHERE quantity > 0;
+CREATE INDEX IF NOT EXISTS idx_product_batches_expiry ON product_batches (expiry_date) WHERE quantity > 0;
+
+-- batch mana saja yang terpakai oleh setiap baris transaksi
+CREATE TABLE IF NOT EXISTS transaction_detail_batches (
+    id                    SERIAL PRIMARY KEY,
+    transaction_detail_id INT NOT NULL REFERENCES transaction_details(id),
+    batch_id              INT NOT NULL REFERENCES product_batches(id),
+    quantity              INT NOT NULL CHECK (quantity > 0)
+);
+
+CREATE INDEX IF NOT EXISTS idx_transaction_detail_batches_detail ON transaction_detail_batches (transaction_detail_id);
+
+ALTER TABLE goods_receipt_lines ADD COLUMN IF NOT EXISTS batch_id INT REFERENCES product_batches(id);
+ALTER TABLE stock_adjustment_lines ADD COLUMN IF NOT EXISTS batch_id INT REFERENCES product_batches(id);
+
+-- batch yang diretur ke supplier, kosong berarti batch dikurangi FEFO
+ALTER TABLE supplier_return_lines ADD COLUMN IF NOT EXISTS batch_id INT REFERENCES product_batches(id);
@@ -0,0 +1,42 @@
+-- harga rata-rata (products.cost_price) sesudah setiap pergerakan, untuk valuasi weighted average per tanggal.
+-- baris lama dibiarkan NULL karena ledger append-only, laporan memakai cost_price saat ini sebagai gantinya
+ALTER TABLE stock_movements ADD COLUMN IF NOT EXISTS average_cost INT;
+
+-- lapisan harga pokok untuk FIFO, satu baris per barang masuk
+CREATE TABLE IF NOT EXISTS cost_layers (
+    id                 BIGSERIAL PRIMARY KEY,
+    product_id         INT NOT NULL REFERENCES products(id),
+    movement_id        BIGINT REFERENCES stock_movements(id),
+    unit_cost          INT NOT NULL CHECK (unit_cost >= 0),
+    quantity           INT NOT NULL CHECK (quantity > 0),
+    remaining_quantity INT NOT NULL CHECK (remaining_quantity >= 0),
+    created_at         TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_cost_layers_fifo ON cost_layers (product_id, created_at, id) WHERE remaining_quantity > 0;
+
+-- pemakaian lapisan oleh barang keluar. cost_layer_id NULL berarti stok sudah habis (minus),
+-- unit tersebut dinilai dengan harga rata-rata
+CREATE TABLE IF NOT EXISTS cost_layer_consumptions (
+    id            BIGSERIAL PRIMARY KEY,
+    cost_layer_id BIGINT REFERENCES cost_layers(id),
+    product_id    INT NOT NULL REFERENCES products(id),
+    movement_id   BIGINT NOT NULL REFERENCES stock_movements(id),
+    quantity      INT NOT NULL CHECK (quantity > 0),
+    unit_cost     INT NOT NULL,
+    created_at    TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_cost_layer_consumptions_layer ON cost_layer_consumptions (cost_layer_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_cost_layer_consumptions_movement ON cost_layer_consumptions (movement_id);
+
+-- HPP per baris penjualan, dicatat saat checkout dengan kedua metode
+ALTER TABLE transaction_details ADD COLUMN IF NOT EXISTS cogs_fifo INT NOT NULL DEFAULT 0;
+ALTER TABLE transaction_details ADD COLUMN IF NOT EXISTS cogs_average INT NOT NULL DEFAULT 0;
+
+-- lapisan awal dari stok yang sudah ada, dinilai dengan cost_price saat ini
+INSERT INTO cost_layers (product_id, unit_cost, quantity, remaining_quantity)
+SELECT p.id, p.cost_price, p.stock, p.stock
+FROM products AS p
+WHERE p.stock > 0
+  AND NOT EXISTS (SELECT 1 FROM cost_layers AS cl WHERE cl.product_id = p.id);
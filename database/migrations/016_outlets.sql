@@ -0,0 +1,87 @@
+-- outlet bisa toko (store) atau gudang (warehouse). Data sebelum multi-outlet masuk ke outlet default
+CREATE TABLE IF NOT EXISTS outlets (
+    id          SERIAL PRIMARY KEY,
+    code        VARCHAR(20) NOT NULL UNIQUE,
+    name        VARCHAR(255) NOT NULL,
+    outlet_type VARCHAR(20) NOT NULL DEFAULT 'store',
+    address     TEXT NOT NULL DEFAULT '',
+    is_default  BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at  TIMESTAMP NOT NULL DEFAULT NOW(),
+    archived_at TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_outlets_single_default ON outlets (is_default) WHERE is_default;
+
+INSERT INTO outlets (code, name, is_default)
+SELECT 'PUSAT', 'Outlet Pusat', TRUE
+WHERE NOT EXISTS (SELECT 1 FROM outlets WHERE is_default);
+
+-- stok per outlet. products.stock tetap dipakai sebagai total semua outlet
+CREATE TABLE IF NOT EXISTS outlet_stocks (
+    outlet_id  INT NOT NULL REFERENCES outlets(id),
+    product_id INT NOT NULL REFERENCES products(id),
+    stock      INT NOT NULL DEFAULT 0,
+    PRIMARY KEY (outlet_id, product_id)
+);
+
+INSERT INTO outlet_stocks (outlet_id, product_id, stock)
+SELECT (SELECT id FROM outlets WHERE is_default), p.id, p.stock
+FROM products AS p
+WHERE p.stock <> 0
+ON CONFLICT (outlet_id, product_id) DO NOTHING;
+
+-- harga khusus outlet, menggantikan products.price sebagai harga dasar di outlet tersebut
+CREATE TABLE IF NOT EXISTS outlet_prices (
+    outlet_id  INT NOT NULL REFERENCES outlets(id),
+    product_id INT NOT NULL REFERENCES products(id),
+    price      INT NOT NULL CHECK (price >= 0),
+    updated_by VARCHAR(100) NOT NULL,
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (outlet_id, product_id)
+);
+
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS outlet_id INT REFERENCES outlets(id);
+UPDATE transactions SET outlet_id = (SELECT id FROM outlets WHERE is_default) WHERE outlet_id IS NULL;
+ALTER TABLE transactions ALTER COLUMN outlet_id SET NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_transactions_outlet_created_at ON transactions (outlet_id, created_at);
+
+ALTER TABLE stock_adjustments ADD COLUMN IF NOT EXISTS outlet_id INT REFERENCES outlets(id);
+UPDATE stock_adjustments SET outlet_id = (SELECT id FROM outlets WHERE is_default) WHERE outlet_id IS NULL;
+ALTER TABLE stock_adjustments ALTER COLUMN outlet_id SET NOT NULL;
+
+ALTER TABLE stock_takes ADD COLUMN IF NOT EXISTS outlet_id INT REFERENCES outlets(id);
+UPDATE stock_takes SET outlet_id = (SELECT id FROM outlets WHERE is_default) WHERE outlet_id IS NULL;
+ALTER TABLE stock_takes ALTER COLUMN outlet_id SET NOT NULL;
+
+ALTER TABLE purchase_orders ADD COLUMN IF NOT EXISTS outlet_id INT REFERENCES outlets(id);
+UPDATE purchase_orders SET outlet_id = (SELECT id FROM outlets WHERE is_default) WHERE outlet_id IS NULL;
+ALTER TABLE purchase_orders ALTER COLUMN outlet_id SET NOT NULL;
+
+ALTER TABLE goods_receipts ADD COLUMN IF NOT EXISTS outlet_id INT REFERENCES outlets(id);
+UPDATE goods_receipts SET outlet_id = (SELECT id FROM outlets WHERE is_default) WHERE outlet_id IS NULL;
+ALTER TABLE goods_receipts ALTER COLUMN outlet_id SET NOT NULL;
+
+ALTER TABLE supplier_returns ADD COLUMN IF NOT EXISTS outlet_id INT REFERENCES outlets(id);
+UPDATE supplier_returns SET outlet_id = (SELECT id FROM outlets WHERE is_default) WHERE outlet_id IS NULL;
+ALTER TABLE supplier_returns ALTER COLUMN outlet_id SET NOT NULL;
+
+-- nomor batch cukup unik per outlet, batch yang sama bisa ada di beberapa outlet
+ALTER TABLE product_batches ADD COLUMN IF NOT EXISTS outlet_id INT REFERENCES outlets(id);
+UPDATE product_batches SET outlet_id = (SELECT id FROM outlets WHERE is_default) WHERE outlet_id IS NULL;
+ALTER TABLE product_batches ALTER COLUMN outlet_id SET NOT NULL;
+ALTER TABLE product_batches DROP CONSTRAINT IF EXISTS product_batches_product_id_batch_number_key;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_product_batches_outlet_number ON product_batches (outlet_id, product_id, batch_number);
+DROP INDEX IF EXISTS idx_product_batches_fefo;
+CREATE INDEX IF NOT EXISTS idx_product_batches_outlet_fefo ON product_batches (outlet_id, product_id, expiry_date, id) WHERE quantity > 0;
+
+-- ledger: stock_after tetap total semua outlet, outlet_stock_after saldo di outlet-nya.
+-- trigger append-only dimatikan sebentar hanya untuk mengisi baris lama
+ALTER TABLE stock_movements ADD COLUMN IF NOT EXISTS outlet_id INT REFERENCES outlets(id);
+ALTER TABLE stock_movements ADD COLUMN IF NOT EXISTS outlet_stock_after INT;
+ALTER TABLE stock_movements DISABLE TRIGGER trg_stock_movements_append_only;
+UPDATE stock_movements SET outlet_id = (SELECT id FROM outlets WHERE is_default), outlet_stock_after = stock_after
+WHERE outlet_id IS NULL;
+ALTER TABLE stock_movements ENABLE TRIGGER trg_stock_movements_append_only;
+ALTER TABLE stock_movements ALTER COLUMN outlet_id SET NOT NULL;
+ALTER TABLE stock_movements ALTER COLUMN outlet_stock_after SET NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_stock_movements_outlet ON stock_movements (outlet_id, product_id, created_at);
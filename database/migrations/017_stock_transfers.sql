@@ -0,0 +1,40 @@
+CREATE TABLE IF NOT EXISTS stock_transfers (
+    id                    SERIAL PRIMARY KEY,
+    source_outlet_id      INT NOT NULL REFERENCES outlets(id),
+    destination_outlet_id INT NOT NULL REFERENCES outlets(id),
+    status                VARCHAR(20) NOT NULL DEFAULT 'requested',
+    note                  TEXT NOT NULL DEFAULT '',
+    requested_by          VARCHAR(100) NOT NULL,
+    requested_at          TIMESTAMP NOT NULL DEFAULT NOW(),
+    shipped_by            VARCHAR(100) NOT NULL DEFAULT '',
+    shipped_at            TIMESTAMP,
+    received_by           VARCHAR(100) NOT NULL DEFAULT '',
+    received_at           TIMESTAMP,
+    CHECK (source_outlet_id <> destination_outlet_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_stock_transfers_status ON stock_transfers (status, requested_at DESC);
+
+-- shipped_cost adalah HPP FIFO barang yang keluar dari outlet asal, dipakai lagi sebagai harga lapisan
+-- saat barang diterima supaya nilai persediaannya tidak berubah karena dipindah
+CREATE TABLE IF NOT EXISTS stock_transfer_lines (
+    id                SERIAL PRIMARY KEY,
+    stock_transfer_id INT NOT NULL REFERENCES stock_transfers(id),
+    product_id        INT NOT NULL REFERENCES products(id),
+    quantity          INT NOT NULL CHECK (quantity > 0),
+    shipped_quantity  INT NOT NULL DEFAULT 0,
+    shipped_cost      INT NOT NULL DEFAULT 0,
+    received_quantity INT,
+    note              TEXT NOT NULL DEFAULT '',
+    UNIQUE (stock_transfer_id, product_id)
+);
+
+-- batch di outlet asal yang ikut terkirim, diterima dengan nomor batch dan kadaluarsa yang sama
+CREATE TABLE IF NOT EXISTS stock_transfer_line_batches (
+    id                     SERIAL PRIMARY KEY,
+    stock_transfer_line_id INT NOT NULL REFERENCES stock_transfer_lines(id),
+    batch_id               INT NOT NULL REFERENCES product_batches(id),
+    quantity               INT NOT NULL CHECK (quantity > 0)
+);
+
+CREATE INDEX IF NOT EXISTS idx_stock_transfer_line_batches_line ON stock_transfer_line_batches (stock_transfer_line_id);
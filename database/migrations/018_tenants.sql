@@ -0,0 +1,55 @@
+-- mode SaaS: setiap toko adalah tenant. Data sebelum multi-tenant masuk ke tenant default (id 1)
+CREATE TABLE IF NOT EXISTS tenants (
+    id          SERIAL PRIMARY KEY,
+    code        VARCHAR(50) NOT NULL UNIQUE,
+    name        VARCHAR(255) NOT NULL,
+    api_token   VARCHAR(64) NOT NULL UNIQUE,
+    created_at  TIMESTAMP NOT NULL DEFAULT NOW(),
+    archived_at TIMESTAMP
+);
+
+INSERT INTO tenants (id, code, name, api_token)
+VALUES (1, 'default', 'Default', md5(random()::text || clock_timestamp()::text))
+ON CONFLICT (id) DO NOTHING;
+SELECT setval('tenants_id_seq', GREATEST((SELECT MAX(id) FROM tenants), 1));
+
+-- isolasi dipaksa oleh row level security: koneksi aplikasi selalu membawa app.tenant_id
+-- (lihat database.TenantPool), jadi query yang lupa memfilter tenant tetap tidak bisa membaca data tenant lain.
+-- tenant_id pada INSERT diisi otomatis dari app.tenant_id. Koneksi tanpa app.tenant_id akan error, bukan melihat semua data.
+-- catatan: role superuser selalu melewati RLS, aplikasi harus konek dengan role biasa
+DO $$
+DECLARE
+    t TEXT;
+BEGIN
+    FOREACH t IN ARRAY ARRAY[
+        'categories', 'products', 'product_price_histories', 'scheduled_price_changes', 'customer_groups',
+        'product_price_tiers', 'product_images', 'transactions', 'transaction_details', 'transaction_detail_batches',
+        'stock_movements', 'stock_adjustments', 'stock_adjustment_lines', 'stock_takes', 'stock_take_categories',
+        'stock_take_items', 'stock_take_counts', 'suppliers', 'purchase_orders', 'purchase_order_lines',
+        'goods_receipts', 'goods_receipt_lines', 'supplier_returns', 'supplier_return_lines', 'product_batches',
+        'cost_layers', 'cost_layer_consumptions', 'outlets', 'outlet_stocks', 'outlet_prices',
+        'stock_transfers', 'stock_transfer_lines', 'stock_transfer_line_batches'
+    ] LOOP
+        EXECUTE format('ALTER TABLE %I ADD COLUMN IF NOT EXISTS tenant_id INT NOT NULL DEFAULT 1 REFERENCES tenants(id)', t);
+        EXECUTE format('ALTER TABLE %I ALTER COLUMN tenant_id SET DEFAULT current_setting(''app.tenant_id'')::int', t);
+        EXECUTE format('CREATE INDEX IF NOT EXISTS %I ON %I (tenant_id)', 'idx_' || t || '_tenant', t);
+        EXECUTE format('ALTER TABLE %I ENABLE ROW LEVEL SECURITY', t);
+        EXECUTE format('ALTER TABLE %I FORCE ROW LEVEL SECURITY', t);
+        EXECUTE format('DROP POLICY IF EXISTS tenant_isolation ON %I', t);
+        EXECUTE format('CREATE POLICY tenant_isolation ON %I USING (tenant_id = current_setting(''app.tenant_id'')::int)', t);
+    END LOOP;
+END $$;
+
+-- keunikan yang tadinya global sekarang per tenant
+DROP INDEX IF EXISTS idx_products_sku;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_products_tenant_sku ON products (tenant_id, sku) WHERE sku IS NOT NULL;
+DROP INDEX IF EXISTS idx_products_barcode;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_products_tenant_barcode ON products (tenant_id, barcode) WHERE barcode IS NOT NULL;
+
+ALTER TABLE outlets DROP CONSTRAINT IF EXISTS outlets_code_key;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_outlets_tenant_code ON outlets (tenant_id, code);
+DROP INDEX IF EXISTS idx_outlets_single_default;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_outlets_tenant_default ON outlets (tenant_id) WHERE is_default;
+
+ALTER TABLE customer_groups DROP CONSTRAINT IF EXISTS customer_groups_name_key;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_customer_groups_tenant_name ON customer_groups (tenant_id, name);
@@ -0,0 +1,2 @@
+-- zona waktu outlet untuk batas hari di laporan, NULL berarti ikut STORE_TIMEZONE
+ALTER TABLE outlets ADD COLUMN IF NOT EXISTS timezone VARCHAR(64);
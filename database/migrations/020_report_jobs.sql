@@ -0,0 +1,56 @@
+-- laporan terjadwal: scheduler menjalankan job yang next_run_at-nya sudah lewat, hasilnya disimpan
+-- sebagai file dan dikirim lewat channel (email, webhook atau directory)
+CREATE TABLE IF NOT EXISTS report_jobs (
+    id          SERIAL PRIMARY KEY,
+    name        VARCHAR(255) NOT NULL,
+    cron        VARCHAR(100) NOT NULL,
+    timezone    VARCHAR(64),
+    report_type VARCHAR(30) NOT NULL,
+    date_range  VARCHAR(30) NOT NULL,
+    format      VARCHAR(10) NOT NULL,
+    outlet_id   INT REFERENCES outlets(id),
+    compare     VARCHAR(20) NOT NULL DEFAULT '',
+    channel     VARCHAR(20) NOT NULL,
+    target      TEXT NOT NULL DEFAULT '',
+    active      BOOLEAN NOT NULL DEFAULT TRUE,
+    next_run_at TIMESTAMPTZ,
+    last_run_at TIMESTAMPTZ,
+    created_by  VARCHAR(100) NOT NULL DEFAULT '',
+    created_at  TIMESTAMP NOT NULL DEFAULT NOW(),
+    tenant_id   INT NOT NULL DEFAULT current_setting('app.tenant_id')::int REFERENCES tenants(id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_report_jobs_due ON report_jobs (next_run_at) WHERE active;
+
+-- file_path relatif terhadap REPORT_DIR tenant, kosong kalau laporan gagal dibuat
+CREATE TABLE IF NOT EXISTS report_runs (
+    id            SERIAL PRIMARY KEY,
+    report_job_id INT NOT NULL REFERENCES report_jobs(id) ON DELETE CASCADE,
+    period_from   DATE NOT NULL,
+    period_to     DATE NOT NULL,
+    status        VARCHAR(20) NOT NULL DEFAULT 'running',
+    file_name     VARCHAR(255) NOT NULL DEFAULT '',
+    file_path     TEXT NOT NULL DEFAULT '',
+    file_size     INT NOT NULL DEFAULT 0,
+    delivered     BOOLEAN NOT NULL DEFAULT FALSE,
+    error         TEXT NOT NULL DEFAULT '',
+    started_at    TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    finished_at   TIMESTAMPTZ,
+    tenant_id     INT NOT NULL DEFAULT current_setting('app.tenant_id')::int REFERENCES tenants(id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_report_runs_job ON report_runs (report_job_id, started_at DESC);
+
+-- sama seperti tabel lain di 018_tenants.sql
+DO $$
+DECLARE
+    t TEXT;
+BEGIN
+    FOREACH t IN ARRAY ARRAY['report_jobs', 'report_runs'] LOOP
+        EXECUTE format('CREATE INDEX IF NOT EXISTS %I ON %I (tenant_id)', 'idx_' || t || '_tenant', t);
+        EXECUTE format('ALTER TABLE %I ENABLE ROW LEVEL SECURITY', t);
+        EXECUTE format('ALTER TABLE %I FORCE ROW LEVEL SECURITY', t);
+        EXECUTE format('DROP POLICY IF EXISTS tenant_isolation ON %I', t);
+        EXECUTE format('CREATE POLICY tenant_isolation ON %I USING (tenant_id = current_setting(''app.tenant_id'')::int)', t);
+    END LOOP;
+END $$;
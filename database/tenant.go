@@ -0,0 +1,197 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// batas per tenant, supaya satu tenant yang sibuk tidak menghabiskan semua slot koneksi global
+const (
+	tenantMaxOpenConns = 10
+	tenantMaxIdleConns = 2
+)
+
+// TenantPool menyimpan satu *sql.DB per tenant. Setiap koneksinya sudah di-SET app.tenant_id,
+// jadi semua query lewat pool ini otomatis dibatasi row level security ke tenant tersebut.
+//
+// Jumlah koneksi ke database dibatasi maxConns untuk semua tenant sekaligus. Koneksi idle ditutup setelah
+// idleTimeout, dan kalau slot penuh koneksi idle tenant yang paling lama tidak dipakai ditutup lebih dulu.
+// *sql.DB tanpa koneksi sendiri murah, jadi handler yang menyimpannya tetap bisa dipakai.
+type TenantPool struct {
+	connectionString string
+	idleTimeout      time.Duration
+	slots            chan struct{}
+
+	mu  sync.Mutex
+	dbs map[int]*tenantDB
+}
+
+type tenantDB struct {
+	db       *sql.DB
+	lastUsed time.Time
+}
+
+func NewTenantPool(connectionString string, maxConns int, idleTimeout time.Duration) *TenantPool {
+	if maxConns < 1 {
+		maxConns = 1
+	}
+	return &TenantPool{
+		connectionString: connectionString,
+		idleTimeout:      idleTimeout,
+		slots:            make(chan struct{}, maxConns),
+		dbs:              make(map[int]*tenantDB),
+	}
+}
+
+func (p *TenantPool) DB(tenantID int) (*sql.DB, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if t, ok := p.dbs[tenantID]; ok {
+		return t.db, nil
+	}
+
+	connector, err := pq.NewConnector(p.connectionString)
+	if err != nil {
+		return nil, err
+	}
+	db := sql.OpenDB(tenantConnector{Connector: connector, tenantID: tenantID, pool: p})
+
+	db.SetMaxOpenConns(min(tenantMaxOpenConns, cap(p.slots)))
+	db.SetMaxIdleConns(tenantMaxIdleConns)
+	db.SetConnMaxIdleTime(p.idleTimeout)
+
+	p.dbs[tenantID] = &tenantDB{db: db, lastUsed: time.Now()}
+	return db, nil
+}
+
+func (p *TenantPool) Close() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for id, t := range p.dbs {
+		t.db.Close()
+		delete(p.dbs, id)
+	}
+}
+
+// acquire mengambil satu slot koneksi global untuk tenantID, menunggu sampai ada slot kosong atau ctx selesai.
+func (p *TenantPool) acquire(ctx context.Context, tenantID int) error {
+	select {
+	case p.slots <- struct{}{}:
+	default:
+		p.closeIdle(tenantID)
+		select {
+		case p.slots <- struct{}{}:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	p.mu.Lock()
+	if t, ok := p.dbs[tenantID]; ok {
+		t.lastUsed = time.Now()
+	}
+	p.mu.Unlock()
+	return nil
+}
+
+func (p *TenantPool) release() {
+	<-p.slots
+}
+
+// closeIdle menutup koneksi idle tenant lain, mulai dari yang paling lama tidak dipakai, sampai ada slot kosong.
+func (p *TenantPool) closeIdle(exceptTenantID int) {
+	p.mu.Lock()
+	candidates := make([]tenantDB, 0, len(p.dbs))
+	for id, t := range p.dbs {
+		if id != exceptTenantID {
+			candidates = append(candidates, *t)
+		}
+	}
+	p.mu.Unlock()
+
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].lastUsed.Before(candidates[j].lastUsed)
+	})
+	for _, t := range candidates {
+		if len(p.slots) < cap(p.slots) {
+			return
+		}
+		//SetMaxIdleConns(0) langsung menutup semua koneksi idle, koneksi yang sedang dipakai tidak terganggu
+		t.db.SetMaxIdleConns(0)
+		t.db.SetMaxIdleConns(tenantMaxIdleConns)
+	}
+}
+
+type tenantConnector struct {
+	driver.Connector
+	tenantID int
+	pool     *TenantPool
+}
+
+func (c tenantConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	if err := c.pool.acquire(ctx, c.tenantID); err != nil {
+		return nil, err
+	}
+
+	conn, err := c.connect(ctx)
+	if err != nil {
+		c.pool.release()
+		return nil, err
+	}
+	return &tenantConn{pqConn: conn, release: c.pool.release}, nil
+}
+
+func (c tenantConnector) connect(ctx context.Context) (pqConn, error) {
+	conn, err := c.Connector.Connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	pc, ok := conn.(pqConn)
+	if !ok {
+		conn.Close()
+		return nil, fmt.Errorf("koneksi %T bukan koneksi lib/pq", conn)
+	}
+	_, err = pc.ExecContext(ctx, fmt.Sprintf("SET app.tenant_id = '%d'", c.tenantID), nil)
+	if err != nil {
+		pc.Close()
+		return nil, err
+	}
+
+	return pc, nil
+}
+
+// pqConn adalah interface yang diimplementasikan koneksi lib/pq. tenantConn meneruskan semuanya supaya
+// database/sql tetap memakai jalur cepat (tanpa prepare terpisah untuk setiap query).
+type pqConn interface {
+	driver.Conn
+	driver.ConnBeginTx
+	driver.ConnPrepareContext
+	driver.ExecerContext
+	driver.QueryerContext
+	driver.Pinger
+	driver.SessionResetter
+	driver.Validator
+}
+
+// tenantConn mengembalikan slot koneksi global saat koneksinya ditutup.
+type tenantConn struct {
+	pqConn
+	release func()
+	once    sync.Once
+}
+
+func (c *tenantConn) Close() error {
+	err := c.pqConn.Close()
+	c.once.Do(c.release)
+	return err
+}
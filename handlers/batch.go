@@ -0,0 +1,95 @@
+package handlers
+
+import (
+	"kasir-api/utils"
+	"net/http"
+	"strconv"
+)
+
+func (h *StockHandler) HandleProductBatches(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.GetProductBatches(w, r)
+	default:
+		utils.RespondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+func (h *StockHandler) HandleExpiringBatches(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.GetExpiringBatches(w, r)
+	default:
+		utils.RespondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+// GetProductBatches godoc
+// @Summary      Daftar Batch Produk
+// @Description  Urut FEFO (kadaluarsa paling dekat di atas)
+// @Tags         stock
+// @Produce      json
+// @Param        id             path   int   true   "Product ID"
+// @Param        outlet_id      query  int   false  "Filter outlet"
+// @Param        include_empty  query  bool  false  "Ikut tampilkan batch yang sudah habis"
+// @Success      200  {array}  models.ProductBatch
+// @Router       /api/produk/{id}/batches [get]
+func (h *StockHandler) GetProductBatches(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	outletID, ok := parseOutletIDQuery(w, r)
+	if !ok {
+		return
+	}
+	includeEmpty, err := parseOptionalBool(r.URL.Query().Get("include_empty"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "invalid include_empty")
+		return
+	}
+
+	batches, err := h.service.GetProductBatches(id, outletID, includeEmpty)
+	if err != nil {
+		respondWithRepoError(w, err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, batches)
+}
+
+// GetExpiringBatches godoc
+// @Summary      Laporan Batch Hampir Kadaluarsa
+// @Tags         stock
+// @Produce      json
+// @Param        days             query  int   false  "Kadaluarsa dalam berapa hari ke depan (default 30)"
+// @Param        outlet_id        query  int   false  "Filter outlet"
+// @Param        include_expired  query  bool  false  "Ikut tampilkan batch yang sudah kadaluarsa tapi masih ada stoknya"
+// @Success      200  {object}  models.ExpiryReport
+// @Router       /api/stock/expiring [get]
+func (h *StockHandler) GetExpiringBatches(w http.ResponseWriter, r *http.Request) {
+	days, err := parseOptionalInt(r.URL.Query().Get("days"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "invalid days")
+		return
+	}
+	outletID, ok := parseOutletIDQuery(w, r)
+	if !ok {
+		return
+	}
+	includeExpired, err := parseOptionalBool(r.URL.Query().Get("include_expired"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "invalid include_expired")
+		return
+	}
+
+	report, err := h.service.GetExpiringBatches(days, outletID, includeExpired)
+	if err != nil {
+		respondWithRepoError(w, err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, report)
+}
@@ -2,7 +2,9 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"kasir-api/models"
+	"kasir-api/repositories"
 	"kasir-api/services"
 	"kasir-api/utils"
 	"net/http"
@@ -42,12 +44,23 @@ func (h *CategoryHandler) HandleCategoryByID(w http.ResponseWriter, r *http.Requ
 	}
 }
 
+func (h *CategoryHandler) HandleRestoreCategory(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPost:
+		h.RestoreCategory(w, r)
+	default:
+		utils.RespondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
 // GetAllCategories godoc
 // @Summary      Daftar Semua Kategori
 // @Tags         categories
+// @Param        include_archived  query  bool  false  "Ikut tampilkan kategori yang diarsipkan"
 // @Router       /api/categories [get]
 func (h *CategoryHandler) GetAllCategories(w http.ResponseWriter, r *http.Request) {
-	categories, err := h.service.GetAllCategories()
+	includeArchived, _ := strconv.ParseBool(r.URL.Query().Get("include_archived"))
+	categories, err := h.service.GetAllCategories(includeArchived)
 	if err != nil {
 		utils.RespondWithError(w, http.StatusInternalServerError, err.Error())
 		return
@@ -130,9 +143,10 @@ func (h *CategoryHandler) UpdateCategory(w http.ResponseWriter, r *http.Request)
 }
 
 // deleteCategory godoc
-// @Summary      Hapus Kategori
+// @Summary      Arsipkan Kategori
 // @Tags         categories
-// @Param        id  path  int  true  "Kategori ID"
+// @Param        id     path   int   true   "Kategori ID"
+// @Param        force  query  bool  false  "Ikut arsipkan produk aktif di kategori ini"
 // @Router       /api/categories/{id} [delete]
 func (h *CategoryHandler) DeleteCategory(w http.ResponseWriter, r *http.Request) {
 	idStr := strings.TrimPrefix(r.URL.Path, "/api/categories/")
@@ -142,13 +156,41 @@ func (h *CategoryHandler) DeleteCategory(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	err = h.service.DeleteCategory(id)
+	force, _ := strconv.ParseBool(r.URL.Query().Get("force"))
+	err = h.service.ArchiveCategory(id, force)
+	if errors.Is(err, repositories.ErrCategoryHasActiveProducts) {
+		utils.RespondWithError(w, http.StatusConflict, err.Error())
+		return
+	}
 	if err != nil {
 		utils.RespondWithError(w, http.StatusInternalServerError, err.Error())
 		return
 	}
 
 	utils.RespondWithJSON(w, http.StatusOK, map[string]string{
-		"message": "Category deleted successfully",
+		"message": "Category archived successfully",
+	})
+}
+
+// RestoreCategory godoc
+// @Summary      Restore Kategori
+// @Tags         categories
+// @Param        id  path  int  true  "Kategori ID"
+// @Router       /api/categories/{id}/restore [post]
+func (h *CategoryHandler) RestoreCategory(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid category ID")
+		return
+	}
+
+	err = h.service.RestoreCategory(id)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, map[string]string{
+		"message": "Category restored successfully",
 	})
 }
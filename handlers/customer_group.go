@@ -0,0 +1,156 @@
+package handlers
+
+import (
+	"encoding/json"
+	"kasir-api/models"
+	"kasir-api/services"
+	"kasir-api/utils"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+type CustomerGroupHandler struct {
+	service *services.CustomerGroupService
+}
+
+func NewCustomerGroupHandler(service *services.CustomerGroupService) *CustomerGroupHandler {
+	return &CustomerGroupHandler{service: service}
+}
+
+func (h *CustomerGroupHandler) HandleCustomerGroups(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.GetAllCustomerGroups(w, r)
+	case http.MethodPost:
+		h.CreateCustomerGroup(w, r)
+	default:
+		utils.RespondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+func (h *CustomerGroupHandler) HandleCustomerGroupByID(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.GetCustomerGroupByID(w, r)
+	case http.MethodPut:
+		h.UpdateCustomerGroup(w, r)
+	case http.MethodDelete:
+		h.DeleteCustomerGroup(w, r)
+	default:
+		utils.RespondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+// GetAllCustomerGroups godoc
+// @Summary      Daftar Customer Group (Price List)
+// @Tags         customer-groups
+// @Success      200  {array}  models.CustomerGroup
+// @Router       /api/customer-groups [get]
+func (h *CustomerGroupHandler) GetAllCustomerGroups(w http.ResponseWriter, r *http.Request) {
+	groups, err := h.service.GetAllCustomerGroups()
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	utils.RespondWithJSON(w, http.StatusOK, groups)
+}
+
+// CreateCustomerGroup godoc
+// @Summary      Tambah Customer Group
+// @Tags         customer-groups
+// @Param        data  body  models.CustomerGroup  true  "Data Customer Group"
+// @Router       /api/customer-groups [post]
+func (h *CustomerGroupHandler) CreateCustomerGroup(w http.ResponseWriter, r *http.Request) {
+	group := models.CustomerGroup{}
+	err := json.NewDecoder(r.Body).Decode(&group)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	err = h.service.CreateCustomerGroup(&group)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	utils.RespondWithJSON(w, http.StatusCreated, group)
+}
+
+// GetCustomerGroupByID godoc
+// @Summary      Ambil Customer Group by ID
+// @Tags         customer-groups
+// @Param        id  path  int  true  "Customer Group ID"
+// @Success      200  {object}  models.CustomerGroup
+// @Router       /api/customer-groups/{id} [get]
+func (h *CustomerGroupHandler) GetCustomerGroupByID(w http.ResponseWriter, r *http.Request) {
+	idStr := strings.TrimPrefix(r.URL.Path, "/api/customer-groups/")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid customer group ID")
+		return
+	}
+
+	group, err := h.service.GetCustomerGroupByID(id)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, group)
+}
+
+// UpdateCustomerGroup godoc
+// @Summary      Update Customer Group
+// @Tags         customer-groups
+// @Param        id    path  int                   true  "Customer Group ID"
+// @Param        data  body  models.CustomerGroup  true  "Data Update"
+// @Router       /api/customer-groups/{id} [put]
+func (h *CustomerGroupHandler) UpdateCustomerGroup(w http.ResponseWriter, r *http.Request) {
+	idStr := strings.TrimPrefix(r.URL.Path, "/api/customer-groups/")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid customer group ID")
+		return
+	}
+
+	var group models.CustomerGroup
+	err = json.NewDecoder(r.Body).Decode(&group)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	group.ID = id
+	err = h.service.UpdateCustomerGroup(&group)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, group)
+}
+
+// DeleteCustomerGroup godoc
+// @Summary      Hapus Customer Group
+// @Tags         customer-groups
+// @Param        id  path  int  true  "Customer Group ID"
+// @Router       /api/customer-groups/{id} [delete]
+func (h *CustomerGroupHandler) DeleteCustomerGroup(w http.ResponseWriter, r *http.Request) {
+	idStr := strings.TrimPrefix(r.URL.Path, "/api/customer-groups/")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid customer group ID")
+		return
+	}
+
+	err = h.service.DeleteCustomerGroup(id)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, map[string]string{
+		"message": "Customer group deleted successfully",
+	})
+}
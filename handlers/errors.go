@@ -0,0 +1,26 @@
+package handlers
+
+import (
+	"errors"
+	"kasir-api/repositories"
+	"kasir-api/utils"
+	"net/http"
+)
+
+// respondWithRepoError memetakan error dari service/repository ke status HTTP yang sesuai.
+func respondWithRepoError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, repositories.ErrNotFound):
+		utils.RespondWithError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, repositories.ErrValidation), errors.Is(err, repositories.ErrInvalidFilter):
+		utils.RespondWithError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, repositories.ErrInvalidStatus):
+		utils.RespondWithError(w, http.StatusConflict, err.Error())
+	case errors.Is(err, repositories.ErrUnauthorized):
+		utils.RespondWithError(w, http.StatusUnauthorized, err.Error())
+	case errors.Is(err, repositories.ErrForbidden):
+		utils.RespondWithError(w, http.StatusForbidden, err.Error())
+	default:
+		utils.RespondWithError(w, http.StatusInternalServerError, err.Error())
+	}
+}
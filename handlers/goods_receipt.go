@@ -0,0 +1,206 @@
+package handlers
+
+import (
+	"encoding/json"
+	"kasir-api/models"
+	"kasir-api/utils"
+	"net/http"
+	"strconv"
+)
+
+func (h *PurchaseHandler) HandleGoodsReceipts(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.GetGoodsReceipts(w, r)
+	case http.MethodPost:
+		h.CreateGoodsReceipt(w, r)
+	default:
+		utils.RespondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+func (h *PurchaseHandler) HandleGoodsReceiptByID(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.GetGoodsReceiptByID(w, r)
+	default:
+		utils.RespondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+func (h *PurchaseHandler) HandleSupplierReturns(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.GetSupplierReturns(w, r)
+	case http.MethodPost:
+		h.CreateSupplierReturn(w, r)
+	default:
+		utils.RespondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+func (h *PurchaseHandler) HandleSupplierReturnByID(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.GetSupplierReturnByID(w, r)
+	default:
+		utils.RespondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+// CreateGoodsReceipt godoc
+// @Summary      Terima Barang Tanpa PO
+// @Description  Untuk pengiriman ad-hoc. supplier_id dan unit_cost setiap line wajib diisi
+// @Tags         purchasing
+// @Accept       json
+// @Produce      json
+// @Param        data  body      models.GoodsReceiptRequest  true  "Barang yang diterima"
+// @Success      201   {object}  models.GoodsReceipt
+// @Router       /api/goods-receipts [post]
+func (h *PurchaseHandler) CreateGoodsReceipt(w http.ResponseWriter, r *http.Request) {
+	var req models.GoodsReceiptRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	receipt, err := h.service.CreateGoodsReceipt(req, utils.GetActor(r))
+	if err != nil {
+		respondWithRepoError(w, err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusCreated, receipt)
+}
+
+// GetGoodsReceipts godoc
+// @Summary      Daftar Penerimaan Barang
+// @Description  Termasuk penerimaan dari PO
+// @Tags         purchasing
+// @Produce      json
+// @Param        supplier_id  query  int  false  "Filter supplier"
+// @Success      200  {array}  models.GoodsReceipt
+// @Router       /api/goods-receipts [get]
+func (h *PurchaseHandler) GetGoodsReceipts(w http.ResponseWriter, r *http.Request) {
+	supplierID, ok := parseSupplierIDQuery(w, r)
+	if !ok {
+		return
+	}
+
+	receipts, err := h.service.GetGoodsReceipts(supplierID)
+	if err != nil {
+		respondWithRepoError(w, err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, receipts)
+}
+
+// GetGoodsReceiptByID godoc
+// @Summary      Detail Penerimaan Barang
+// @Tags         purchasing
+// @Produce      json
+// @Param        id   path      int  true  "Goods Receipt ID"
+// @Success      200  {object}  models.GoodsReceipt
+// @Router       /api/goods-receipts/{id} [get]
+func (h *PurchaseHandler) GetGoodsReceiptByID(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid goods receipt ID")
+		return
+	}
+
+	receipt, err := h.service.GetGoodsReceiptByID(id)
+	if err != nil {
+		respondWithRepoError(w, err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, receipt)
+}
+
+// CreateSupplierReturn godoc
+// @Summary      Retur Barang ke Supplier
+// @Description  Mengurangi stok. unit_cost kosong berarti memakai harga pokok produk saat ini
+// @Tags         purchasing
+// @Accept       json
+// @Produce      json
+// @Param        data  body      models.SupplierReturnRequest  true  "Barang yang diretur"
+// @Success      201   {object}  models.SupplierReturn
+// @Router       /api/supplier-returns [post]
+func (h *PurchaseHandler) CreateSupplierReturn(w http.ResponseWriter, r *http.Request) {
+	var req models.SupplierReturnRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	ret, err := h.service.CreateSupplierReturn(req, utils.GetActor(r))
+	if err != nil {
+		respondWithRepoError(w, err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusCreated, ret)
+}
+
+// GetSupplierReturns godoc
+// @Summary      Daftar Retur ke Supplier
+// @Tags         purchasing
+// @Produce      json
+// @Param        supplier_id  query  int  false  "Filter supplier"
+// @Success      200  {array}  models.SupplierReturn
+// @Router       /api/supplier-returns [get]
+func (h *PurchaseHandler) GetSupplierReturns(w http.ResponseWriter, r *http.Request) {
+	supplierID, ok := parseSupplierIDQuery(w, r)
+	if !ok {
+		return
+	}
+
+	returns, err := h.service.GetSupplierReturns(supplierID)
+	if err != nil {
+		respondWithRepoError(w, err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, returns)
+}
+
+// GetSupplierReturnByID godoc
+// @Summary      Detail Retur ke Supplier
+// @Tags         purchasing
+// @Produce      json
+// @Param        id   path      int  true  "Supplier Return ID"
+// @Success      200  {object}  models.SupplierReturn
+// @Router       /api/supplier-returns/{id} [get]
+func (h *PurchaseHandler) GetSupplierReturnByID(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid supplier return ID")
+		return
+	}
+
+	ret, err := h.service.GetSupplierReturnByID(id)
+	if err != nil {
+		respondWithRepoError(w, err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, ret)
+}
+
+func parseSupplierIDQuery(w http.ResponseWriter, r *http.Request) (*int, bool) {
+	raw := r.URL.Query().Get("supplier_id")
+	if raw == "" {
+		return nil, true
+	}
+
+	id, err := strconv.Atoi(raw)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid supplier ID")
+		return nil, false
+	}
+	return &id, true
+}
@@ -0,0 +1,150 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"kasir-api/services"
+	"kasir-api/utils"
+	"net/http"
+	"strconv"
+)
+
+type ImageHandler struct {
+	service      *services.ImageService
+	maxImageSize int64
+}
+
+func NewImageHandler(service *services.ImageService, maxImageSize int64) *ImageHandler {
+	return &ImageHandler{service: service, maxImageSize: maxImageSize}
+}
+
+func (h *ImageHandler) HandleProductImages(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.GetProductImages(w, r)
+	case http.MethodPost:
+		h.UploadProductImage(w, r)
+	default:
+		utils.RespondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+func (h *ImageHandler) HandleProductImageByID(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodDelete:
+		h.DeleteProductImage(w, r)
+	default:
+		utils.RespondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+// GetProductImages godoc
+// @Summary      Daftar Gambar Produk
+// @Tags         product
+// @Produce      json
+// @Param        id   path      int  true  "Product ID"
+// @Success      200  {array}   models.ProductImage
+// @Router       /api/produk/{id}/images [get]
+func (h *ImageHandler) GetProductImages(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	images, err := h.service.GetProductImages(id)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, images)
+}
+
+// UploadProductImage godoc
+// @Summary      Upload Gambar Produk
+// @Tags         product
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        id       path      int     true   "Product ID"
+// @Param        image    formData  file    true   "File gambar (JPEG, PNG, GIF)"
+// @Param        primary  formData  bool    false  "Jadikan gambar utama"
+// @Success      201      {object}  models.ProductImage
+// @Router       /api/produk/{id}/images [post]
+func (h *ImageHandler) UploadProductImage(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	//sisakan 1MB untuk field form lainnya
+	r.Body = http.MaxBytesReader(w, r.Body, h.maxImageSize+1<<20)
+	err = r.ParseMultipartForm(h.maxImageSize)
+	if err != nil {
+		var maxErr *http.MaxBytesError
+		if errors.As(err, &maxErr) {
+			utils.RespondWithError(w, http.StatusRequestEntityTooLarge, fmt.Sprintf("ukuran gambar maksimal %d bytes", h.maxImageSize))
+			return
+		}
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid multipart form")
+		return
+	}
+
+	file, header, err := r.FormFile("image")
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "field image is required")
+		return
+	}
+	defer file.Close()
+
+	if header.Size > h.maxImageSize {
+		utils.RespondWithError(w, http.StatusRequestEntityTooLarge, fmt.Sprintf("ukuran gambar maksimal %d bytes", h.maxImageSize))
+		return
+	}
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	primary, _ := strconv.ParseBool(r.FormValue("primary"))
+	img, err := h.service.UploadProductImage(id, data, primary)
+	if err != nil {
+		respondWithRepoError(w, err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusCreated, img)
+}
+
+// DeleteProductImage godoc
+// @Summary      Hapus Gambar Produk
+// @Tags         product
+// @Param        id        path  int  true  "Product ID"
+// @Param        imageId   path  int  true  "Image ID"
+// @Router       /api/produk/{id}/images/{imageId} [delete]
+func (h *ImageHandler) DeleteProductImage(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+	imageID, err := strconv.Atoi(r.PathValue("imageId"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid image ID")
+		return
+	}
+
+	err = h.service.DeleteProductImage(id, imageID)
+	if err != nil {
+		respondWithRepoError(w, err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, map[string]string{
+		"message": "Image deleted successfully",
+	})
+}
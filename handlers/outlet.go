@@ -0,0 +1,327 @@
+package handlers
+
+import (
+	"encoding/json"
+	"kasir-api/models"
+	"kasir-api/services"
+	"kasir-api/utils"
+	"net/http"
+	"strconv"
+)
+
+type OutletHandler struct {
+	service *services.OutletService
+}
+
+func NewOutletHandler(service *services.OutletService) *OutletHandler {
+	return &OutletHandler{service: service}
+}
+
+func (h *OutletHandler) HandleOutlets(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.GetAllOutlets(w, r)
+	case http.MethodPost:
+		h.CreateOutlet(w, r)
+	default:
+		utils.RespondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+func (h *OutletHandler) HandleOutletByID(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.GetOutletByID(w, r)
+	case http.MethodPut:
+		h.UpdateOutlet(w, r)
+	case http.MethodDelete:
+		h.DeleteOutlet(w, r)
+	default:
+		utils.RespondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+func (h *OutletHandler) HandleOutletStocks(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.GetOutletStocks(w, r)
+	default:
+		utils.RespondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+func (h *OutletHandler) HandleOutletPrices(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.GetOutletPrices(w, r)
+	default:
+		utils.RespondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+func (h *OutletHandler) HandleOutletPriceByProduct(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPut:
+		h.SetOutletPrice(w, r)
+	case http.MethodDelete:
+		h.DeleteOutletPrice(w, r)
+	default:
+		utils.RespondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+// GetAllOutlets godoc
+// @Summary      Daftar Outlet
+// @Tags         outlets
+// @Produce      json
+// @Param        include_archived  query  bool  false  "Ikut tampilkan outlet yang diarsipkan"
+// @Success      200  {array}  models.Outlet
+// @Router       /api/outlets [get]
+func (h *OutletHandler) GetAllOutlets(w http.ResponseWriter, r *http.Request) {
+	includeArchived, err := parseOptionalBool(r.URL.Query().Get("include_archived"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "invalid include_archived")
+		return
+	}
+
+	outlets, err := h.service.GetAllOutlets(includeArchived)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	utils.RespondWithJSON(w, http.StatusOK, outlets)
+}
+
+// CreateOutlet godoc
+// @Summary      Tambah Outlet
+// @Description  type: store atau warehouse (default store)
+// @Tags         outlets
+// @Accept       json
+// @Produce      json
+// @Param        data  body      models.Outlet  true  "Data Outlet"
+// @Success      201   {object}  models.Outlet
+// @Router       /api/outlets [post]
+func (h *OutletHandler) CreateOutlet(w http.ResponseWriter, r *http.Request) {
+	var outlet models.Outlet
+	err := json.NewDecoder(r.Body).Decode(&outlet)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	err = h.service.CreateOutlet(&outlet)
+	if err != nil {
+		respondWithRepoError(w, err)
+		return
+	}
+	utils.RespondWithJSON(w, http.StatusCreated, outlet)
+}
+
+// GetOutletByID godoc
+// @Summary      Ambil Outlet by ID
+// @Tags         outlets
+// @Produce      json
+// @Param        id   path      int  true  "Outlet ID"
+// @Success      200  {object}  models.Outlet
+// @Router       /api/outlets/{id} [get]
+func (h *OutletHandler) GetOutletByID(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid outlet ID")
+		return
+	}
+
+	outlet, err := h.service.GetOutletByID(id)
+	if err != nil {
+		respondWithRepoError(w, err)
+		return
+	}
+	utils.RespondWithJSON(w, http.StatusOK, outlet)
+}
+
+// UpdateOutlet godoc
+// @Summary      Update Outlet
+// @Tags         outlets
+// @Accept       json
+// @Produce      json
+// @Param        id    path      int            true  "Outlet ID"
+// @Param        data  body      models.Outlet  true  "Data Update"
+// @Success      200   {object}  models.Outlet
+// @Router       /api/outlets/{id} [put]
+func (h *OutletHandler) UpdateOutlet(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid outlet ID")
+		return
+	}
+
+	var outlet models.Outlet
+	err = json.NewDecoder(r.Body).Decode(&outlet)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	outlet.ID = id
+	err = h.service.UpdateOutlet(&outlet)
+	if err != nil {
+		respondWithRepoError(w, err)
+		return
+	}
+	utils.RespondWithJSON(w, http.StatusOK, outlet)
+}
+
+// DeleteOutlet godoc
+// @Summary      Arsipkan Outlet
+// @Description  Outlet default dan outlet yang masih punya stok tidak bisa diarsipkan
+// @Tags         outlets
+// @Param        id  path  int  true  "Outlet ID"
+// @Router       /api/outlets/{id} [delete]
+func (h *OutletHandler) DeleteOutlet(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid outlet ID")
+		return
+	}
+
+	err = h.service.ArchiveOutlet(id)
+	if err != nil {
+		respondWithRepoError(w, err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, map[string]string{
+		"message": "Outlet archived successfully",
+	})
+}
+
+// GetOutletStocks godoc
+// @Summary      Stok dan Harga di Outlet
+// @Tags         outlets
+// @Produce      json
+// @Param        id           path   int  true   "Outlet ID"
+// @Param        category_id  query  int  false  "Filter kategori"
+// @Success      200  {array}  models.OutletStock
+// @Router       /api/outlets/{id}/stock [get]
+func (h *OutletHandler) GetOutletStocks(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid outlet ID")
+		return
+	}
+	categoryID, ok := parseCategoryIDQuery(w, r)
+	if !ok {
+		return
+	}
+
+	stocks, err := h.service.GetOutletStocks(id, categoryID)
+	if err != nil {
+		respondWithRepoError(w, err)
+		return
+	}
+	utils.RespondWithJSON(w, http.StatusOK, stocks)
+}
+
+// GetOutletPrices godoc
+// @Summary      Daftar Harga Khusus Outlet
+// @Tags         outlets
+// @Produce      json
+// @Param        id   path  int  true  "Outlet ID"
+// @Success      200  {array}  models.OutletPrice
+// @Router       /api/outlets/{id}/prices [get]
+func (h *OutletHandler) GetOutletPrices(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid outlet ID")
+		return
+	}
+
+	prices, err := h.service.GetOutletPrices(id)
+	if err != nil {
+		respondWithRepoError(w, err)
+		return
+	}
+	utils.RespondWithJSON(w, http.StatusOK, prices)
+}
+
+// SetOutletPrice godoc
+// @Summary      Set Harga Khusus Outlet
+// @Description  Menggantikan harga dasar produk di outlet ini. Harga customer group dan tier tetap berlaku di atasnya.
+// @Tags         outlets
+// @Accept       json
+// @Produce      json
+// @Param        id          path      int                        true  "Outlet ID"
+// @Param        product_id  path      int                        true  "Product ID"
+// @Param        data        body      models.OutletPriceRequest  true  "Harga"
+// @Success      200         {object}  models.OutletPrice
+// @Router       /api/outlets/{id}/prices/{product_id} [put]
+func (h *OutletHandler) SetOutletPrice(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid outlet ID")
+		return
+	}
+	productID, err := strconv.Atoi(r.PathValue("product_id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	var req models.OutletPriceRequest
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	price, err := h.service.SetOutletPrice(id, productID, req, utils.GetActor(r))
+	if err != nil {
+		respondWithRepoError(w, err)
+		return
+	}
+	utils.RespondWithJSON(w, http.StatusOK, price)
+}
+
+// DeleteOutletPrice godoc
+// @Summary      Hapus Harga Khusus Outlet
+// @Description  Outlet kembali memakai harga dasar produk
+// @Tags         outlets
+// @Param        id          path  int  true  "Outlet ID"
+// @Param        product_id  path  int  true  "Product ID"
+// @Router       /api/outlets/{id}/prices/{product_id} [delete]
+func (h *OutletHandler) DeleteOutletPrice(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid outlet ID")
+		return
+	}
+	productID, err := strconv.Atoi(r.PathValue("product_id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	err = h.service.DeleteOutletPrice(id, productID)
+	if err != nil {
+		respondWithRepoError(w, err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, map[string]string{
+		"message": "Outlet price removed successfully",
+	})
+}
+
+func parseOutletIDQuery(w http.ResponseWriter, r *http.Request) (*int, bool) {
+	raw := r.URL.Query().Get("outlet_id")
+	if raw == "" {
+		return nil, true
+	}
+
+	id, err := strconv.Atoi(raw)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid outlet ID")
+		return nil, false
+	}
+	return &id, true
+}
@@ -0,0 +1,255 @@
+package handlers
+
+import (
+	"encoding/json"
+	"kasir-api/models"
+	"kasir-api/services"
+	"kasir-api/utils"
+	"net/http"
+	"strconv"
+)
+
+type PriceHandler struct {
+	service *services.PriceService
+}
+
+func NewPriceHandler(service *services.PriceService) *PriceHandler {
+	return &PriceHandler{service: service}
+}
+
+func (h *PriceHandler) HandlePriceHistory(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.GetPriceHistory(w, r)
+	default:
+		utils.RespondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+func (h *PriceHandler) HandleScheduledPrices(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.GetScheduledPriceChanges(w, r)
+	case http.MethodPost:
+		h.SchedulePriceChange(w, r)
+	default:
+		utils.RespondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+func (h *PriceHandler) HandleScheduledPriceByID(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodDelete:
+		h.CancelScheduledPriceChange(w, r)
+	default:
+		utils.RespondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+func (h *PriceHandler) HandlePriceTiers(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.GetPriceTiers(w, r)
+	case http.MethodPost:
+		h.CreatePriceTier(w, r)
+	default:
+		utils.RespondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+func (h *PriceHandler) HandlePriceTierByID(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodDelete:
+		h.DeletePriceTier(w, r)
+	default:
+		utils.RespondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+// GetPriceHistory godoc
+// @Summary      Riwayat Perubahan Harga Produk
+// @Tags         price
+// @Produce      json
+// @Param        id   path      int  true  "Product ID"
+// @Success      200  {array}   models.PriceHistory
+// @Router       /api/produk/{id}/price-history [get]
+func (h *PriceHandler) GetPriceHistory(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	histories, err := h.service.GetPriceHistory(id)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, histories)
+}
+
+// GetScheduledPriceChanges godoc
+// @Summary      Daftar Jadwal Perubahan Harga
+// @Description  status: pending, applied, cancelled, atau failed (alasannya di field error)
+// @Tags         price
+// @Produce      json
+// @Param        id   path      int  true  "Product ID"
+// @Success      200  {array}   models.ScheduledPriceChange
+// @Router       /api/produk/{id}/scheduled-prices [get]
+func (h *PriceHandler) GetScheduledPriceChanges(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	changes, err := h.service.GetScheduledPriceChanges(id)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, changes)
+}
+
+// SchedulePriceChange godoc
+// @Summary      Jadwalkan Perubahan Harga
+// @Tags         price
+// @Accept       json
+// @Produce      json
+// @Param        id    path      int                          true  "Product ID"
+// @Param        data  body      models.ScheduledPriceChange  true  "new_price dan effective_at (RFC3339)"
+// @Success      201   {object}  models.ScheduledPriceChange
+// @Router       /api/produk/{id}/scheduled-prices [post]
+func (h *PriceHandler) SchedulePriceChange(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	var change models.ScheduledPriceChange
+	err = json.NewDecoder(r.Body).Decode(&change)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if change.EffectiveAt.IsZero() {
+		utils.RespondWithError(w, http.StatusBadRequest, "effective_at is required")
+		return
+	}
+
+	change.ProductID = id
+	change.CreatedBy = utils.GetActor(r)
+	err = h.service.SchedulePriceChange(&change)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusCreated, change)
+}
+
+// CancelScheduledPriceChange godoc
+// @Summary      Batalkan Jadwal Perubahan Harga
+// @Tags         price
+// @Param        id   path      int  true  "Scheduled Price ID"
+// @Router       /api/scheduled-prices/{id} [delete]
+func (h *PriceHandler) CancelScheduledPriceChange(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid scheduled price ID")
+		return
+	}
+
+	err = h.service.CancelScheduledPriceChange(id)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, map[string]string{
+		"message": "Scheduled price change cancelled",
+	})
+}
+
+// GetPriceTiers godoc
+// @Summary      Daftar Harga Bertingkat Produk
+// @Tags         price
+// @Produce      json
+// @Param        id   path      int  true  "Product ID"
+// @Success      200  {array}   models.PriceTier
+// @Router       /api/produk/{id}/price-tiers [get]
+func (h *PriceHandler) GetPriceTiers(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	tiers, err := h.service.GetPriceTiers(id)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, tiers)
+}
+
+// CreatePriceTier godoc
+// @Summary      Tambah Harga Bertingkat
+// @Description  customer_group_id kosong berarti berlaku untuk semua pelanggan. Saat checkout dipakai harga termurah antara harga dasar dan semua tier yang berlaku, jadi tier hanya bisa menurunkan harga.
+// @Tags         price
+// @Accept       json
+// @Produce      json
+// @Param        id    path      int               true  "Product ID"
+// @Param        data  body      models.PriceTier  true  "Data Tier"
+// @Success      201   {object}  models.PriceTier
+// @Router       /api/produk/{id}/price-tiers [post]
+func (h *PriceHandler) CreatePriceTier(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	var tier models.PriceTier
+	err = json.NewDecoder(r.Body).Decode(&tier)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	tier.ProductID = id
+	err = h.service.CreatePriceTier(&tier)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusCreated, tier)
+}
+
+// DeletePriceTier godoc
+// @Summary      Hapus Harga Bertingkat
+// @Tags         price
+// @Param        id   path      int  true  "Price Tier ID"
+// @Router       /api/price-tiers/{id} [delete]
+func (h *PriceHandler) DeletePriceTier(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid price tier ID")
+		return
+	}
+
+	err = h.service.DeletePriceTier(id)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, map[string]string{
+		"message": "Price tier deleted successfully",
+	})
+}
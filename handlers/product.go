@@ -2,7 +2,10 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"kasir-api/models"
+	"kasir-api/repositories"
 	"kasir-api/services"
 	"kasir-api/utils"
 	"net/http"
@@ -11,11 +14,12 @@ import (
 )
 
 type ProductHandler struct {
-	service *services.ProductService
+	service       *services.ProductService
+	maxImportSize int64
 }
 
-func NewProductHandler(service *services.ProductService) *ProductHandler {
-	return &ProductHandler{service: service}
+func NewProductHandler(service *services.ProductService, maxImportSize int64) *ProductHandler {
+	return &ProductHandler{service: service, maxImportSize: maxImportSize}
 }
 
 func (h *ProductHandler) HandleProducts(w http.ResponseWriter, r *http.Request) {
@@ -42,28 +46,109 @@ func (h *ProductHandler) HandleProductByID(w http.ResponseWriter, r *http.Reques
 	}
 }
 
+func (h *ProductHandler) HandleSearchProducts(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.SearchProducts(w, r)
+	default:
+		utils.RespondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+func (h *ProductHandler) HandleRestoreProduct(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPost:
+		h.RestoreProduct(w, r)
+	default:
+		utils.RespondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
 // GetAllProducts godoc
 // @Summary      Daftar Semua Produk
 // @Tags         product
 // @Produce      json
-// @Success      200  {array}  models.Product
+// @Param        name                 query  string  false  "Cari nama, SKU, barcode atau kategori (full-text + trigram)"
+// @Param        category_id          query  int     false  "Filter kategori"
+// @Param        min_price            query  int     false  "Harga minimum"
+// @Param        max_price            query  int     false  "Harga maksimum"
+// @Param        in_stock             query  bool    false  "true = stok > 0, false = stok habis"
+// @Param        low_stock            query  bool    false  "Hanya produk dengan stok menipis"
+// @Param        low_stock_threshold  query  int     false  "Batas stok menipis (default 5)"
+// @Param        include_archived     query  bool    false  "Ikut tampilkan produk yang diarsipkan"
+// @Param        sort                 query  string  false  "id, name, price, stock, created_at"
+// @Param        order                query  string  false  "asc atau desc"
+// @Param        limit                query  int     false  "Jumlah per halaman (default 50, maks 200)"
+// @Param        offset               query  int     false  "Offset pagination"
+// @Param        cursor               query  string  false  "Cursor dari meta.next_cursor"
+// @Success      200  {object}  models.ProductPage
 // @Router       /api/product [get]
 func (h *ProductHandler) GetAllProducts(w http.ResponseWriter, r *http.Request) {
-	name := r.URL.Query().Get("name")
-	products, err := h.service.GetAllProducts(name)
+	filter, err := parseProductFilter(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	h.respondWithProducts(w, filter)
+}
+
+// SearchProducts godoc
+// @Summary      Cari Produk
+// @Description  Full-text search + trigram (tahan salah ketik) atas nama, SKU, barcode dan nama kategori, diurutkan berdasarkan relevansi
+// @Tags         product
+// @Produce      json
+// @Param        q                 query  string  true   "Kata kunci, mendukung prefix untuk type-ahead"
+// @Param        limit             query  int     false  "Jumlah hasil (default 20, maks 100)"
+// @Param        category_id       query  int     false  "Filter kategori"
+// @Param        include_archived  query  bool    false  "Ikut cari produk yang diarsipkan"
+// @Success      200  {array}  models.ProductSearchResult
+// @Router       /api/produk/search [get]
+func (h *ProductHandler) SearchProducts(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	limit, err := parseOptionalInt(q.Get("limit"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "invalid limit")
+		return
+	}
+
+	var categoryID *int
+	if raw := q.Get("category_id"); raw != "" {
+		id, err := strconv.Atoi(raw)
+		if err != nil {
+			utils.RespondWithError(w, http.StatusBadRequest, "invalid category_id")
+			return
+		}
+		categoryID = &id
+	}
+
+	includeArchived, err := parseOptionalBool(q.Get("include_archived"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "invalid include_archived")
+		return
+	}
+
+	results, err := h.service.SearchProducts(q.Get("q"), limit, categoryID, includeArchived)
+	if errors.Is(err, repositories.ErrInvalidFilter) {
+		utils.RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
 	if err != nil {
 		utils.RespondWithError(w, http.StatusInternalServerError, err.Error())
 		return
 	}
 
-	utils.RespondWithJSON(w, http.StatusOK, products)
+	utils.RespondWithJSON(w, http.StatusOK, results)
 }
 
 // GetAllProductsByCategoryID godoc
 // @Summary      Daftar Semua Produk berdasarkan kategori ID
+// @Description  Mendukung query filter, sort dan pagination yang sama dengan /api/produk
 // @Tags         product
 // @Produce      json
-// @Success      200  {array}  models.Product
+// @Param        id   path      int  true  "Kategori ID"
+// @Success      200  {object}  models.ProductPage
 // @Router       /api/categories/{id}/produk [get]
 func (h *ProductHandler) GetAllProductsByCategoryID(w http.ResponseWriter, r *http.Request) {
 	idStr := r.PathValue("id")
@@ -73,13 +158,97 @@ func (h *ProductHandler) GetAllProductsByCategoryID(w http.ResponseWriter, r *ht
 		return
 	}
 
-	products, err := h.service.GetAllProductsByCategoryID(id)
+	filter, err := parseProductFilter(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	filter.CategoryID = &id
+
+	h.respondWithProducts(w, filter)
+}
+
+func (h *ProductHandler) respondWithProducts(w http.ResponseWriter, filter models.ProductFilter) {
+	page, err := h.service.GetProducts(filter)
+	if errors.Is(err, repositories.ErrInvalidFilter) {
+		utils.RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
 	if err != nil {
-		utils.RespondWithError(w, http.StatusNotFound, "Produk tidak ditemukan")
+		utils.RespondWithError(w, http.StatusInternalServerError, err.Error())
 		return
 	}
 
-	utils.RespondWithJSON(w, http.StatusOK, products)
+	utils.RespondWithJSON(w, http.StatusOK, page)
+}
+
+func parseProductFilter(r *http.Request) (models.ProductFilter, error) {
+	q := r.URL.Query()
+	filter := models.ProductFilter{
+		Name:   q.Get("name"),
+		Sort:   q.Get("sort"),
+		Order:  strings.ToLower(q.Get("order")),
+		Cursor: q.Get("cursor"),
+	}
+
+	intParams := []struct {
+		name   string
+		target **int
+	}{
+		{"category_id", &filter.CategoryID},
+		{"min_price", &filter.MinPrice},
+		{"max_price", &filter.MaxPrice},
+	}
+	for _, p := range intParams {
+		if raw := q.Get(p.name); raw != "" {
+			v, err := strconv.Atoi(raw)
+			if err != nil {
+				return filter, fmt.Errorf("invalid %s", p.name)
+			}
+			*p.target = &v
+		}
+	}
+
+	if raw := q.Get("in_stock"); raw != "" {
+		v, err := strconv.ParseBool(raw)
+		if err != nil {
+			return filter, errors.New("invalid in_stock")
+		}
+		filter.InStock = &v
+	}
+
+	var err error
+	if filter.LowStock, err = parseOptionalBool(q.Get("low_stock")); err != nil {
+		return filter, errors.New("invalid low_stock")
+	}
+	if filter.IncludeArchived, err = parseOptionalBool(q.Get("include_archived")); err != nil {
+		return filter, errors.New("invalid include_archived")
+	}
+	if filter.LowStockThreshold, err = parseOptionalInt(q.Get("low_stock_threshold")); err != nil {
+		return filter, errors.New("invalid low_stock_threshold")
+	}
+	if filter.Limit, err = parseOptionalInt(q.Get("limit")); err != nil {
+		return filter, errors.New("invalid limit")
+	}
+	if filter.Offset, err = parseOptionalInt(q.Get("offset")); err != nil {
+		return filter, errors.New("invalid offset")
+	}
+
+	return filter, nil
+}
+
+func parseOptionalBool(raw string) (bool, error) {
+	if raw == "" {
+		return false, nil
+	}
+	return strconv.ParseBool(raw)
+}
+
+func parseOptionalInt(raw string) (int, error) {
+	if raw == "" {
+		return 0, nil
+	}
+	return strconv.Atoi(raw)
 }
 
 // CreateProduct godoc
@@ -98,7 +267,7 @@ func (h *ProductHandler) CreateProduct(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = h.service.CreateProduct(&product)
+	err = h.service.CreateProduct(&product, utils.GetActor(r))
 	if err != nil {
 		utils.RespondWithError(w, http.StatusInternalServerError, err.Error())
 		return
@@ -131,6 +300,7 @@ func (h *ProductHandler) GetProductByID(w http.ResponseWriter, r *http.Request)
 
 // updateProduct godoc
 // @Summary      Update Produk
+// @Description  Semua field produk diganti dengan isi body, field yang tidak dikirim ikut dikosongkan. Field stock ditolak, perubahan stok lewat /api/stock/adjustments
 // @Tags         product
 // @Param        id    path      int     true  "Product ID"
 // @Param        data  body      models.Product  true  "Data Update"
@@ -143,27 +313,45 @@ func (h *ProductHandler) UpdateProduct(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	var product models.Product
-	err = json.NewDecoder(r.Body).Decode(&product)
+	//stock ikut di-decode hanya untuk dideteksi, supaya client tidak mengira stoknya sudah berubah
+	var req struct {
+		models.Product
+		Stock *int `json:"stock"`
+	}
+	err = json.NewDecoder(r.Body).Decode(&req)
 	if err != nil {
 		utils.RespondWithError(w, http.StatusBadRequest, "Invalid request body")
 		return
 	}
+	if req.Stock != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "stock tidak bisa diubah lewat update produk, gunakan POST /api/stock/adjustments")
+		return
+	}
 
+	product := req.Product
 	product.ID = id
-	err = h.service.UpdateProduct(&product)
+	err = h.service.UpdateProduct(&product, utils.GetActor(r))
 	if err != nil {
 		utils.RespondWithError(w, http.StatusInternalServerError, err.Error())
 		return
 	}
 
-	utils.RespondWithJSON(w, http.StatusOK, product)
+	//ambil ulang supaya stok yang dikembalikan adalah stok sebenarnya, bukan dari body request
+	updated, err := h.service.GetProductByID(id)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, updated)
 }
 
 // deleteProduct godoc
-// @Summary      Hapus Produk
+// @Summary      Arsipkan Produk
 // @Tags         product
 // @Param        id   path      int  true  "Product ID"
+// @Failure      404  {object}  map[string]string
+// @Failure      409  {object}  map[string]string
 // @Router       /api/product/{id} [delete]
 func (h *ProductHandler) DeleteProduct(w http.ResponseWriter, r *http.Request) {
 	idStr := strings.TrimPrefix(r.URL.Path, "/api/produk/")
@@ -173,13 +361,36 @@ func (h *ProductHandler) DeleteProduct(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = h.service.DeleteProduct(id)
+	err = h.service.ArchiveProduct(id)
 	if err != nil {
-		utils.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		respondWithRepoError(w, err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, map[string]string{
+		"message": "Product archived successfully",
+	})
+}
+
+// RestoreProduct godoc
+// @Summary      Restore Produk
+// @Tags         product
+// @Param        id   path      int  true  "Product ID"
+// @Router       /api/produk/{id}/restore [post]
+func (h *ProductHandler) RestoreProduct(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	err = h.service.RestoreProduct(id)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, err.Error())
 		return
 	}
 
 	utils.RespondWithJSON(w, http.StatusOK, map[string]string{
-		"message": "Product deleted successfully",
+		"message": "Product restored successfully",
 	})
 }
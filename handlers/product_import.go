@@ -0,0 +1,134 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"kasir-api/spreadsheet"
+	"kasir-api/utils"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+func (h *ProductHandler) HandleImportProducts(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPost:
+		h.ImportProducts(w, r)
+	default:
+		utils.RespondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+func (h *ProductHandler) HandleExportProducts(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.ExportProducts(w, r)
+	default:
+		utils.RespondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+// ImportProducts godoc
+// @Summary      Import Produk dari CSV / XLSX
+// @Description  Upsert berdasarkan SKU, kategori yang belum ada dibuat otomatis. Kolom standar: sku, name, category, price, stock. stock adalah stok total semua outlet seperti hasil export, kalau ada lebih dari satu outlet aktif perubahan stok produk lama diabaikan dan dilaporkan di warnings.
+// @Tags         product
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        file     formData  file    true   "File .csv atau .xlsx"
+// @Param        format   formData  string  false  "csv atau xlsx, default dari ekstensi file"
+// @Param        mapping  formData  string  false  "JSON field ke nama header, contoh {\"name\":\"Nama Produk\"}"
+// @Param        dry_run  formData  bool    false  "Validasi saja tanpa menyimpan"
+// @Success      200      {object}  models.ProductImportResult
+// @Failure      422      {object}  models.ProductImportResult
+// @Router       /api/produk/import [post]
+func (h *ProductHandler) ImportProducts(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, h.maxImportSize+1<<20)
+	err := r.ParseMultipartForm(32 << 20)
+	if err != nil {
+		var maxErr *http.MaxBytesError
+		if errors.As(err, &maxErr) {
+			utils.RespondWithError(w, http.StatusRequestEntityTooLarge, fmt.Sprintf("ukuran file maksimal %d bytes", h.maxImportSize))
+			return
+		}
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid multipart form")
+		return
+	}
+
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "field file is required")
+		return
+	}
+	defer file.Close()
+
+	format := r.FormValue("format")
+	if format == "" {
+		format = spreadsheet.FormatFromFilename(header.Filename)
+	}
+	if format != spreadsheet.FormatCSV && format != spreadsheet.FormatXLSX {
+		utils.RespondWithError(w, http.StatusBadRequest, "format harus csv atau xlsx")
+		return
+	}
+
+	var mapping map[string]string
+	if raw := r.FormValue("mapping"); raw != "" {
+		if err := json.Unmarshal([]byte(raw), &mapping); err != nil {
+			utils.RespondWithError(w, http.StatusBadRequest, "mapping harus berupa JSON object")
+			return
+		}
+	}
+
+	dryRun, _ := strconv.ParseBool(r.FormValue("dry_run"))
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	result, err := h.service.ImportProducts(format, data, mapping, utils.GetActor(r), dryRun)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if len(result.Errors) > 0 {
+		utils.RespondWithJSON(w, http.StatusUnprocessableEntity, result)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, result)
+}
+
+// ExportProducts godoc
+// @Summary      Export Produk ke CSV / XLSX
+// @Tags         product
+// @Produce      octet-stream
+// @Param        format            query  string  false  "csv (default) atau xlsx"
+// @Param        include_archived  query  bool    false  "Ikut export produk yang diarsipkan"
+// @Router       /api/produk/export [get]
+func (h *ProductHandler) ExportProducts(w http.ResponseWriter, r *http.Request) {
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = spreadsheet.FormatCSV
+	}
+	if format != spreadsheet.FormatCSV && format != spreadsheet.FormatXLSX {
+		utils.RespondWithError(w, http.StatusBadRequest, "format harus csv atau xlsx")
+		return
+	}
+
+	includeArchived, _ := strconv.ParseBool(r.URL.Query().Get("include_archived"))
+
+	filename := fmt.Sprintf("produk-%s.%s", time.Now().Format("20060102"), format)
+	w.Header().Set("Content-Type", spreadsheet.ContentType(format))
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+
+	//status sudah terkirim begitu baris pertama ditulis, jadi error di tengah jalan cuma bisa di-log
+	err := h.service.ExportProducts(format, w, includeArchived)
+	if err != nil {
+		log.Println("gagal export produk:", err)
+	}
+}
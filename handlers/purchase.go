@@ -0,0 +1,251 @@
+package handlers
+
+import (
+	"encoding/json"
+	"kasir-api/models"
+	"kasir-api/services"
+	"kasir-api/utils"
+	"net/http"
+	"strconv"
+)
+
+type PurchaseHandler struct {
+	service *services.PurchaseService
+}
+
+func NewPurchaseHandler(service *services.PurchaseService) *PurchaseHandler {
+	return &PurchaseHandler{service: service}
+}
+
+func (h *PurchaseHandler) HandlePurchaseOrders(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.GetPurchaseOrders(w, r)
+	case http.MethodPost:
+		h.CreatePurchaseOrder(w, r)
+	default:
+		utils.RespondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+func (h *PurchaseHandler) HandlePurchaseOrderByID(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.GetPurchaseOrderByID(w, r)
+	case http.MethodPut:
+		h.UpdatePurchaseOrder(w, r)
+	default:
+		utils.RespondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+func (h *PurchaseHandler) HandleSendPurchaseOrder(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPost:
+		h.SendPurchaseOrder(w, r)
+	default:
+		utils.RespondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+func (h *PurchaseHandler) HandleReceivePurchaseOrder(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPost:
+		h.ReceivePurchaseOrder(w, r)
+	default:
+		utils.RespondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+func (h *PurchaseHandler) HandleCancelPurchaseOrder(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPost:
+		h.CancelPurchaseOrder(w, r)
+	default:
+		utils.RespondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+// GetPurchaseOrders godoc
+// @Summary      Daftar Purchase Order
+// @Tags         purchasing
+// @Produce      json
+// @Param        status       query  string  false  "draft, sent, partially_received, received, cancelled"
+// @Param        supplier_id  query  int     false  "Filter supplier"
+// @Success      200  {array}  models.PurchaseOrder
+// @Router       /api/purchase-orders [get]
+func (h *PurchaseHandler) GetPurchaseOrders(w http.ResponseWriter, r *http.Request) {
+	supplierID, ok := parseSupplierIDQuery(w, r)
+	if !ok {
+		return
+	}
+
+	orders, err := h.service.GetPurchaseOrders(r.URL.Query().Get("status"), supplierID)
+	if err != nil {
+		respondWithRepoError(w, err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, orders)
+}
+
+// CreatePurchaseOrder godoc
+// @Summary      Buat Purchase Order
+// @Description  PO baru selalu berstatus draft
+// @Tags         purchasing
+// @Accept       json
+// @Produce      json
+// @Param        data  body      models.PurchaseOrderRequest  true  "Data PO"
+// @Success      201   {object}  models.PurchaseOrder
+// @Router       /api/purchase-orders [post]
+func (h *PurchaseHandler) CreatePurchaseOrder(w http.ResponseWriter, r *http.Request) {
+	var req models.PurchaseOrderRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	po, err := h.service.CreatePurchaseOrder(req, utils.GetActor(r))
+	if err != nil {
+		respondWithRepoError(w, err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusCreated, po)
+}
+
+// GetPurchaseOrderByID godoc
+// @Summary      Detail Purchase Order
+// @Tags         purchasing
+// @Produce      json
+// @Param        id   path      int  true  "Purchase Order ID"
+// @Success      200  {object}  models.PurchaseOrder
+// @Router       /api/purchase-orders/{id} [get]
+func (h *PurchaseHandler) GetPurchaseOrderByID(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid purchase order ID")
+		return
+	}
+
+	po, err := h.service.GetPurchaseOrderByID(id)
+	if err != nil {
+		respondWithRepoError(w, err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, po)
+}
+
+// UpdatePurchaseOrder godoc
+// @Summary      Update Purchase Order
+// @Description  Hanya untuk PO draft, semua line diganti
+// @Tags         purchasing
+// @Accept       json
+// @Produce      json
+// @Param        id    path      int                          true  "Purchase Order ID"
+// @Param        data  body      models.PurchaseOrderRequest  true  "Data PO"
+// @Success      200   {object}  models.PurchaseOrder
+// @Router       /api/purchase-orders/{id} [put]
+func (h *PurchaseHandler) UpdatePurchaseOrder(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid purchase order ID")
+		return
+	}
+
+	var req models.PurchaseOrderRequest
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	po, err := h.service.UpdatePurchaseOrder(id, req)
+	if err != nil {
+		respondWithRepoError(w, err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, po)
+}
+
+// SendPurchaseOrder godoc
+// @Summary      Tandai PO Sudah Dikirim ke Supplier
+// @Tags         purchasing
+// @Produce      json
+// @Param        id   path      int  true  "Purchase Order ID"
+// @Success      200  {object}  models.PurchaseOrder
+// @Router       /api/purchase-orders/{id}/send [post]
+func (h *PurchaseHandler) SendPurchaseOrder(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid purchase order ID")
+		return
+	}
+
+	po, err := h.service.SendPurchaseOrder(id)
+	if err != nil {
+		respondWithRepoError(w, err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, po)
+}
+
+// ReceivePurchaseOrder godoc
+// @Summary      Terima Barang dari PO
+// @Description  Boleh sebagian. Stok bertambah dan harga pokok dihitung ulang (rata-rata tertimbang). unit_cost kosong berarti sesuai PO
+// @Tags         purchasing
+// @Accept       json
+// @Produce      json
+// @Param        id    path      int                         true  "Purchase Order ID"
+// @Param        data  body      models.GoodsReceiptRequest  true  "Barang yang diterima"
+// @Success      200   {object}  models.PurchaseOrder
+// @Router       /api/purchase-orders/{id}/receive [post]
+func (h *PurchaseHandler) ReceivePurchaseOrder(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid purchase order ID")
+		return
+	}
+
+	var req models.GoodsReceiptRequest
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	po, err := h.service.ReceivePurchaseOrder(id, req, utils.GetActor(r))
+	if err != nil {
+		respondWithRepoError(w, err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, po)
+}
+
+// CancelPurchaseOrder godoc
+// @Summary      Batalkan Purchase Order
+// @Tags         purchasing
+// @Produce      json
+// @Param        id   path      int  true  "Purchase Order ID"
+// @Success      200  {object}  models.PurchaseOrder
+// @Router       /api/purchase-orders/{id}/cancel [post]
+func (h *PurchaseHandler) CancelPurchaseOrder(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid purchase order ID")
+		return
+	}
+
+	po, err := h.service.CancelPurchaseOrder(id)
+	if err != nil {
+		respondWithRepoError(w, err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, po)
+}
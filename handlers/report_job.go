@@ -0,0 +1,272 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"kasir-api/models"
+	"kasir-api/services"
+	"kasir-api/spreadsheet"
+	"kasir-api/utils"
+	"log"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+type ReportJobHandler struct {
+	service *services.ReportJobService
+}
+
+func NewReportJobHandler(service *services.ReportJobService) *ReportJobHandler {
+	return &ReportJobHandler{service: service}
+}
+
+func (h *ReportJobHandler) HandleReportJobs(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.GetReportJobs(w, r)
+	case http.MethodPost:
+		h.CreateReportJob(w, r)
+	default:
+		utils.RespondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+func (h *ReportJobHandler) HandleReportJobByID(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.GetReportJobByID(w, r)
+	case http.MethodPut:
+		h.UpdateReportJob(w, r)
+	case http.MethodDelete:
+		h.DeleteReportJob(w, r)
+	default:
+		utils.RespondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+func (h *ReportJobHandler) HandleRunReportJob(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPost:
+		h.RunReportJob(w, r)
+	default:
+		utils.RespondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+func (h *ReportJobHandler) HandleReportRuns(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.GetReportRuns(w, r)
+	default:
+		utils.RespondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+func (h *ReportJobHandler) HandleDownloadReportRun(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.DownloadReportRun(w, r)
+	default:
+		utils.RespondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+// GetReportJobs godoc
+// @Summary      Daftar Laporan Terjadwal
+// @Tags         report-jobs
+// @Produce      json
+// @Success      200  {array}  models.ReportJob
+// @Router       /api/report-jobs [get]
+func (h *ReportJobHandler) GetReportJobs(w http.ResponseWriter, r *http.Request) {
+	jobs, err := h.service.GetReportJobs()
+	if err != nil {
+		respondWithRepoError(w, err)
+		return
+	}
+	utils.RespondWithJSON(w, http.StatusOK, jobs)
+}
+
+// CreateReportJob godoc
+// @Summary      Buat Laporan Terjadwal
+// @Description  cron 5 field (menit jam tanggal bulan hari) di zona waktu job, misalnya "0 7 * * *" dengan range yesterday untuk ringkasan harian tiap pagi. channel email butuh SMTP_HOST, directory butuh REPORT_DELIVERY_DIR
+// @Tags         report-jobs
+// @Accept       json
+// @Produce      json
+// @Param        data  body      models.ReportJobRequest  true  "Jadwal, jenis laporan, format dan tujuan pengiriman"
+// @Success      201   {object}  models.ReportJob
+// @Router       /api/report-jobs [post]
+func (h *ReportJobHandler) CreateReportJob(w http.ResponseWriter, r *http.Request) {
+	var req models.ReportJobRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	job, err := h.service.CreateReportJob(req, utils.GetActor(r))
+	if err != nil {
+		respondWithRepoError(w, err)
+		return
+	}
+	utils.RespondWithJSON(w, http.StatusCreated, job)
+}
+
+// GetReportJobByID godoc
+// @Summary      Detail Laporan Terjadwal
+// @Tags         report-jobs
+// @Produce      json
+// @Param        id   path      int  true  "Report Job ID"
+// @Success      200  {object}  models.ReportJob
+// @Router       /api/report-jobs/{id} [get]
+func (h *ReportJobHandler) GetReportJobByID(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid report job ID")
+		return
+	}
+
+	job, err := h.service.GetReportJobByID(id)
+	if err != nil {
+		respondWithRepoError(w, err)
+		return
+	}
+	utils.RespondWithJSON(w, http.StatusOK, job)
+}
+
+// UpdateReportJob godoc
+// @Summary      Update Laporan Terjadwal
+// @Description  Jadwal berikutnya dihitung ulang dari sekarang
+// @Tags         report-jobs
+// @Accept       json
+// @Produce      json
+// @Param        id    path      int                      true  "Report Job ID"
+// @Param        data  body      models.ReportJobRequest  true  "Data Update"
+// @Success      200   {object}  models.ReportJob
+// @Router       /api/report-jobs/{id} [put]
+func (h *ReportJobHandler) UpdateReportJob(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid report job ID")
+		return
+	}
+
+	var req models.ReportJobRequest
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	job, err := h.service.UpdateReportJob(id, req)
+	if err != nil {
+		respondWithRepoError(w, err)
+		return
+	}
+	utils.RespondWithJSON(w, http.StatusOK, job)
+}
+
+// DeleteReportJob godoc
+// @Summary      Hapus Laporan Terjadwal
+// @Description  Riwayat run dan file hasilnya ikut dihapus. Untuk menghentikan sementara, update dengan active false
+// @Tags         report-jobs
+// @Param        id  path  int  true  "Report Job ID"
+// @Router       /api/report-jobs/{id} [delete]
+func (h *ReportJobHandler) DeleteReportJob(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid report job ID")
+		return
+	}
+
+	err = h.service.DeleteReportJob(id)
+	if err != nil {
+		respondWithRepoError(w, err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, map[string]string{
+		"message": "Report job deleted successfully",
+	})
+}
+
+// RunReportJob godoc
+// @Summary      Jalankan Laporan Terjadwal Sekarang
+// @Description  Untuk mencoba konfigurasi job. Jadwal berikutnya tidak berubah. Gagal membuat atau mengirim laporan tercatat di status run, bukan sebagai error HTTP
+// @Tags         report-jobs
+// @Produce      json
+// @Param        id   path      int  true  "Report Job ID"
+// @Success      200  {object}  models.ReportRun
+// @Router       /api/report-jobs/{id}/run [post]
+func (h *ReportJobHandler) RunReportJob(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid report job ID")
+		return
+	}
+
+	run, err := h.service.RunReportJob(id)
+	if err != nil {
+		respondWithRepoError(w, err)
+		return
+	}
+	utils.RespondWithJSON(w, http.StatusOK, run)
+}
+
+// GetReportRuns godoc
+// @Summary      Riwayat Run Laporan Terjadwal
+// @Tags         report-jobs
+// @Produce      json
+// @Param        id     path   int  true   "Report Job ID"
+// @Param        limit  query  int  false  "Jumlah run terakhir, default 30"
+// @Success      200  {array}  models.ReportRun
+// @Router       /api/report-jobs/{id}/runs [get]
+func (h *ReportJobHandler) GetReportRuns(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid report job ID")
+		return
+	}
+	limit, err := parseOptionalInt(r.URL.Query().Get("limit"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "invalid limit")
+		return
+	}
+
+	runs, err := h.service.GetReportRuns(id, limit)
+	if err != nil {
+		respondWithRepoError(w, err)
+		return
+	}
+	utils.RespondWithJSON(w, http.StatusOK, runs)
+}
+
+// DownloadReportRun godoc
+// @Summary      Unduh File Hasil Laporan Terjadwal
+// @Tags         report-jobs
+// @Produce      octet-stream
+// @Param        id  path  int  true  "Report Run ID"
+// @Router       /api/report-runs/{id}/download [get]
+func (h *ReportJobHandler) DownloadReportRun(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid report run ID")
+		return
+	}
+
+	run, f, err := h.service.OpenReportRunFile(id)
+	if err != nil {
+		respondWithRepoError(w, err)
+		return
+	}
+	defer f.Close()
+
+	format := strings.TrimPrefix(filepath.Ext(run.FileName), ".")
+	w.Header().Set("Content-Type", spreadsheet.ContentType(format))
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, run.FileName))
+	if _, err := io.Copy(w, f); err != nil {
+		log.Println("gagal mengirim file laporan:", err)
+	}
+}
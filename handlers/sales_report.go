@@ -0,0 +1,176 @@
+package handlers
+
+import (
+	"fmt"
+	"io"
+	"kasir-api/services"
+	"kasir-api/spreadsheet"
+	"kasir-api/utils"
+	"log"
+	"net/http"
+	"time"
+)
+
+func (h *TransactionHandler) HandleSalesTimeseries(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.GetSalesTimeseries(w, r)
+	default:
+		utils.RespondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+func (h *TransactionHandler) HandleProductRankings(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.GetProductRankings(w, r)
+	default:
+		utils.RespondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+// GetSalesTimeseries godoc
+// @Summary      Grafik Penjualan per Jam/Hari/Minggu/Bulan
+// @Description  Revenue, jumlah transaksi, item terjual dan rata-rata nilai transaksi per bucket. Bucket tanpa penjualan tetap muncul dengan nilai 0
+// @Tags         Transaction
+// @Produce      json,octet-stream
+// @Param        from         query  string  true   "Tanggal awal (YYYY-MM-DD)"
+// @Param        to           query  string  false  "Tanggal akhir (YYYY-MM-DD), default sama dengan from"
+// @Param        granularity  query  string  false  "hour, day (default), week atau month"
+// @Param        outlet_id    query  int     false  "Filter outlet"
+// @Param        tz           query  string  false  "Zona waktu IANA, misalnya Asia/Makassar. Default timezone outlet atau STORE_TIMEZONE"
+// @Param        format       query  string  false  "json (default), csv, xlsx atau pdf"
+// @Success      200  {object}  models.SalesTimeseries
+// @Router       /api/report/sales-timeseries [get]
+func (h *TransactionHandler) GetSalesTimeseries(w http.ResponseWriter, r *http.Request) {
+	format, ok := parseReportFormat(w, r)
+	if !ok {
+		return
+	}
+	from, to, ok := parseDateRangeQuery(w, r)
+	if !ok {
+		return
+	}
+	outletID, ok := parseOutletIDQuery(w, r)
+	if !ok {
+		return
+	}
+
+	report, err := h.service.GetSalesTimeseries(from, to, r.URL.Query().Get("granularity"), outletID, r.URL.Query().Get("tz"))
+	if err != nil {
+		respondWithRepoError(w, err)
+		return
+	}
+	if format != "" {
+		respondWithReportFile(w, format, services.ReportFilename("grafik-penjualan", report.From, report.To, format), func(out io.Writer) error {
+			return h.service.ExportSalesTimeseries(format, out, report)
+		})
+		return
+	}
+	utils.RespondWithJSON(w, http.StatusOK, report)
+}
+
+// GetProductRankings godoc
+// @Summary      Ranking Produk (Top-N / Bottom-N)
+// @Description  Ranking per quantity, revenue atau profit. order asc untuk slow mover (produk aktif yang tidak terjual ikut dengan nilai 0). Kontribusi revenue dan kelas ABC dihitung dari semua produk
+// @Tags         Transaction
+// @Produce      json,octet-stream
+// @Param        from         query  string  true   "Tanggal awal (YYYY-MM-DD)"
+// @Param        to           query  string  false  "Tanggal akhir (YYYY-MM-DD), default sama dengan from"
+// @Param        by           query  string  false  "quantity, revenue (default) atau profit"
+// @Param        order        query  string  false  "desc (default) atau asc"
+// @Param        limit        query  int     false  "Jumlah produk, default 10"
+// @Param        category_id  query  int     false  "Filter kategori"
+// @Param        outlet_id    query  int     false  "Filter outlet"
+// @Param        tz           query  string  false  "Zona waktu IANA, default timezone outlet atau STORE_TIMEZONE"
+// @Param        format       query  string  false  "json (default), csv, xlsx atau pdf"
+// @Success      200  {object}  models.ProductRankingReport
+// @Router       /api/report/product-rankings [get]
+func (h *TransactionHandler) GetProductRankings(w http.ResponseWriter, r *http.Request) {
+	format, ok := parseReportFormat(w, r)
+	if !ok {
+		return
+	}
+	from, to, ok := parseDateRangeQuery(w, r)
+	if !ok {
+		return
+	}
+	limit, err := parseOptionalInt(r.URL.Query().Get("limit"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "invalid limit")
+		return
+	}
+	categoryID, ok := parseCategoryIDQuery(w, r)
+	if !ok {
+		return
+	}
+	outletID, ok := parseOutletIDQuery(w, r)
+	if !ok {
+		return
+	}
+
+	q := r.URL.Query()
+	report, err := h.service.GetProductRankings(from, to, q.Get("by"), q.Get("order"), limit, categoryID, outletID, q.Get("tz"))
+	if err != nil {
+		respondWithRepoError(w, err)
+		return
+	}
+	if format != "" {
+		respondWithReportFile(w, format, services.ReportFilename("ranking-produk", report.From, report.To, format), func(out io.Writer) error {
+			return h.service.ExportProductRankings(format, out, report)
+		})
+		return
+	}
+	utils.RespondWithJSON(w, http.StatusOK, report)
+}
+
+// parseDateRangeQuery membaca from (wajib) dan to (default sama dengan from) berformat YYYY-MM-DD.
+func parseDateRangeQuery(w http.ResponseWriter, r *http.Request) (time.Time, time.Time, bool) {
+	fromStr := r.URL.Query().Get("from")
+	if fromStr == "" {
+		utils.RespondWithError(w, http.StatusBadRequest, "from is required")
+		return time.Time{}, time.Time{}, false
+	}
+
+	from, err := time.Parse("2006-01-02", fromStr)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "invalid from format (YYYY-MM-DD)")
+		return time.Time{}, time.Time{}, false
+	}
+
+	to := from
+	if toStr := r.URL.Query().Get("to"); toStr != "" {
+		to, err = time.Parse("2006-01-02", toStr)
+		if err != nil {
+			utils.RespondWithError(w, http.StatusBadRequest, "invalid to format (YYYY-MM-DD)")
+			return time.Time{}, time.Time{}, false
+		}
+	}
+
+	return from, to, true
+}
+
+// parseReportFormat: format kosong atau json berarti response JSON biasa dan dikembalikan sebagai "".
+func parseReportFormat(w http.ResponseWriter, r *http.Request) (string, bool) {
+	format := r.URL.Query().Get("format")
+	switch format {
+	case "", "json":
+		return "", true
+	case spreadsheet.FormatCSV, spreadsheet.FormatXLSX, spreadsheet.FormatPDF:
+		return format, true
+	default:
+		utils.RespondWithError(w, http.StatusBadRequest, "format harus json, csv, xlsx atau pdf")
+		return "", false
+	}
+}
+
+// respondWithReportFile dipanggil setelah laporannya berhasil dibuat, jadi error validasi tetap dikirim sebagai JSON.
+func respondWithReportFile(w http.ResponseWriter, format string, filename string, export func(io.Writer) error) {
+	w.Header().Set("Content-Type", spreadsheet.ContentType(format))
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+
+	//status sudah terkirim begitu data pertama ditulis, jadi error di tengah jalan cuma bisa di-log
+	if err := export(w); err != nil {
+		log.Println("gagal export laporan:", err)
+	}
+}
@@ -0,0 +1,369 @@
+package handlers
+
+import (
+	"encoding/json"
+	"kasir-api/models"
+	"kasir-api/services"
+	"kasir-api/utils"
+	"net/http"
+	"strconv"
+)
+
+type StockHandler struct {
+	service *services.StockService
+}
+
+func NewStockHandler(service *services.StockService) *StockHandler {
+	return &StockHandler{service: service}
+}
+
+func (h *StockHandler) HandleStockMovements(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.GetStockMovements(w, r)
+	default:
+		utils.RespondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+func (h *StockHandler) HandleStockConsistency(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.CheckConsistency(w, r)
+	default:
+		utils.RespondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+// GetStockMovements godoc
+// @Summary      Riwayat Pergerakan Stok Produk
+// @Tags         stock
+// @Produce      json
+// @Param        id      path   int  true   "Product ID"
+// @Param        limit   query  int  false  "Jumlah per halaman (default 50, maks 200)"
+// @Param        offset  query  int  false  "Offset pagination"
+// @Success      200  {object}  models.StockMovementPage
+// @Router       /api/produk/{id}/stock-movements [get]
+func (h *StockHandler) GetStockMovements(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	limit, err := parseOptionalInt(r.URL.Query().Get("limit"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "invalid limit")
+		return
+	}
+	offset, err := parseOptionalInt(r.URL.Query().Get("offset"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "invalid offset")
+		return
+	}
+
+	page, err := h.service.GetStockMovements(id, limit, offset)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, page)
+}
+
+// CheckConsistency godoc
+// @Summary      Cek Konsistensi Stok dengan Ledger
+// @Description  Menghitung ulang stok dari stock_movements dan dari outlet_stocks, lalu membandingkannya dengan products.stock
+// @Tags         stock
+// @Produce      json
+// @Param        product_id  query  int  false  "Cek satu produk saja"
+// @Success      200  {object}  models.StockConsistencyReport
+// @Router       /api/stock/consistency [get]
+func (h *StockHandler) CheckConsistency(w http.ResponseWriter, r *http.Request) {
+	var productID *int
+	if raw := r.URL.Query().Get("product_id"); raw != "" {
+		id, err := strconv.Atoi(raw)
+		if err != nil {
+			utils.RespondWithError(w, http.StatusBadRequest, "Invalid product ID")
+			return
+		}
+		productID = &id
+	}
+
+	report, err := h.service.CheckConsistency(productID)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, report)
+}
+
+func (h *StockHandler) HandleStockAdjustments(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.GetStockAdjustments(w, r)
+	case http.MethodPost:
+		h.CreateStockAdjustment(w, r)
+	default:
+		utils.RespondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+func (h *StockHandler) HandleStockAdjustmentByID(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.GetStockAdjustmentByID(w, r)
+	default:
+		utils.RespondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+func (h *StockHandler) HandleApproveStockAdjustment(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPost:
+		h.ReviewStockAdjustment(w, r, true)
+	default:
+		utils.RespondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+func (h *StockHandler) HandleRejectStockAdjustment(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPost:
+		h.ReviewStockAdjustment(w, r, false)
+	default:
+		utils.RespondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+// CreateStockAdjustment godoc
+// @Summary      Buat Stock Adjustment
+// @Description  Reason: damaged, expired, lost (quantity negatif), found (positif), correction. Kalau approval diwajibkan, status pending sampai di-approve
+// @Tags         stock
+// @Accept       json
+// @Produce      json
+// @Param        data  body      models.StockAdjustmentRequest  true  "Adjustment Data"
+// @Success      201   {object}  models.StockAdjustment
+// @Router       /api/stock/adjustments [post]
+func (h *StockHandler) CreateStockAdjustment(w http.ResponseWriter, r *http.Request) {
+	var req models.StockAdjustmentRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	adj, err := h.service.CreateStockAdjustment(req, utils.GetActor(r))
+	if err != nil {
+		respondWithRepoError(w, err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusCreated, adj)
+}
+
+// GetStockAdjustments godoc
+// @Summary      Daftar Stock Adjustment
+// @Tags         stock
+// @Produce      json
+// @Param        status  query  string  false  "pending, applied, atau rejected"
+// @Success      200  {array}  models.StockAdjustment
+// @Router       /api/stock/adjustments [get]
+func (h *StockHandler) GetStockAdjustments(w http.ResponseWriter, r *http.Request) {
+	adjustments, err := h.service.GetStockAdjustments(r.URL.Query().Get("status"))
+	if err != nil {
+		respondWithRepoError(w, err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, adjustments)
+}
+
+// GetStockAdjustmentByID godoc
+// @Summary      Detail Stock Adjustment
+// @Tags         stock
+// @Produce      json
+// @Param        id   path      int  true  "Adjustment ID"
+// @Success      200  {object}  models.StockAdjustment
+// @Router       /api/stock/adjustments/{id} [get]
+func (h *StockHandler) GetStockAdjustmentByID(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid adjustment ID")
+		return
+	}
+
+	adj, err := h.service.GetStockAdjustmentByID(id)
+	if err != nil {
+		respondWithRepoError(w, err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, adj)
+}
+
+// ReviewStockAdjustment godoc
+// @Summary      Approve / Reject Stock Adjustment
+// @Description  Hanya untuk adjustment pending. Reviewer (header X-User) harus terdaftar di STOCK_ADJUSTMENT_APPROVERS dan berbeda dengan pembuatnya
+// @Tags         stock
+// @Accept       json
+// @Produce      json
+// @Param        id    path      int                           true   "Adjustment ID"
+// @Param        data  body      models.StockAdjustmentReview  false  "Catatan review"
+// @Success      200   {object}  models.StockAdjustment
+// @Router       /api/stock/adjustments/{id}/approve [post]
+// @Router       /api/stock/adjustments/{id}/reject [post]
+func (h *StockHandler) ReviewStockAdjustment(w http.ResponseWriter, r *http.Request, approve bool) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid adjustment ID")
+		return
+	}
+
+	//body boleh kosong
+	var review models.StockAdjustmentReview
+	if r.ContentLength != 0 {
+		err = json.NewDecoder(r.Body).Decode(&review)
+		if err != nil {
+			utils.RespondWithError(w, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+	}
+
+	var adj *models.StockAdjustment
+	if approve {
+		adj, err = h.service.ApproveStockAdjustment(id, utils.GetActor(r), review.Note)
+	} else {
+		adj, err = h.service.RejectStockAdjustment(id, utils.GetActor(r), review.Note)
+	}
+	if err != nil {
+		respondWithRepoError(w, err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, adj)
+}
+
+func (h *StockHandler) HandleLowStock(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.GetLowStockProducts(w, r)
+	default:
+		utils.RespondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+func (h *StockHandler) HandleReorderSuggestions(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.GetReorderSuggestions(w, r)
+	default:
+		utils.RespondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+// GetLowStockProducts godoc
+// @Summary      Produk dengan Stok Menipis
+// @Description  Produk aktif dengan stok <= reorder_point (produk dengan reorder_point 0 tidak dipantau)
+// @Tags         stock
+// @Produce      json
+// @Param        category_id  query  int  false  "Filter kategori"
+// @Success      200  {array}  models.LowStockProduct
+// @Router       /api/stock/low [get]
+func (h *StockHandler) GetLowStockProducts(w http.ResponseWriter, r *http.Request) {
+	categoryID, ok := parseCategoryIDQuery(w, r)
+	if !ok {
+		return
+	}
+
+	products, err := h.service.GetLowStockProducts(categoryID)
+	if err != nil {
+		respondWithRepoError(w, err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, products)
+}
+
+// GetReorderSuggestions godoc
+// @Summary      Saran Reorder
+// @Description  Estimasi sisa hari stok dari rata-rata penjualan harian, dan jumlah yang perlu dipesan supaya cukup untuk cover_days
+// @Tags         stock
+// @Produce      json
+// @Param        window_days  query  int  false  "Jumlah hari penjualan yang dihitung (default dari REORDER_WINDOW_DAYS)"
+// @Param        cover_days   query  int  false  "Target stok cukup untuk berapa hari (default 14)"
+// @Param        category_id  query  int  false  "Filter kategori"
+// @Success      200  {object}  models.ReorderSuggestionReport
+// @Router       /api/stock/reorder-suggestions [get]
+func (h *StockHandler) GetReorderSuggestions(w http.ResponseWriter, r *http.Request) {
+	windowDays, err := parseOptionalInt(r.URL.Query().Get("window_days"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "invalid window_days")
+		return
+	}
+	coverDays, err := parseOptionalInt(r.URL.Query().Get("cover_days"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "invalid cover_days")
+		return
+	}
+	categoryID, ok := parseCategoryIDQuery(w, r)
+	if !ok {
+		return
+	}
+
+	report, err := h.service.GetReorderSuggestions(windowDays, coverDays, categoryID)
+	if err != nil {
+		respondWithRepoError(w, err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, report)
+}
+
+func (h *StockHandler) HandleInventoryValuation(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.GetInventoryValuation(w, r)
+	default:
+		utils.RespondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+// GetInventoryValuation godoc
+// @Summary      Valuasi Persediaan
+// @Description  Nilai stok per akhir hari as_of dengan metode FIFO dan weighted average, per produk dan per kategori
+// @Tags         stock
+// @Produce      json
+// @Param        as_of        query  string  false  "Tanggal (YYYY-MM-DD), default hari ini"
+// @Param        category_id  query  int     false  "Filter kategori"
+// @Success      200  {object}  models.InventoryValuation
+// @Router       /api/stock/valuation [get]
+func (h *StockHandler) GetInventoryValuation(w http.ResponseWriter, r *http.Request) {
+	categoryID, ok := parseCategoryIDQuery(w, r)
+	if !ok {
+		return
+	}
+
+	report, err := h.service.GetInventoryValuation(r.URL.Query().Get("as_of"), categoryID)
+	if err != nil {
+		respondWithRepoError(w, err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, report)
+}
+
+func parseCategoryIDQuery(w http.ResponseWriter, r *http.Request) (*int, bool) {
+	raw := r.URL.Query().Get("category_id")
+	if raw == "" {
+		return nil, true
+	}
+
+	id, err := strconv.Atoi(raw)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid category ID")
+		return nil, false
+	}
+	return &id, true
+}
@@ -0,0 +1,253 @@
+package handlers
+
+import (
+	"encoding/json"
+	"kasir-api/models"
+	"kasir-api/utils"
+	"net/http"
+	"strconv"
+)
+
+func (h *StockHandler) HandleStockTakes(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.GetStockTakes(w, r)
+	case http.MethodPost:
+		h.StartStockTake(w, r)
+	default:
+		utils.RespondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+func (h *StockHandler) HandleStockTakeByID(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.GetStockTakeByID(w, r)
+	default:
+		utils.RespondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+func (h *StockHandler) HandleStockTakeCounts(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPost:
+		h.RecordStockTakeCounts(w, r)
+	default:
+		utils.RespondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+func (h *StockHandler) HandleStockTakeVariance(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.GetStockTakeVariance(w, r)
+	default:
+		utils.RespondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+func (h *StockHandler) HandleFinalizeStockTake(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPost:
+		h.FinalizeStockTake(w, r)
+	default:
+		utils.RespondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+func (h *StockHandler) HandleCancelStockTake(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPost:
+		h.CancelStockTake(w, r)
+	default:
+		utils.RespondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+// StartStockTake godoc
+// @Summary      Mulai Sesi Stock Opname
+// @Description  Membekukan stok saat ini sebagai expected quantity. category_ids kosong berarti semua kategori
+// @Tags         stock-take
+// @Accept       json
+// @Produce      json
+// @Param        data  body      models.StockTakeRequest  true  "Scope opname"
+// @Success      201   {object}  models.StockTake
+// @Router       /api/stock-takes [post]
+func (h *StockHandler) StartStockTake(w http.ResponseWriter, r *http.Request) {
+	var req models.StockTakeRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	st, err := h.service.StartStockTake(req, utils.GetActor(r))
+	if err != nil {
+		respondWithRepoError(w, err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusCreated, st)
+}
+
+// GetStockTakes godoc
+// @Summary      Daftar Sesi Stock Opname
+// @Tags         stock-take
+// @Produce      json
+// @Param        status  query  string  false  "open, finalized, atau cancelled"
+// @Success      200  {array}  models.StockTake
+// @Router       /api/stock-takes [get]
+func (h *StockHandler) GetStockTakes(w http.ResponseWriter, r *http.Request) {
+	takes, err := h.service.GetStockTakes(r.URL.Query().Get("status"))
+	if err != nil {
+		respondWithRepoError(w, err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, takes)
+}
+
+// GetStockTakeByID godoc
+// @Summary      Detail Sesi Stock Opname
+// @Tags         stock-take
+// @Produce      json
+// @Param        id   path      int  true  "Stock Take ID"
+// @Success      200  {object}  models.StockTake
+// @Router       /api/stock-takes/{id} [get]
+func (h *StockHandler) GetStockTakeByID(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid stock take ID")
+		return
+	}
+
+	st, err := h.service.GetStockTakeByID(id)
+	if err != nil {
+		respondWithRepoError(w, err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, st)
+}
+
+// RecordStockTakeCounts godoc
+// @Summary      Input Hasil Hitungan Opname
+// @Description  Mode set menimpa hitungan, mode add menambahkan (boleh dari beberapa device sekaligus)
+// @Tags         stock-take
+// @Accept       json
+// @Produce      json
+// @Param        id    path      int                           true  "Stock Take ID"
+// @Param        data  body      models.StockTakeCountRequest  true  "Hasil hitungan"
+// @Success      200   {array}   models.StockTakeItem
+// @Router       /api/stock-takes/{id}/counts [post]
+func (h *StockHandler) RecordStockTakeCounts(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid stock take ID")
+		return
+	}
+
+	var req models.StockTakeCountRequest
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	items, err := h.service.RecordStockTakeCounts(id, req, utils.GetActor(r))
+	if err != nil {
+		respondWithRepoError(w, err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, items)
+}
+
+// GetStockTakeVariance godoc
+// @Summary      Selisih Stock Opname
+// @Description  Selisih per produk (counted - expected) beserta dampak nilainya berdasarkan harga produk
+// @Tags         stock-take
+// @Produce      json
+// @Param        id                  path   int   true   "Stock Take ID"
+// @Param        only_discrepancies  query  bool  false  "Sembunyikan produk yang hitungannya cocok"
+// @Success      200  {object}  models.StockTakeVariance
+// @Router       /api/stock-takes/{id}/variance [get]
+func (h *StockHandler) GetStockTakeVariance(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid stock take ID")
+		return
+	}
+
+	onlyDiscrepancies, err := parseOptionalBool(r.URL.Query().Get("only_discrepancies"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "invalid only_discrepancies")
+		return
+	}
+
+	report, err := h.service.GetStockTakeVariance(id, onlyDiscrepancies)
+	if err != nil {
+		respondWithRepoError(w, err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, report)
+}
+
+// FinalizeStockTake godoc
+// @Summary      Finalisasi Stock Opname
+// @Description  Memposting semua selisih ke stok dalam satu transaksi database
+// @Tags         stock-take
+// @Accept       json
+// @Produce      json
+// @Param        id    path      int                              true   "Stock Take ID"
+// @Param        data  body      models.StockTakeFinalizeRequest  false  "Opsi finalisasi"
+// @Success      200   {object}  models.StockTakeVariance
+// @Router       /api/stock-takes/{id}/finalize [post]
+func (h *StockHandler) FinalizeStockTake(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid stock take ID")
+		return
+	}
+
+	var req models.StockTakeFinalizeRequest
+	if r.ContentLength != 0 {
+		err = json.NewDecoder(r.Body).Decode(&req)
+		if err != nil {
+			utils.RespondWithError(w, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+	}
+
+	report, err := h.service.FinalizeStockTake(id, req, utils.GetActor(r))
+	if err != nil {
+		respondWithRepoError(w, err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, report)
+}
+
+// CancelStockTake godoc
+// @Summary      Batalkan Sesi Stock Opname
+// @Tags         stock-take
+// @Param        id   path  int  true  "Stock Take ID"
+// @Router       /api/stock-takes/{id}/cancel [post]
+func (h *StockHandler) CancelStockTake(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid stock take ID")
+		return
+	}
+
+	err = h.service.CancelStockTake(id)
+	if err != nil {
+		respondWithRepoError(w, err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, map[string]string{
+		"message": "Stock take cancelled",
+	})
+}
@@ -0,0 +1,253 @@
+package handlers
+
+import (
+	"encoding/json"
+	"kasir-api/models"
+	"kasir-api/utils"
+	"net/http"
+	"strconv"
+)
+
+func (h *StockHandler) HandleStockTransfers(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.GetStockTransfers(w, r)
+	case http.MethodPost:
+		h.CreateStockTransfer(w, r)
+	default:
+		utils.RespondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+func (h *StockHandler) HandleStockTransferByID(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.GetStockTransferByID(w, r)
+	default:
+		utils.RespondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+func (h *StockHandler) HandleShipStockTransfer(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPost:
+		h.ShipStockTransfer(w, r)
+	default:
+		utils.RespondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+func (h *StockHandler) HandleReceiveStockTransfer(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPost:
+		h.ReceiveStockTransfer(w, r)
+	default:
+		utils.RespondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+func (h *StockHandler) HandleCancelStockTransfer(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPost:
+		h.CancelStockTransfer(w, r)
+	default:
+		utils.RespondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+func (h *StockHandler) HandleInTransitStock(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.GetInTransitStock(w, r)
+	default:
+		utils.RespondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+// CreateStockTransfer godoc
+// @Summary      Buat Permintaan Transfer Stok
+// @Description  Transfer antar outlet. Stok belum berubah sampai transfer dikirim
+// @Tags         stock-transfer
+// @Accept       json
+// @Produce      json
+// @Param        data  body      models.StockTransferRequest  true  "Outlet asal, tujuan dan produk"
+// @Success      201   {object}  models.StockTransfer
+// @Router       /api/stock-transfers [post]
+func (h *StockHandler) CreateStockTransfer(w http.ResponseWriter, r *http.Request) {
+	var req models.StockTransferRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	t, err := h.service.CreateStockTransfer(req, utils.GetActor(r))
+	if err != nil {
+		respondWithRepoError(w, err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusCreated, t)
+}
+
+// GetStockTransfers godoc
+// @Summary      Daftar Transfer Stok
+// @Tags         stock-transfer
+// @Produce      json
+// @Param        status     query  string  false  "requested, shipped, received, atau cancelled"
+// @Param        outlet_id  query  int     false  "Transfer dari atau ke outlet ini"
+// @Success      200  {array}  models.StockTransfer
+// @Router       /api/stock-transfers [get]
+func (h *StockHandler) GetStockTransfers(w http.ResponseWriter, r *http.Request) {
+	outletID, ok := parseOutletIDQuery(w, r)
+	if !ok {
+		return
+	}
+
+	transfers, err := h.service.GetStockTransfers(r.URL.Query().Get("status"), outletID)
+	if err != nil {
+		respondWithRepoError(w, err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, transfers)
+}
+
+// GetStockTransferByID godoc
+// @Summary      Detail Transfer Stok
+// @Tags         stock-transfer
+// @Produce      json
+// @Param        id   path      int  true  "Stock Transfer ID"
+// @Success      200  {object}  models.StockTransfer
+// @Router       /api/stock-transfers/{id} [get]
+func (h *StockHandler) GetStockTransferByID(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid stock transfer ID")
+		return
+	}
+
+	t, err := h.service.GetStockTransferByID(id)
+	if err != nil {
+		respondWithRepoError(w, err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, t)
+}
+
+// ShipStockTransfer godoc
+// @Summary      Kirim Transfer Stok
+// @Description  Mengurangi stok outlet asal, barang berstatus in-transit sampai diterima. Body kosong berarti semua dikirim sesuai permintaan
+// @Tags         stock-transfer
+// @Accept       json
+// @Produce      json
+// @Param        id    path      int                                true   "Stock Transfer ID"
+// @Param        data  body      models.StockTransferActionRequest  false  "Quantity yang dikirim per produk"
+// @Success      200   {object}  models.StockTransfer
+// @Router       /api/stock-transfers/{id}/ship [post]
+func (h *StockHandler) ShipStockTransfer(w http.ResponseWriter, r *http.Request) {
+	id, req, ok := decodeStockTransferAction(w, r)
+	if !ok {
+		return
+	}
+
+	t, err := h.service.ShipStockTransfer(id, req, utils.GetActor(r))
+	if err != nil {
+		respondWithRepoError(w, err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, t)
+}
+
+// ReceiveStockTransfer godoc
+// @Summary      Terima Transfer Stok
+// @Description  Menambah stok outlet tujuan. Quantity yang kurang dari yang dikirim dicatat sebagai discrepancy
+// @Tags         stock-transfer
+// @Accept       json
+// @Produce      json
+// @Param        id    path      int                                true   "Stock Transfer ID"
+// @Param        data  body      models.StockTransferActionRequest  false  "Quantity yang diterima per produk"
+// @Success      200   {object}  models.StockTransfer
+// @Router       /api/stock-transfers/{id}/receive [post]
+func (h *StockHandler) ReceiveStockTransfer(w http.ResponseWriter, r *http.Request) {
+	id, req, ok := decodeStockTransferAction(w, r)
+	if !ok {
+		return
+	}
+
+	t, err := h.service.ReceiveStockTransfer(id, req, utils.GetActor(r))
+	if err != nil {
+		respondWithRepoError(w, err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, t)
+}
+
+// CancelStockTransfer godoc
+// @Summary      Batalkan Transfer Stok
+// @Description  Hanya transfer yang belum dikirim
+// @Tags         stock-transfer
+// @Produce      json
+// @Param        id   path      int  true  "Stock Transfer ID"
+// @Success      200  {object}  models.StockTransfer
+// @Router       /api/stock-transfers/{id}/cancel [post]
+func (h *StockHandler) CancelStockTransfer(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid stock transfer ID")
+		return
+	}
+
+	t, err := h.service.CancelStockTransfer(id)
+	if err != nil {
+		respondWithRepoError(w, err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, t)
+}
+
+// GetInTransitStock godoc
+// @Summary      Stok In-Transit
+// @Description  Barang yang sudah dikirim tapi belum diterima, dinilai dengan HPP saat dikirim
+// @Tags         stock-transfer
+// @Produce      json
+// @Param        outlet_id  query  int  false  "Transfer dari atau ke outlet ini"
+// @Success      200  {object}  models.InTransitReport
+// @Router       /api/stock/in-transit [get]
+func (h *StockHandler) GetInTransitStock(w http.ResponseWriter, r *http.Request) {
+	outletID, ok := parseOutletIDQuery(w, r)
+	if !ok {
+		return
+	}
+
+	report, err := h.service.GetInTransitStock(outletID)
+	if err != nil {
+		respondWithRepoError(w, err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, report)
+}
+
+func decodeStockTransferAction(w http.ResponseWriter, r *http.Request) (int, models.StockTransferActionRequest, bool) {
+	var req models.StockTransferActionRequest
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid stock transfer ID")
+		return 0, req, false
+	}
+
+	if r.ContentLength != 0 {
+		err = json.NewDecoder(r.Body).Decode(&req)
+		if err != nil {
+			utils.RespondWithError(w, http.StatusBadRequest, "Invalid request body")
+			return 0, req, false
+		}
+	}
+
+	return id, req, true
+}
@@ -0,0 +1,165 @@
+package handlers
+
+import (
+	"encoding/json"
+	"kasir-api/models"
+	"kasir-api/services"
+	"kasir-api/utils"
+	"net/http"
+	"strconv"
+)
+
+type SupplierHandler struct {
+	service *services.SupplierService
+}
+
+func NewSupplierHandler(service *services.SupplierService) *SupplierHandler {
+	return &SupplierHandler{service: service}
+}
+
+func (h *SupplierHandler) HandleSuppliers(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.GetAllSuppliers(w, r)
+	case http.MethodPost:
+		h.CreateSupplier(w, r)
+	default:
+		utils.RespondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+func (h *SupplierHandler) HandleSupplierByID(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.GetSupplierByID(w, r)
+	case http.MethodPut:
+		h.UpdateSupplier(w, r)
+	case http.MethodDelete:
+		h.DeleteSupplier(w, r)
+	default:
+		utils.RespondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+// GetAllSuppliers godoc
+// @Summary      Daftar Supplier
+// @Tags         suppliers
+// @Produce      json
+// @Param        include_archived  query  bool  false  "Ikut tampilkan supplier yang diarsipkan"
+// @Success      200  {array}  models.Supplier
+// @Router       /api/suppliers [get]
+func (h *SupplierHandler) GetAllSuppliers(w http.ResponseWriter, r *http.Request) {
+	includeArchived, err := parseOptionalBool(r.URL.Query().Get("include_archived"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "invalid include_archived")
+		return
+	}
+
+	suppliers, err := h.service.GetAllSuppliers(includeArchived)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	utils.RespondWithJSON(w, http.StatusOK, suppliers)
+}
+
+// CreateSupplier godoc
+// @Summary      Tambah Supplier
+// @Tags         suppliers
+// @Accept       json
+// @Produce      json
+// @Param        data  body      models.Supplier  true  "Data Supplier"
+// @Success      201   {object}  models.Supplier
+// @Router       /api/suppliers [post]
+func (h *SupplierHandler) CreateSupplier(w http.ResponseWriter, r *http.Request) {
+	var supplier models.Supplier
+	err := json.NewDecoder(r.Body).Decode(&supplier)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	err = h.service.CreateSupplier(&supplier)
+	if err != nil {
+		respondWithRepoError(w, err)
+		return
+	}
+	utils.RespondWithJSON(w, http.StatusCreated, supplier)
+}
+
+// GetSupplierByID godoc
+// @Summary      Ambil Supplier by ID
+// @Tags         suppliers
+// @Produce      json
+// @Param        id   path      int  true  "Supplier ID"
+// @Success      200  {object}  models.Supplier
+// @Router       /api/suppliers/{id} [get]
+func (h *SupplierHandler) GetSupplierByID(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid supplier ID")
+		return
+	}
+
+	supplier, err := h.service.GetSupplierByID(id)
+	if err != nil {
+		respondWithRepoError(w, err)
+		return
+	}
+	utils.RespondWithJSON(w, http.StatusOK, supplier)
+}
+
+// UpdateSupplier godoc
+// @Summary      Update Supplier
+// @Tags         suppliers
+// @Accept       json
+// @Produce      json
+// @Param        id    path      int              true  "Supplier ID"
+// @Param        data  body      models.Supplier  true  "Data Update"
+// @Success      200   {object}  models.Supplier
+// @Router       /api/suppliers/{id} [put]
+func (h *SupplierHandler) UpdateSupplier(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid supplier ID")
+		return
+	}
+
+	var supplier models.Supplier
+	err = json.NewDecoder(r.Body).Decode(&supplier)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	supplier.ID = id
+	err = h.service.UpdateSupplier(&supplier)
+	if err != nil {
+		respondWithRepoError(w, err)
+		return
+	}
+	utils.RespondWithJSON(w, http.StatusOK, supplier)
+}
+
+// DeleteSupplier godoc
+// @Summary      Arsipkan Supplier
+// @Tags         suppliers
+// @Param        id  path  int  true  "Supplier ID"
+// @Router       /api/suppliers/{id} [delete]
+func (h *SupplierHandler) DeleteSupplier(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid supplier ID")
+		return
+	}
+
+	err = h.service.ArchiveSupplier(id)
+	if err != nil {
+		respondWithRepoError(w, err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, map[string]string{
+		"message": "Supplier archived successfully",
+	})
+}
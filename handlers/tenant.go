@@ -0,0 +1,324 @@
+package handlers
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"kasir-api/models"
+	"kasir-api/services"
+	"kasir-api/utils"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// TenantHandler adalah endpoint provisioning tenant, hanya bisa diakses dengan header X-Admin-Key.
+type TenantHandler struct {
+	service  *services.TenantService
+	adminKey string
+}
+
+// NewTenantHandler: adminKey kosong berarti endpoint provisioning dimatikan.
+func NewTenantHandler(service *services.TenantService, adminKey string) *TenantHandler {
+	return &TenantHandler{service: service, adminKey: adminKey}
+}
+
+func (h *TenantHandler) HandleTenants(w http.ResponseWriter, r *http.Request) {
+	if !h.authorize(w, r) {
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		h.GetAllTenants(w, r)
+	case http.MethodPost:
+		h.CreateTenant(w, r)
+	default:
+		utils.RespondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+func (h *TenantHandler) HandleTenantByID(w http.ResponseWriter, r *http.Request) {
+	if !h.authorize(w, r) {
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		h.GetTenantByID(w, r)
+	case http.MethodPut:
+		h.UpdateTenant(w, r)
+	case http.MethodDelete:
+		h.DeleteTenant(w, r)
+	default:
+		utils.RespondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+func (h *TenantHandler) HandleRotateTenantToken(w http.ResponseWriter, r *http.Request) {
+	if !h.authorize(w, r) {
+		return
+	}
+
+	switch r.Method {
+	case http.MethodPost:
+		h.RotateTenantToken(w, r)
+	default:
+		utils.RespondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+func (h *TenantHandler) authorize(w http.ResponseWriter, r *http.Request) bool {
+	if h.adminKey == "" {
+		utils.RespondWithError(w, http.StatusForbidden, "admin api tidak aktif, isi ADMIN_API_KEY")
+		return false
+	}
+	if subtle.ConstantTimeCompare([]byte(r.Header.Get("X-Admin-Key")), []byte(h.adminKey)) != 1 {
+		utils.RespondWithError(w, http.StatusUnauthorized, "X-Admin-Key tidak valid")
+		return false
+	}
+	return true
+}
+
+// GetAllTenants godoc
+// @Summary      Daftar Tenant
+// @Tags         tenants
+// @Produce      json
+// @Param        X-Admin-Key       header  string  true   "Admin API key"
+// @Param        include_archived  query   bool    false  "Ikut tampilkan tenant yang diarsipkan"
+// @Success      200  {array}  models.Tenant
+// @Router       /api/admin/tenants [get]
+func (h *TenantHandler) GetAllTenants(w http.ResponseWriter, r *http.Request) {
+	includeArchived, err := parseOptionalBool(r.URL.Query().Get("include_archived"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "invalid include_archived")
+		return
+	}
+
+	tenants, err := h.service.GetAllTenants(includeArchived)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	utils.RespondWithJSON(w, http.StatusOK, tenants)
+}
+
+// CreateTenant godoc
+// @Summary      Tambah Tenant
+// @Description  Membuat tenant beserta outlet default-nya. api_token hanya ditampilkan sekali di response ini
+// @Tags         tenants
+// @Accept       json
+// @Produce      json
+// @Param        X-Admin-Key  header    string                true  "Admin API key"
+// @Param        data         body      models.TenantRequest  true  "Kode (juga subdomain) dan nama tenant"
+// @Success      201          {object}  models.Tenant
+// @Router       /api/admin/tenants [post]
+func (h *TenantHandler) CreateTenant(w http.ResponseWriter, r *http.Request) {
+	var req models.TenantRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	tenant, err := h.service.CreateTenant(req)
+	if err != nil {
+		respondWithRepoError(w, err)
+		return
+	}
+	utils.RespondWithJSON(w, http.StatusCreated, tenant)
+}
+
+// GetTenantByID godoc
+// @Summary      Ambil Tenant by ID
+// @Tags         tenants
+// @Produce      json
+// @Param        X-Admin-Key  header    string  true  "Admin API key"
+// @Param        id           path      int     true  "Tenant ID"
+// @Success      200          {object}  models.Tenant
+// @Router       /api/admin/tenants/{id} [get]
+func (h *TenantHandler) GetTenantByID(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid tenant ID")
+		return
+	}
+
+	tenant, err := h.service.GetTenantByID(id)
+	if err != nil {
+		respondWithRepoError(w, err)
+		return
+	}
+	utils.RespondWithJSON(w, http.StatusOK, tenant)
+}
+
+// UpdateTenant godoc
+// @Summary      Update Tenant
+// @Description  Hanya nama yang bisa diubah
+// @Tags         tenants
+// @Accept       json
+// @Produce      json
+// @Param        X-Admin-Key  header    string                true  "Admin API key"
+// @Param        id           path      int                   true  "Tenant ID"
+// @Param        data         body      models.TenantRequest  true  "Data Update"
+// @Success      200          {object}  models.Tenant
+// @Router       /api/admin/tenants/{id} [put]
+func (h *TenantHandler) UpdateTenant(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid tenant ID")
+		return
+	}
+
+	var req models.TenantRequest
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	tenant, err := h.service.UpdateTenant(id, req)
+	if err != nil {
+		respondWithRepoError(w, err)
+		return
+	}
+	utils.RespondWithJSON(w, http.StatusOK, tenant)
+}
+
+// DeleteTenant godoc
+// @Summary      Arsipkan Tenant
+// @Description  Tenant yang diarsipkan tidak bisa mengakses api lagi, datanya tetap disimpan
+// @Tags         tenants
+// @Param        X-Admin-Key  header  string  true  "Admin API key"
+// @Param        id           path    int     true  "Tenant ID"
+// @Router       /api/admin/tenants/{id} [delete]
+func (h *TenantHandler) DeleteTenant(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid tenant ID")
+		return
+	}
+
+	err = h.service.ArchiveTenant(id)
+	if err != nil {
+		respondWithRepoError(w, err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, map[string]string{
+		"message": "Tenant archived successfully",
+	})
+}
+
+// RotateTenantToken godoc
+// @Summary      Ganti API Token Tenant
+// @Description  Token lama langsung tidak berlaku
+// @Tags         tenants
+// @Produce      json
+// @Param        X-Admin-Key  header    string  true  "Admin API key"
+// @Param        id           path      int     true  "Tenant ID"
+// @Success      200          {object}  models.Tenant
+// @Router       /api/admin/tenants/{id}/rotate-token [post]
+func (h *TenantHandler) RotateTenantToken(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid tenant ID")
+		return
+	}
+
+	tenant, err := h.service.RotateTenantToken(id)
+	if err != nil {
+		respondWithRepoError(w, err)
+		return
+	}
+	utils.RespondWithJSON(w, http.StatusOK, tenant)
+}
+
+// TenantRouter meneruskan request ke handler milik tenant yang sedang mengakses. Handler tiap tenant
+// dibuat sekali lewat build lalu disimpan, karena semua repository di dalamnya memakai koneksi tenant tersebut.
+type TenantRouter struct {
+	service     *services.TenantService
+	multiTenant bool
+	baseDomain  string
+	build       func(tenant *models.Tenant) (http.Handler, error)
+
+	mu       sync.Mutex
+	handlers map[int]http.Handler
+}
+
+// NewTenantRouter: kalau multiTenant false semua request masuk ke tenant default.
+// baseDomain dipakai untuk membaca kode tenant dari subdomain, misalnya tokoa.kasir.example.com.
+func NewTenantRouter(service *services.TenantService, multiTenant bool, baseDomain string, build func(tenant *models.Tenant) (http.Handler, error)) *TenantRouter {
+	return &TenantRouter{
+		service:     service,
+		multiTenant: multiTenant,
+		baseDomain:  strings.TrimPrefix(strings.ToLower(baseDomain), "."),
+		build:       build,
+		handlers:    make(map[int]http.Handler),
+	}
+}
+
+func (t *TenantRouter) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	tenant := &models.Tenant{ID: models.DefaultTenantID, Code: "default"}
+	if t.multiTenant {
+		var err error
+		tenant, err = t.service.ResolveTenant(bearerToken(r), t.tenantCode(r))
+		if err != nil {
+			respondWithRepoError(w, err)
+			return
+		}
+	}
+
+	handler, err := t.handler(tenant)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	handler.ServeHTTP(w, r)
+}
+
+func (t *TenantRouter) handler(tenant *models.Tenant) (http.Handler, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if h, ok := t.handlers[tenant.ID]; ok {
+		return h, nil
+	}
+
+	h, err := t.build(tenant)
+	if err != nil {
+		return nil, err
+	}
+	t.handlers[tenant.ID] = h
+	return h, nil
+}
+
+// tenantCode dari header X-Tenant, kalau kosong dari subdomain baseDomain. Hanya petunjuk, tenant tetap
+// ditentukan oleh api token (lihat TenantService.ResolveTenant).
+func (t *TenantRouter) tenantCode(r *http.Request) string {
+	if code := strings.TrimSpace(r.Header.Get("X-Tenant")); code != "" {
+		return strings.ToLower(code)
+	}
+	if t.baseDomain == "" {
+		return ""
+	}
+
+	host := r.Host
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	host = strings.ToLower(host)
+	if sub, ok := strings.CutSuffix(host, "."+t.baseDomain); ok && !strings.Contains(sub, ".") {
+		return sub
+	}
+	return ""
+}
+
+func bearerToken(r *http.Request) string {
+	auth := r.Header.Get("Authorization")
+	if token, ok := strings.CutPrefix(auth, "Bearer "); ok {
+		return strings.TrimSpace(token)
+	}
+	return ""
+}
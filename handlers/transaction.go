@@ -3,6 +3,7 @@ package handlers
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"kasir-api/models"
 	"kasir-api/services"
 	"kasir-api/utils"
@@ -45,6 +46,15 @@ func (h *TransactionHandler) GetReportByDate(w http.ResponseWriter, r *http.Requ
 	}
 }
 
+func (h *TransactionHandler) HandleSalesByCategory(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.GetSalesByCategory(w, r)
+	default:
+		utils.RespondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
 // Checkout godoc
 // @Summary      Proses Checkout Transaksi
 // @Tags         Transaction
@@ -64,26 +74,64 @@ func (h *TransactionHandler) Checkout(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	tx, err := h.service.Checkout(req.Items)
+	req.CreatedBy = utils.GetActor(r)
+	tx, err := h.service.Checkout(req)
 	if err != nil {
-		utils.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		respondWithRepoError(w, err)
 		return
 	}
 
 	utils.RespondWithJSON(w, http.StatusOK, tx)
 }
 
+// GenerateTodayReport godoc
+// @Summary      Laporan Penjualan Hari Ini
+// @Description  Tanpa outlet_id laporannya gabungan semua outlet, dengan rincian per outlet. compare menambahkan metrik periode pembanding beserta selisihnya
+// @Tags         Transaction
+// @Produce      json,octet-stream
+// @Param        outlet_id  query  int     false  "Filter outlet"
+// @Param        compare    query  string  false  "Periode pembanding: previous, last_week atau last_year"
+// @Param        tz         query  string  false  "Zona waktu IANA untuk menentukan hari ini, default timezone outlet atau STORE_TIMEZONE"
+// @Param        format     query  string  false  "json (default), csv, xlsx atau pdf"
+// @Success      200  {object}  models.Report
+// @Router       /api/report/hari-ini [get]
 func (h *TransactionHandler) GenerateTodayReport(w http.ResponseWriter, r *http.Request) {
-	date := time.Now()
-	report, err := h.service.GenerateReport(&date, nil)
+	format, ok := parseReportFormat(w, r)
+	if !ok {
+		return
+	}
+	outletID, ok := parseOutletIDQuery(w, r)
+	if !ok {
+		return
+	}
+
+	report, err := h.service.GenerateTodayReport(outletID, r.URL.Query().Get("compare"), r.URL.Query().Get("tz"))
 	if err != nil {
-		utils.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		respondWithRepoError(w, err)
 		return
 	}
-	utils.RespondWithJSON(w, http.StatusOK, report)
+	h.respondWithReport(w, format, report)
 }
 
+// GenerateReportByDate godoc
+// @Summary      Laporan Penjualan per Rentang Tanggal
+// @Description  Tanpa outlet_id laporannya gabungan semua outlet, dengan rincian per outlet. compare menambahkan metrik periode pembanding beserta selisihnya
+// @Tags         Transaction
+// @Produce      json,octet-stream
+// @Param        start_date  query  string  true   "Tanggal awal (YYYY-MM-DD)"
+// @Param        end_date    query  string  false  "Tanggal akhir (YYYY-MM-DD)"
+// @Param        outlet_id   query  int     false  "Filter outlet"
+// @Param        compare     query  string  false  "Periode pembanding: previous, last_week atau last_year"
+// @Param        tz          query  string  false  "Zona waktu IANA, default timezone outlet atau STORE_TIMEZONE"
+// @Param        format      query  string  false  "json (default), csv, xlsx atau pdf"
+// @Success      200  {object}  models.Report
+// @Router       /api/report [get]
 func (h *TransactionHandler) GenerateReportByDate(w http.ResponseWriter, r *http.Request) {
+	format, ok := parseReportFormat(w, r)
+	if !ok {
+		return
+	}
+
 	startStr := r.URL.Query().Get("start_date")
 	endStr := r.URL.Query().Get("end_date")
 
@@ -98,21 +146,73 @@ func (h *TransactionHandler) GenerateReportByDate(w http.ResponseWriter, r *http
 		return
 	}
 
-	var endPtr *time.Time
+	end := start
 	if endStr != "" {
-		parsedEnd, err := time.Parse("2006-01-02", endStr)
+		end, err = time.Parse("2006-01-02", endStr)
 		if err != nil {
 			utils.RespondWithError(w, http.StatusBadRequest, "invalid end_date format")
 			return
 		}
-		endPtr = &parsedEnd
 	}
 
-	fmt.Println(&start, endPtr)
+	outletID, ok := parseOutletIDQuery(w, r)
+	if !ok {
+		return
+	}
 
-	report, err := h.service.GenerateReport(&start, endPtr)
+	report, err := h.service.GenerateReport(start, end, outletID, r.URL.Query().Get("compare"), r.URL.Query().Get("tz"))
 	if err != nil {
-		utils.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		respondWithRepoError(w, err)
+		return
+	}
+	h.respondWithReport(w, format, report)
+}
+
+func (h *TransactionHandler) respondWithReport(w http.ResponseWriter, format string, report *models.Report) {
+	if format == "" {
+		utils.RespondWithJSON(w, http.StatusOK, report)
+		return
+	}
+	respondWithReportFile(w, format, services.ReportFilename("laporan-penjualan", report.From, report.To, format), func(out io.Writer) error {
+		return h.service.ExportReport(format, out, report)
+	})
+}
+
+// GetSalesByCategory godoc
+// @Summary      Laporan Penjualan per Kategori
+// @Description  Quantity, revenue dan porsi revenue per kategori, dibandingkan dengan periode sebelumnya yang panjangnya sama
+// @Tags         Transaction
+// @Produce      json,octet-stream
+// @Param        from       query  string  true   "Tanggal awal (YYYY-MM-DD)"
+// @Param        to         query  string  false  "Tanggal akhir (YYYY-MM-DD), default sama dengan from"
+// @Param        outlet_id  query  int     false  "Filter outlet"
+// @Param        tz         query  string  false  "Zona waktu IANA, default timezone outlet atau STORE_TIMEZONE"
+// @Param        format     query  string  false  "json (default), csv, xlsx atau pdf"
+// @Success      200  {object}  models.CategorySalesReport
+// @Router       /api/report/sales-by-category [get]
+func (h *TransactionHandler) GetSalesByCategory(w http.ResponseWriter, r *http.Request) {
+	format, ok := parseReportFormat(w, r)
+	if !ok {
+		return
+	}
+	from, to, ok := parseDateRangeQuery(w, r)
+	if !ok {
+		return
+	}
+	outletID, ok := parseOutletIDQuery(w, r)
+	if !ok {
+		return
+	}
+
+	report, err := h.service.GetSalesByCategory(from, to, outletID, r.URL.Query().Get("tz"))
+	if err != nil {
+		respondWithRepoError(w, err)
+		return
+	}
+	if format != "" {
+		respondWithReportFile(w, format, services.ReportFilename("penjualan-per-kategori", report.From, report.To, format), func(out io.Writer) error {
+			return h.service.ExportSalesByCategory(format, out, report)
+		})
 		return
 	}
 	utils.RespondWithJSON(w, http.StatusOK, report)
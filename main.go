@@ -1,55 +1,64 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"net/http"
-	"os"
-	"strings"
+	_ "time/tzdata" // zona waktu toko tetap bisa dibaca walau image server tidak punya tzdata
 
 	// Library Swagger
 
+	"kasir-api/config"
 	"kasir-api/database"
 	_ "kasir-api/docs"
+	"kasir-api/notifications"
+	"kasir-api/repositories"
 	"kasir-api/routes"
-
-	"github.com/spf13/viper"
+	"kasir-api/scheduler"
+	"kasir-api/services"
 )
 
-type Config struct {
-	Port   string `mapstructure:"PORT"`
-	DBConn string `mapstructure:"DB_CONN"`
-}
-
 // @title           CodeWithUmam - Task Session 1
 // @version         1.0
 // @description     Task Untuk Session 1.
 // @host            localhost:8080
 // @BasePath        /
 func main() {
-	viper.AutomaticEnv()
-	viper.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+	cfg := config.Load()
 
-	if _, err := os.Stat(".env"); err == nil {
-		viper.SetConfigFile(".env")
-		_ = viper.ReadInConfig()
+	db, err := database.InitDB(cfg.DBConn)
+	if err != nil {
+		log.Fatal("Failed to Initialize Database: ", err)
 	}
+	defer db.Close()
+
+	tenantPool := database.NewTenantPool(cfg.DBConn, cfg.TenantPoolMaxConns, cfg.TenantPoolIdleTimeout)
+	defer tenantPool.Close()
 
-	config := Config{
-		Port:   viper.GetString("PORT"),
-		DBConn: viper.GetString("DB_CONN"),
+	//email laporan terjadwal hanya tersedia kalau SMTP_HOST diisi
+	var mailer *notifications.Mailer
+	if cfg.SMTPHost != "" {
+		mailer = notifications.NewMailer(cfg.SMTPHost, cfg.SMTPPort, cfg.SMTPUsername, cfg.SMTPPassword, cfg.SMTPFrom)
 	}
+	reportNotifiers := notifications.NewReportNotifiers(mailer, cfg.ReportDeliveryDir, cfg.ReportWebhookAllowedHosts)
 
-	db, err := database.InitDB(config.DBConn)
+	routes.RegisterAllRoutes(db, tenantPool, reportNotifiers, cfg)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	tenantService := services.NewTenantService(repositories.NewTenantRepository(db), cfg.TenantRequireToken)
+	scheduler.NewPriceScheduler(tenantService, tenantPool, cfg.PriceSchedulerInterval).Start(ctx)
+
+	reportScheduler, err := scheduler.NewReportScheduler(tenantService, tenantPool, reportNotifiers, cfg)
 	if err != nil {
-		log.Fatal("Failed to Initialize Database: ", err)
+		log.Fatal("Failed to start report scheduler: ", err)
 	}
-	defer db.Close()
-
-	routes.RegisterAllRoutes(db)
+	reportScheduler.Start(ctx)
 
-	fmt.Println("server running di localhost: " + config.Port)
-	err = http.ListenAndServe(":"+config.Port, nil)
+	fmt.Println("server running di localhost: " + cfg.Port)
+	err = http.ListenAndServe(":"+cfg.Port, nil)
 	if err != nil {
 		fmt.Println("gagal running server")
 	}
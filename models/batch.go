@@ -0,0 +1,33 @@
+package models
+
+import "time"
+
+type ProductBatch struct {
+	ID               int       `json:"id"`
+	ProductID        int       `json:"product_id"`
+	ProductName      string    `json:"product_name,omitempty"`
+	OutletID         int       `json:"outlet_id"`
+	BatchNumber      string    `json:"batch_number"`
+	ExpiryDate       string    `json:"expiry_date,omitempty"` // YYYY-MM-DD
+	Quantity         int       `json:"quantity"`
+	ReceivedQuantity int       `json:"received_quantity"`
+	CreatedAt        time.Time `json:"created_at"`
+	Expired          bool      `json:"expired"`
+	DaysUntilExpiry  *int      `json:"days_until_expiry,omitempty"`
+	// nilai sisa batch dengan harga pokok produk, hanya diisi di laporan kadaluarsa
+	Value int `json:"value,omitempty"`
+}
+
+type ExpiryReport struct {
+	Days          int            `json:"days"`
+	TotalQuantity int            `json:"total_quantity"`
+	TotalValue    int            `json:"total_value"`
+	Items         []ProductBatch `json:"items"`
+}
+
+type TransactionDetailBatch struct {
+	BatchID     int    `json:"batch_id"`
+	BatchNumber string `json:"batch_number"`
+	ExpiryDate  string `json:"expiry_date,omitempty"`
+	Quantity    int    `json:"quantity"`
+}
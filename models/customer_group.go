@@ -0,0 +1,7 @@
+package models
+
+type CustomerGroup struct {
+	ID          int    `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
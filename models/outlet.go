@@ -0,0 +1,47 @@
+package models
+
+import "time"
+
+const (
+	OutletTypeStore     = "store"
+	OutletTypeWarehouse = "warehouse"
+)
+
+// Outlet.IsDefault dipakai untuk request yang tidak menyebut outlet_id, dan untuk data sebelum multi-outlet.
+// Timezone kosong berarti laporan outlet ini memakai STORE_TIMEZONE.
+type Outlet struct {
+	ID         int        `json:"id"`
+	Code       string     `json:"code"`
+	Name       string     `json:"name"`
+	Type       string     `json:"type"`
+	Address    string     `json:"address"`
+	Timezone   string     `json:"timezone"`
+	IsDefault  bool       `json:"is_default"`
+	CreatedAt  time.Time  `json:"created_at"`
+	ArchivedAt *time.Time `json:"archived_at,omitempty"`
+}
+
+// OutletStock.Price adalah harga dasar di outlet tersebut, PriceOverride diisi kalau berbeda dari products.price.
+type OutletStock struct {
+	ProductID     int    `json:"product_id"`
+	SKU           string `json:"sku,omitempty"`
+	ProductName   string `json:"product_name"`
+	CategoryID    int    `json:"category_id"`
+	Stock         int    `json:"stock"`
+	Price         int    `json:"price"`
+	PriceOverride *int   `json:"price_override,omitempty"`
+}
+
+type OutletPrice struct {
+	OutletID    int       `json:"outlet_id"`
+	ProductID   int       `json:"product_id"`
+	ProductName string    `json:"product_name"`
+	BasePrice   int       `json:"base_price"`
+	Price       int       `json:"price"`
+	UpdatedBy   string    `json:"updated_by"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+type OutletPriceRequest struct {
+	Price int `json:"price"`
+}
@@ -0,0 +1,39 @@
+package models
+
+import "time"
+
+const (
+	PriceChangePending   = "pending"
+	PriceChangeApplied   = "applied"
+	PriceChangeCancelled = "cancelled"
+	PriceChangeFailed    = "failed"
+)
+
+type PriceHistory struct {
+	ID        int       `json:"id"`
+	ProductID int       `json:"product_id"`
+	OldPrice  int       `json:"old_price"`
+	NewPrice  int       `json:"new_price"`
+	ChangedBy string    `json:"changed_by"`
+	ChangedAt time.Time `json:"changed_at"`
+}
+
+type ScheduledPriceChange struct {
+	ID          int        `json:"id"`
+	ProductID   int        `json:"product_id"`
+	NewPrice    int        `json:"new_price"`
+	EffectiveAt time.Time  `json:"effective_at"`
+	Status      string     `json:"status"`
+	CreatedBy   string     `json:"created_by"`
+	CreatedAt   time.Time  `json:"created_at"`
+	AppliedAt   *time.Time `json:"applied_at,omitempty"`
+	Error       string     `json:"error,omitempty"`
+}
+
+type PriceTier struct {
+	ID              int  `json:"id"`
+	ProductID       int  `json:"product_id"`
+	CustomerGroupID *int `json:"customer_group_id"`
+	MinQuantity     int  `json:"min_quantity"`
+	Price           int  `json:"price"`
+}
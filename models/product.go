@@ -1,14 +1,60 @@
 package models
 
+import "time"
+
+// Product.ReorderPoint: stok <= ReorderPoint dianggap menipis, 0 berarti tidak dipantau.
 type Product struct {
-	ID         int    `json:"id"`
-	CategoryID int    `json:"category_id"`
-	Name       string `json:"name"`
-	Price      int    `json:"price"`
-	Stock      int    `json:"stock"`
+	ID              int        `json:"id"`
+	CategoryID      int        `json:"category_id"`
+	SKU             string     `json:"sku"`
+	Barcode         string     `json:"barcode"`
+	Name            string     `json:"name"`
+	Price           int        `json:"price"`
+	CostPrice       int        `json:"cost_price"`
+	ReorderPoint    int        `json:"reorder_point"`
+	ReorderQuantity int        `json:"reorder_quantity"`
+	Stock           int        `json:"stock"`
+	CreatedAt       time.Time  `json:"created_at"`
+	ArchivedAt      *time.Time `json:"archived_at,omitempty"`
 }
 
 type ProductWithCategory struct {
 	Product
-	CategoryName string `json:"category_name"`
+	CategoryName      string `json:"category_name"`
+	ImageURL          string `json:"image_url"`
+	ImageThumbnailURL string `json:"image_thumbnail_url"`
+}
+
+type ProductFilter struct {
+	Name              string
+	CategoryID        *int
+	MinPrice          *int
+	MaxPrice          *int
+	InStock           *bool
+	LowStock          bool
+	LowStockThreshold int
+	IncludeArchived   bool
+	Sort              string
+	Order             string
+	Limit             int
+	Offset            int
+	Cursor            string
+}
+
+type PageMeta struct {
+	Total      int    `json:"total"`
+	Limit      int    `json:"limit"`
+	Offset     int    `json:"offset"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+type ProductPage struct {
+	Data []*ProductWithCategory `json:"data"`
+	Meta PageMeta               `json:"meta"`
+}
+
+type ProductSearchResult struct {
+	ProductWithCategory
+	Rank      float64 `json:"rank"`
+	Highlight string  `json:"highlight"`
 }
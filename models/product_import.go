@@ -0,0 +1,31 @@
+package models
+
+// ProductImportRow adalah satu baris file import yang sudah lolos validasi format.
+// Stock nil berarti kolom stok kosong: produk baru mulai dari 0, produk lama stoknya tidak diubah.
+type ProductImportRow struct {
+	Row          int
+	SKU          string
+	Barcode      string
+	Name         string
+	CategoryName string
+	Price        int
+	Stock        *int
+}
+
+type ProductImportError struct {
+	Row     int    `json:"row"`
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+}
+
+type ProductImportResult struct {
+	DryRun            bool                 `json:"dry_run"`
+	Applied           bool                 `json:"applied"`
+	TotalRows         int                  `json:"total_rows"`
+	Created           int                  `json:"created"`
+	Updated           int                  `json:"updated"`
+	CategoriesCreated []string             `json:"categories_created"`
+	Errors            []ProductImportError `json:"errors"`
+	// baris yang tetap diimport tapi sebagian isinya diabaikan
+	Warnings []ProductImportError `json:"warnings"`
+}
@@ -0,0 +1,117 @@
+package models
+
+import "time"
+
+const (
+	PurchaseOrderDraft             = "draft"
+	PurchaseOrderSent              = "sent"
+	PurchaseOrderPartiallyReceived = "partially_received"
+	PurchaseOrderReceived          = "received"
+	PurchaseOrderCancelled         = "cancelled"
+)
+
+type PurchaseOrder struct {
+	ID           int                 `json:"id"`
+	SupplierID   int                 `json:"supplier_id"`
+	SupplierName string              `json:"supplier_name"`
+	OutletID     int                 `json:"outlet_id"`
+	Status       string              `json:"status"`
+	ExpectedDate string              `json:"expected_date,omitempty"` // YYYY-MM-DD
+	Note         string              `json:"note"`
+	TotalAmount  int                 `json:"total_amount"`
+	CreatedBy    string              `json:"created_by"`
+	CreatedAt    time.Time           `json:"created_at"`
+	UpdatedAt    time.Time           `json:"updated_at"`
+	Lines        []PurchaseOrderLine `json:"lines,omitempty"`
+	Receipts     []GoodsReceipt      `json:"receipts,omitempty"`
+}
+
+type PurchaseOrderLine struct {
+	ID               int    `json:"id"`
+	ProductID        int    `json:"product_id"`
+	ProductName      string `json:"product_name,omitempty"`
+	Quantity         int    `json:"quantity"`
+	ReceivedQuantity int    `json:"received_quantity"`
+	UnitCost         int    `json:"unit_cost"`
+	Subtotal         int    `json:"subtotal"`
+}
+
+// PurchaseOrderRequest.OutletID adalah outlet tujuan pengiriman, kosong berarti outlet default.
+type PurchaseOrderRequest struct {
+	SupplierID   int                 `json:"supplier_id"`
+	OutletID     int                 `json:"outlet_id"`
+	ExpectedDate string              `json:"expected_date"`
+	Note         string              `json:"note"`
+	Lines        []PurchaseOrderLine `json:"lines"`
+}
+
+type GoodsReceipt struct {
+	ID              int                `json:"id"`
+	PurchaseOrderID *int               `json:"purchase_order_id,omitempty"`
+	SupplierID      int                `json:"supplier_id"`
+	SupplierName    string             `json:"supplier_name"`
+	OutletID        int                `json:"outlet_id"`
+	Reference       string             `json:"reference"`
+	Note            string             `json:"note"`
+	TotalAmount     int                `json:"total_amount"`
+	ReceivedBy      string             `json:"received_by"`
+	ReceivedAt      time.Time          `json:"received_at"`
+	Lines           []GoodsReceiptLine `json:"lines"`
+}
+
+// GoodsReceiptLine.UnitCost boleh dikosongkan saat menerima PO, defaultnya unit_cost di PO.
+// Kalau BatchNumber atau ExpiryDate diisi, barang masuk ke batch tersebut (batch dibuat kalau belum ada).
+type GoodsReceiptLine struct {
+	ID          int    `json:"id"`
+	ProductID   int    `json:"product_id"`
+	ProductName string `json:"product_name,omitempty"`
+	Quantity    int    `json:"quantity"`
+	UnitCost    *int   `json:"unit_cost,omitempty"`
+	Subtotal    int    `json:"subtotal"`
+	BatchID     *int   `json:"batch_id,omitempty"`
+	BatchNumber string `json:"batch_number,omitempty"`
+	ExpiryDate  string `json:"expiry_date,omitempty"`
+}
+
+// GoodsReceiptRequest.SupplierID dan OutletID hanya dipakai untuk penerimaan tanpa PO, saat menerima PO
+// keduanya diambil dari PO-nya.
+type GoodsReceiptRequest struct {
+	SupplierID int                `json:"supplier_id,omitempty"`
+	OutletID   int                `json:"outlet_id,omitempty"`
+	Reference  string             `json:"reference"`
+	Note       string             `json:"note"`
+	Lines      []GoodsReceiptLine `json:"lines"`
+}
+
+type SupplierReturn struct {
+	ID           int                  `json:"id"`
+	SupplierID   int                  `json:"supplier_id"`
+	SupplierName string               `json:"supplier_name"`
+	OutletID     int                  `json:"outlet_id"`
+	Reference    string               `json:"reference"`
+	Note         string               `json:"note"`
+	TotalAmount  int                  `json:"total_amount"`
+	CreatedBy    string               `json:"created_by"`
+	CreatedAt    time.Time            `json:"created_at"`
+	Lines        []SupplierReturnLine `json:"lines"`
+}
+
+// SupplierReturnLine.UnitCost kosong berarti memakai harga pokok produk saat ini.
+// SupplierReturnLine.BatchID kosong berarti batch dikurangi FEFO, termasuk batch yang sudah kadaluarsa.
+type SupplierReturnLine struct {
+	ID          int    `json:"id"`
+	ProductID   int    `json:"product_id"`
+	ProductName string `json:"product_name,omitempty"`
+	BatchID     *int   `json:"batch_id,omitempty"`
+	Quantity    int    `json:"quantity"`
+	UnitCost    *int   `json:"unit_cost,omitempty"`
+	Subtotal    int    `json:"subtotal"`
+}
+
+type SupplierReturnRequest struct {
+	SupplierID int                  `json:"supplier_id"`
+	OutletID   int                  `json:"outlet_id"`
+	Reference  string               `json:"reference"`
+	Note       string               `json:"note"`
+	Lines      []SupplierReturnLine `json:"lines"`
+}
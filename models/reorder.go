@@ -0,0 +1,37 @@
+package models
+
+import "time"
+
+type LowStockProduct struct {
+	ProductID       int    `json:"product_id"`
+	SKU             string `json:"sku"`
+	Name            string `json:"name"`
+	CategoryID      int    `json:"category_id"`
+	CategoryName    string `json:"category_name"`
+	Stock           int    `json:"stock"`
+	ReorderPoint    int    `json:"reorder_point"`
+	ReorderQuantity int    `json:"reorder_quantity"`
+}
+
+// ReorderSuggestion.DaysOfStockLeft nil kalau produk tidak terjual selama window.
+type ReorderSuggestion struct {
+	LowStockProduct
+	SoldQuantity      int      `json:"sold_quantity"`
+	AvgDailySales     float64  `json:"avg_daily_sales"`
+	DaysOfStockLeft   *float64 `json:"days_of_stock_left"`
+	OnOrderQuantity   int      `json:"on_order_quantity"`
+	SuggestedQuantity int      `json:"suggested_quantity"`
+}
+
+type ReorderSuggestionReport struct {
+	WindowDays  int                 `json:"window_days"`
+	CoverDays   int                 `json:"cover_days"`
+	GeneratedAt time.Time           `json:"generated_at"`
+	Items       []ReorderSuggestion `json:"items"`
+}
+
+// LowStockAlert dikirim ke webhook saat checkout membuat stok produk turun ke reorder point atau di bawahnya.
+type LowStockAlert struct {
+	TransactionID int               `json:"transaction_id"`
+	Products      []LowStockProduct `json:"products"`
+}
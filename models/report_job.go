@@ -0,0 +1,86 @@
+package models
+
+import "time"
+
+const (
+	ReportTypeSales           = "sales"
+	ReportTypeSalesByCategory = "sales_by_category"
+	ReportTypeProductRankings = "product_rankings"
+	ReportTypeSalesTimeseries = "sales_timeseries"
+)
+
+// rentang laporan dihitung dari waktu job dijalankan, di zona waktu job
+const (
+	ReportRangeToday      = "today"
+	ReportRangeYesterday  = "yesterday"
+	ReportRangeLast7Days  = "last_7_days"
+	ReportRangeLast30Days = "last_30_days"
+	ReportRangeLastWeek   = "last_week"
+	ReportRangeLastMonth  = "last_month"
+)
+
+const (
+	ReportChannelEmail     = "email"
+	ReportChannelWebhook   = "webhook"
+	ReportChannelDirectory = "directory"
+)
+
+const (
+	ReportRunRunning = "running"
+	ReportRunSuccess = "success"
+	ReportRunFailed  = "failed"
+)
+
+// ReportJob.Target tergantung channel: daftar email dipisah koma, URL webhook publik, atau nama subfolder
+// di REPORT_DELIVERY_DIR (boleh kosong). Timezone kosong berarti timezone outlet atau STORE_TIMEZONE,
+// dipakai untuk cron sekaligus batas hari laporan.
+type ReportJob struct {
+	ID         int        `json:"id"`
+	Name       string     `json:"name"`
+	Cron       string     `json:"cron"`
+	Timezone   string     `json:"timezone"`
+	ReportType string     `json:"report_type"`
+	Range      string     `json:"range"`
+	Format     string     `json:"format"`
+	OutletID   *int       `json:"outlet_id,omitempty"`
+	Compare    string     `json:"compare,omitempty"`
+	Channel    string     `json:"channel"`
+	Target     string     `json:"target"`
+	Active     bool       `json:"active"`
+	NextRunAt  *time.Time `json:"next_run_at,omitempty"`
+	LastRunAt  *time.Time `json:"last_run_at,omitempty"`
+	CreatedBy  string     `json:"created_by"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// ReportJobRequest.Active kosong berarti aktif.
+type ReportJobRequest struct {
+	Name       string `json:"name"`
+	Cron       string `json:"cron"`
+	Timezone   string `json:"timezone"`
+	ReportType string `json:"report_type"`
+	Range      string `json:"range"`
+	Format     string `json:"format"`
+	OutletID   *int   `json:"outlet_id,omitempty"`
+	Compare    string `json:"compare,omitempty"`
+	Channel    string `json:"channel"`
+	Target     string `json:"target"`
+	Active     *bool  `json:"active,omitempty"`
+}
+
+// ReportRun.FileName kosong kalau laporan gagal dibuat. Laporan yang berhasil dibuat tapi gagal dikirim
+// berstatus failed dengan Delivered false, file-nya tetap bisa diunduh.
+type ReportRun struct {
+	ID          int        `json:"id"`
+	ReportJobID int        `json:"report_job_id"`
+	PeriodFrom  string     `json:"period_from"`
+	PeriodTo    string     `json:"period_to"`
+	Status      string     `json:"status"`
+	FileName    string     `json:"file_name,omitempty"`
+	FilePath    string     `json:"-"`
+	FileSize    int        `json:"file_size"`
+	Delivered   bool       `json:"delivered"`
+	Error       string     `json:"error,omitempty"`
+	StartedAt   time.Time  `json:"started_at"`
+	FinishedAt  *time.Time `json:"finished_at,omitempty"`
+}
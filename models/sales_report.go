@@ -0,0 +1,68 @@
+package models
+
+import "time"
+
+const (
+	GranularityHour  = "hour"
+	GranularityDay   = "day"
+	GranularityWeek  = "week"
+	GranularityMonth = "month"
+)
+
+// SalesBucket.AverageBasket adalah rata-rata nilai per transaksi di bucket tersebut.
+type SalesBucket struct {
+	Start            time.Time `json:"start"`
+	End              time.Time `json:"end"`
+	Revenue          int       `json:"revenue"`
+	TransactionCount int       `json:"transaction_count"`
+	ItemsSold        int       `json:"items_sold"`
+	AverageBasket    int       `json:"average_basket"`
+}
+
+// SalesTimeseries berisi semua bucket dari From sampai To, bucket tanpa penjualan bernilai 0.
+// Bucket week dimulai hari Senin.
+type SalesTimeseries struct {
+	From             string        `json:"from"`
+	To               string        `json:"to"`
+	Granularity      string        `json:"granularity"`
+	Timezone         string        `json:"timezone"`
+	OutletID         *int          `json:"outlet_id,omitempty"`
+	TotalRevenue     int           `json:"total_revenue"`
+	TransactionCount int           `json:"transaction_count"`
+	ItemsSold        int           `json:"items_sold"`
+	Buckets          []SalesBucket `json:"buckets"`
+}
+
+const (
+	RankByQuantity = "quantity"
+	RankByRevenue  = "revenue"
+	RankByProfit   = "profit"
+)
+
+// ProductRanking.ABCClass dihitung dari kontribusi revenue kumulatif: A sampai 80%, B sampai 95%, sisanya C.
+// Profit memakai HPP FIFO yang dicatat saat checkout.
+type ProductRanking struct {
+	Rank                int     `json:"rank"`
+	ProductID           int     `json:"product_id"`
+	SKU                 string  `json:"sku,omitempty"`
+	ProductName         string  `json:"product_name"`
+	CategoryID          int     `json:"category_id"`
+	CategoryName        string  `json:"category_name"`
+	Quantity            int     `json:"quantity"`
+	Revenue             int     `json:"revenue"`
+	Profit              int     `json:"profit"`
+	RevenueContribution float64 `json:"revenue_contribution"`
+	ABCClass            string  `json:"abc_class"`
+}
+
+type ProductRankingReport struct {
+	From         string           `json:"from"`
+	To           string           `json:"to"`
+	By           string           `json:"by"`
+	Order        string           `json:"order"`
+	Timezone     string           `json:"timezone"`
+	OutletID     *int             `json:"outlet_id,omitempty"`
+	CategoryID   *int             `json:"category_id,omitempty"`
+	TotalRevenue int              `json:"total_revenue"`
+	Products     []ProductRanking `json:"products"`
+}
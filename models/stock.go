@@ -0,0 +1,49 @@
+package models
+
+import "time"
+
+const (
+	StockMovementSale       = "sale"
+	StockMovementRefund     = "refund"
+	StockMovementAdjustment = "adjustment"
+	StockMovementReceipt    = "receipt"
+	StockMovementReturn     = "supplier_return"
+	StockMovementTransfer   = "transfer"
+	StockMovementOpname     = "opname"
+)
+
+// StockMovement.StockAfter adalah total semua outlet, OutletStockAfter saldo di outlet movement ini.
+type StockMovement struct {
+	ID               int64     `json:"id"`
+	ProductID        int       `json:"product_id"`
+	OutletID         int       `json:"outlet_id"`
+	Type             string    `json:"type"`
+	Quantity         int       `json:"quantity"`
+	StockAfter       int       `json:"stock_after"`
+	OutletStockAfter int       `json:"outlet_stock_after"`
+	ReferenceType    string    `json:"reference_type,omitempty"`
+	ReferenceID      *int      `json:"reference_id,omitempty"`
+	Note             string    `json:"note,omitempty"`
+	CreatedBy        string    `json:"created_by"`
+	CreatedAt        time.Time `json:"created_at"`
+}
+
+type StockMovementPage struct {
+	Data []*StockMovement `json:"data"`
+	Meta PageMeta         `json:"meta"`
+}
+
+type StockConsistencyIssue struct {
+	ProductID   int    `json:"product_id"`
+	ProductName string `json:"product_name"`
+	Stock       int    `json:"stock"`
+	LedgerStock int    `json:"ledger_stock"`
+	OutletStock int    `json:"outlet_stock"`
+	Difference  int    `json:"difference"`
+}
+
+type StockConsistencyReport struct {
+	CheckedProducts int                     `json:"checked_products"`
+	Consistent      bool                    `json:"consistent"`
+	Issues          []StockConsistencyIssue `json:"issues"`
+}
@@ -0,0 +1,55 @@
+package models
+
+import "time"
+
+const (
+	AdjustmentStatusPending  = "pending"
+	AdjustmentStatusApplied  = "applied"
+	AdjustmentStatusRejected = "rejected"
+)
+
+const (
+	AdjustmentReasonDamaged    = "damaged"
+	AdjustmentReasonExpired    = "expired"
+	AdjustmentReasonLost       = "lost"
+	AdjustmentReasonFound      = "found"
+	AdjustmentReasonCorrection = "correction"
+)
+
+type StockAdjustment struct {
+	ID         int                   `json:"id"`
+	OutletID   int                   `json:"outlet_id"`
+	Status     string                `json:"status"`
+	Note       string                `json:"note"`
+	CreatedBy  string                `json:"created_by"`
+	CreatedAt  time.Time             `json:"created_at"`
+	ReviewedBy string                `json:"reviewed_by,omitempty"`
+	ReviewedAt *time.Time            `json:"reviewed_at,omitempty"`
+	ReviewNote string                `json:"review_note,omitempty"`
+	Lines      []StockAdjustmentLine `json:"lines"`
+}
+
+// StockAdjustmentLine.Quantity bertanda: negatif mengurangi stok (damaged, expired, lost),
+// positif menambah (found), correction boleh keduanya. BatchID diisi kalau adjustment untuk batch tertentu,
+// misalnya membuang batch yang sudah kadaluarsa.
+type StockAdjustmentLine struct {
+	ID          int    `json:"id"`
+	ProductID   int    `json:"product_id"`
+	ProductName string `json:"product_name,omitempty"`
+	BatchID     *int   `json:"batch_id,omitempty"`
+	Quantity    int    `json:"quantity"`
+	Reason      string `json:"reason"`
+	Note        string `json:"note"`
+}
+
+// StockAdjustmentRequest.OutletID kosong berarti outlet default.
+type StockAdjustmentRequest struct {
+	OutletID        int                   `json:"outlet_id"`
+	Note            string                `json:"note"`
+	RequireApproval bool                  `json:"require_approval"`
+	Lines           []StockAdjustmentLine `json:"lines"`
+}
+
+type StockAdjustmentReview struct {
+	Note string `json:"note"`
+}
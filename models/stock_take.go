@@ -0,0 +1,74 @@
+package models
+
+import "time"
+
+const (
+	StockTakeStatusOpen      = "open"
+	StockTakeStatusFinalized = "finalized"
+	StockTakeStatusCancelled = "cancelled"
+)
+
+// set menimpa hasil hitungan, add menambahkan (untuk beberapa device yang menghitung rak berbeda)
+const (
+	StockTakeCountSet = "set"
+	StockTakeCountAdd = "add"
+)
+
+type StockTake struct {
+	ID           int        `json:"id"`
+	OutletID     int        `json:"outlet_id"`
+	Status       string     `json:"status"`
+	Note         string     `json:"note"`
+	CategoryIDs  []int      `json:"category_ids"`
+	CreatedBy    string     `json:"created_by"`
+	CreatedAt    time.Time  `json:"created_at"`
+	FinalizedBy  string     `json:"finalized_by,omitempty"`
+	FinalizedAt  *time.Time `json:"finalized_at,omitempty"`
+	TotalItems   int        `json:"total_items"`
+	CountedItems int        `json:"counted_items"`
+}
+
+// StockTakeRequest.OutletID kosong berarti outlet default.
+type StockTakeRequest struct {
+	OutletID    int    `json:"outlet_id"`
+	Note        string `json:"note"`
+	CategoryIDs []int  `json:"category_ids"`
+}
+
+type StockTakeCount struct {
+	ProductID int    `json:"product_id"`
+	Quantity  int    `json:"quantity"`
+	Mode      string `json:"mode"`
+}
+
+type StockTakeCountRequest struct {
+	Device string           `json:"device"`
+	Counts []StockTakeCount `json:"counts"`
+}
+
+// StockTakeItem.Variance = counted - expected, ValueImpact = Variance * UnitPrice.
+// Keduanya nil selama produk belum dihitung.
+type StockTakeItem struct {
+	ProductID        int        `json:"product_id"`
+	ProductName      string     `json:"product_name"`
+	CategoryName     string     `json:"category_name"`
+	ExpectedQuantity int        `json:"expected_quantity"`
+	CountedQuantity  *int       `json:"counted_quantity"`
+	UnitPrice        int        `json:"unit_price"`
+	Variance         *int       `json:"variance"`
+	ValueImpact      *int       `json:"value_impact"`
+	UpdatedAt        *time.Time `json:"updated_at,omitempty"`
+}
+
+type StockTakeVariance struct {
+	StockTake        StockTake       `json:"stock_take"`
+	Items            []StockTakeItem `json:"items"`
+	UncountedItems   int             `json:"uncounted_items"`
+	TotalVariance    int             `json:"total_variance"`
+	TotalValueImpact int             `json:"total_value_impact"`
+}
+
+type StockTakeFinalizeRequest struct {
+	// kalau true produk yang tidak dihitung dianggap 0, kalau false dibiarkan apa adanya
+	ZeroUncounted bool `json:"zero_uncounted"`
+}
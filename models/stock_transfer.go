@@ -0,0 +1,78 @@
+package models
+
+import "time"
+
+const (
+	StockTransferRequested = "requested"
+	StockTransferShipped   = "shipped"
+	StockTransferReceived  = "received"
+	StockTransferCancelled = "cancelled"
+)
+
+type StockTransfer struct {
+	ID                    int                 `json:"id"`
+	SourceOutletID        int                 `json:"source_outlet_id"`
+	SourceOutletName      string              `json:"source_outlet_name"`
+	DestinationOutletID   int                 `json:"destination_outlet_id"`
+	DestinationOutletName string              `json:"destination_outlet_name"`
+	Status                string              `json:"status"`
+	Note                  string              `json:"note"`
+	RequestedBy           string              `json:"requested_by"`
+	RequestedAt           time.Time           `json:"requested_at"`
+	ShippedBy             string              `json:"shipped_by,omitempty"`
+	ShippedAt             *time.Time          `json:"shipped_at,omitempty"`
+	ReceivedBy            string              `json:"received_by,omitempty"`
+	ReceivedAt            *time.Time          `json:"received_at,omitempty"`
+	Lines                 []StockTransferLine `json:"lines,omitempty"`
+}
+
+// StockTransferLine.Discrepancy adalah shipped - received, diisi setelah transfer diterima.
+// Selisih positif berarti barang hilang/rusak di jalan dan tidak masuk ke stok outlet tujuan.
+type StockTransferLine struct {
+	ID               int                      `json:"id"`
+	ProductID        int                      `json:"product_id"`
+	ProductName      string                   `json:"product_name,omitempty"`
+	Quantity         int                      `json:"quantity"`
+	ShippedQuantity  int                      `json:"shipped_quantity"`
+	ShippedCost      int                      `json:"shipped_cost"`
+	ReceivedQuantity *int                     `json:"received_quantity,omitempty"`
+	Discrepancy      int                      `json:"discrepancy"`
+	Note             string                   `json:"note,omitempty"`
+	Batches          []TransactionDetailBatch `json:"batches,omitempty"`
+}
+
+type StockTransferRequest struct {
+	SourceOutletID      int                 `json:"source_outlet_id"`
+	DestinationOutletID int                 `json:"destination_outlet_id"`
+	Note                string              `json:"note"`
+	Lines               []StockTransferLine `json:"lines"`
+}
+
+// StockTransferQuantity dipakai saat kirim dan terima. Produk yang tidak disebut dianggap
+// dikirim sesuai permintaan / diterima sesuai yang dikirim.
+type StockTransferQuantity struct {
+	ProductID int    `json:"product_id"`
+	Quantity  int    `json:"quantity"`
+	Note      string `json:"note"`
+}
+
+type StockTransferActionRequest struct {
+	Lines []StockTransferQuantity `json:"lines"`
+}
+
+type InTransitItem struct {
+	TransferID          int       `json:"transfer_id"`
+	SourceOutletID      int       `json:"source_outlet_id"`
+	DestinationOutletID int       `json:"destination_outlet_id"`
+	ProductID           int       `json:"product_id"`
+	ProductName         string    `json:"product_name"`
+	Quantity            int       `json:"quantity"`
+	Value               int       `json:"value"`
+	ShippedAt           time.Time `json:"shipped_at"`
+}
+
+type InTransitReport struct {
+	TotalQuantity int             `json:"total_quantity"`
+	TotalValue    int             `json:"total_value"`
+	Items         []InTransitItem `json:"items"`
+}
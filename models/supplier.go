@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+type Supplier struct {
+	ID          int        `json:"id"`
+	Name        string     `json:"name"`
+	ContactName string     `json:"contact_name"`
+	Phone       string     `json:"phone"`
+	Email       string     `json:"email"`
+	Address     string     `json:"address"`
+	CreatedAt   time.Time  `json:"created_at"`
+	ArchivedAt  *time.Time `json:"archived_at,omitempty"`
+}
@@ -0,0 +1,21 @@
+package models
+
+import "time"
+
+// DefaultTenantID adalah tenant untuk data sebelum multi-tenant, dan satu-satunya tenant kalau MULTI_TENANT mati.
+const DefaultTenantID = 1
+
+// Tenant.APIToken hanya dikembalikan saat tenant dibuat atau token di-rotate.
+type Tenant struct {
+	ID         int        `json:"id"`
+	Code       string     `json:"code"`
+	Name       string     `json:"name"`
+	APIToken   string     `json:"api_token,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	ArchivedAt *time.Time `json:"archived_at,omitempty"`
+}
+
+type TenantRequest struct {
+	Code string `json:"code"`
+	Name string `json:"name"`
+}
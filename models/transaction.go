@@ -1,9 +1,13 @@
 package models
 
 type Transaction struct {
-	ID          int                 `json:"id"`
-	TotalAmount int                 `json:"total_amount"`
-	Details     []TransactionDetail `json:"details"`
+	ID              int                 `json:"id"`
+	OutletID        int                 `json:"outlet_id"`
+	CustomerGroupID *int                `json:"customer_group_id,omitempty"`
+	TotalAmount     int                 `json:"total_amount"`
+	Details         []TransactionDetail `json:"details"`
+	// produk yang stoknya baru saja turun melewati reorder point karena transaksi ini
+	LowStock []LowStockProduct `json:"-"`
 }
 
 type TransactionDetail struct {
@@ -12,11 +16,21 @@ type TransactionDetail struct {
 	ProductID     int    `json:"product_id"`
 	ProductName   string `json:"product_name,omitempty"`
 	Quantity      int    `json:"quantity"`
+	UnitPrice     int    `json:"unit_price"`
 	Subtotal      int    `json:"subtotal"`
+	// HPP baris ini menurut FIFO dan weighted average, dicatat saat checkout
+	COGSFIFO    int `json:"cogs_fifo"`
+	COGSAverage int `json:"cogs_average"`
+	// batch yang terpakai (FEFO), kosong untuk produk tanpa batch
+	Batches []TransactionDetailBatch `json:"batches,omitempty"`
 }
 
+// CheckoutRequest.OutletID kosong berarti outlet default.
 type CheckoutRequest struct {
-	Items []CheckoutItem `json:"items"`
+	OutletID        int            `json:"outlet_id"`
+	CustomerGroupID *int           `json:"customer_group_id,omitempty"`
+	Items           []CheckoutItem `json:"items"`
+	CreatedBy       string         `json:"-"`
 }
 
 type CheckoutItem struct {
@@ -29,8 +43,79 @@ type SoldProduct struct {
 	Quantity    int    `json:"sold_quantity"`
 }
 
+type OutletSales struct {
+	OutletID         int    `json:"outlet_id"`
+	OutletName       string `json:"outlet_name"`
+	TotalRevenue     int    `json:"total_revenue"`
+	TotalTransaction int    `json:"total_transaction"`
+}
+
+// Report.OutletID diisi kalau laporan difilter per outlet. Tanpa filter laporannya gabungan semua outlet
+// dan Outlets berisi rinciannya.
 type Report struct {
-	TotalRevenue     int         `json:"total_revenue"`
-	TotalTransaction int         `json:"total_transaction"`
-	PopularProduct   SoldProduct `json:"popular_product"`
+	From             string            `json:"from"`
+	To               string            `json:"to"`
+	Timezone         string            `json:"timezone"`
+	OutletID         *int              `json:"outlet_id,omitempty"`
+	TotalRevenue     int               `json:"total_revenue"`
+	TotalTransaction int               `json:"total_transaction"`
+	AverageBasket    int               `json:"average_basket"`
+	PopularProduct   SoldProduct       `json:"popular_product"`
+	Outlets          []OutletSales     `json:"outlets,omitempty"`
+	Comparison       *ReportComparison `json:"comparison,omitempty"`
+}
+
+const (
+	ComparePrevious = "previous"
+	CompareLastWeek = "last_week"
+	CompareLastYear = "last_year"
+)
+
+// ReportDelta.Percent kosong kalau nilai pembandingnya 0.
+type ReportDelta struct {
+	Change  int      `json:"change"`
+	Percent *float64 `json:"percent"`
+}
+
+// ReportComparison berisi metrik yang sama untuk periode pembanding, delta dihitung sebagai laporan utama - pembanding.
+// PopularProductQuantity membandingkan jumlah terjual produk terlaris di masing-masing periode.
+type ReportComparison struct {
+	Period                 string      `json:"period"`
+	From                   string      `json:"from"`
+	To                     string      `json:"to"`
+	TotalRevenue           int         `json:"total_revenue"`
+	TotalTransaction       int         `json:"total_transaction"`
+	AverageBasket          int         `json:"average_basket"`
+	PopularProduct         SoldProduct `json:"popular_product"`
+	Revenue                ReportDelta `json:"revenue_delta"`
+	Transactions           ReportDelta `json:"transaction_delta"`
+	AverageBasketDelta     ReportDelta `json:"average_basket_delta"`
+	PopularProductQuantity ReportDelta `json:"popular_product_quantity_delta"`
+	PopularProductChanged  bool        `json:"popular_product_changed"`
+}
+
+// CategorySales dikelompokkan menurut kategori produk saat ini. RevenueGrowth kosong kalau periode sebelumnya tidak ada penjualan.
+type CategorySales struct {
+	CategoryID       int      `json:"category_id"`
+	CategoryName     string   `json:"category_name"`
+	Quantity         int      `json:"quantity"`
+	Revenue          int      `json:"revenue"`
+	Share            float64  `json:"share"`
+	PreviousQuantity int      `json:"previous_quantity"`
+	PreviousRevenue  int      `json:"previous_revenue"`
+	RevenueChange    int      `json:"revenue_change"`
+	RevenueGrowth    *float64 `json:"revenue_growth"`
+}
+
+// CategorySalesReport membandingkan From..To dengan periode sebelumnya yang panjangnya sama.
+type CategorySalesReport struct {
+	From                 string          `json:"from"`
+	To                   string          `json:"to"`
+	PreviousFrom         string          `json:"previous_from"`
+	PreviousTo           string          `json:"previous_to"`
+	Timezone             string          `json:"timezone"`
+	OutletID             *int            `json:"outlet_id,omitempty"`
+	TotalRevenue         int             `json:"total_revenue"`
+	PreviousTotalRevenue int             `json:"previous_total_revenue"`
+	Categories           []CategorySales `json:"categories"`
 }
@@ -0,0 +1,31 @@
+package models
+
+type ProductValuation struct {
+	ProductID    int    `json:"product_id"`
+	SKU          string `json:"sku,omitempty"`
+	ProductName  string `json:"product_name"`
+	CategoryID   int    `json:"category_id"`
+	CategoryName string `json:"category_name"`
+	Quantity     int    `json:"quantity"`
+	FIFOValue    int    `json:"fifo_value"`
+	AverageCost  int    `json:"average_cost"`
+	AverageValue int    `json:"average_value"`
+}
+
+type CategoryValuation struct {
+	CategoryID   int    `json:"category_id"`
+	CategoryName string `json:"category_name"`
+	Quantity     int    `json:"quantity"`
+	FIFOValue    int    `json:"fifo_value"`
+	AverageValue int    `json:"average_value"`
+}
+
+// InventoryValuation adalah nilai persediaan per akhir hari AsOf.
+type InventoryValuation struct {
+	AsOf              string              `json:"as_of"`
+	TotalQuantity     int                 `json:"total_quantity"`
+	TotalFIFOValue    int                 `json:"total_fifo_value"`
+	TotalAverageValue int                 `json:"total_average_value"`
+	Categories        []CategoryValuation `json:"categories"`
+	Products          []ProductValuation  `json:"products"`
+}
@@ -0,0 +1,147 @@
+package notifications
+
+import (
+	"bytes"
+	"crypto/tls"
+	"encoding/base64"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/smtp"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const mailTimeout = 30 * time.Second
+
+// Mailer mengirim laporan sebagai lampiran email lewat SMTP. STARTTLS dipakai kalau server mendukung,
+// AUTH hanya kalau username diisi.
+type Mailer struct {
+	host     string
+	port     int
+	username string
+	password string
+	from     string
+}
+
+func NewMailer(host string, port int, username string, password string, from string) *Mailer {
+	return &Mailer{host: host, port: port, username: username, password: password, from: from}
+}
+
+// SendReport mengirim ke semua alamat di msg.Target (dipisah koma).
+func (m *Mailer) SendReport(msg ReportMessage) error {
+	var recipients []string
+	for _, addr := range strings.Split(msg.Target, ",") {
+		if addr = strings.TrimSpace(addr); addr != "" {
+			recipients = append(recipients, addr)
+		}
+	}
+	if len(recipients) == 0 {
+		return fmt.Errorf("tidak ada alamat email tujuan")
+	}
+
+	body, err := m.buildMessage(recipients, msg)
+	if err != nil {
+		return err
+	}
+	return m.send(recipients, body)
+}
+
+func (m *Mailer) buildMessage(recipients []string, msg ReportMessage) ([]byte, error) {
+	var buf bytes.Buffer
+	mw := multipart.NewWriter(&buf)
+
+	fmt.Fprintf(&buf, "From: %s\r\n", m.from)
+	fmt.Fprintf(&buf, "To: %s\r\n", strings.Join(recipients, ", "))
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", msg.Subject))
+	fmt.Fprintf(&buf, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&buf, "Content-Type: multipart/mixed; boundary=%q\r\n\r\n", mw.Boundary())
+
+	text, err := mw.CreatePart(textproto.MIMEHeader{"Content-Type": {"text/plain; charset=utf-8"}})
+	if err != nil {
+		return nil, err
+	}
+	if _, err := text.Write([]byte(strings.ReplaceAll(msg.Body, "\n", "\r\n"))); err != nil {
+		return nil, err
+	}
+
+	attachment, err := mw.CreatePart(textproto.MIMEHeader{
+		"Content-Type":              {mime.FormatMediaType(strings.Split(msg.ContentType, ";")[0], map[string]string{"name": msg.FileName})},
+		"Content-Disposition":       {mime.FormatMediaType("attachment", map[string]string{"filename": msg.FileName})},
+		"Content-Transfer-Encoding": {"base64"},
+	})
+	if err != nil {
+		return nil, err
+	}
+	//base64 dipotong per 76 karakter sesuai RFC 2045
+	encoded := base64.StdEncoding.EncodeToString(msg.Data)
+	for len(encoded) > 76 {
+		if _, err := attachment.Write([]byte(encoded[:76] + "\r\n")); err != nil {
+			return nil, err
+		}
+		encoded = encoded[76:]
+	}
+	if _, err := attachment.Write([]byte(encoded + "\r\n")); err != nil {
+		return nil, err
+	}
+
+	if err := mw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// send tidak memakai smtp.SendMail supaya ada timeout, server SMTP yang macet tidak menahan scheduler.
+func (m *Mailer) send(recipients []string, body []byte) error {
+	addr := net.JoinHostPort(m.host, strconv.Itoa(m.port))
+	conn, err := net.DialTimeout("tcp", addr, mailTimeout)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	if err := conn.SetDeadline(time.Now().Add(mailTimeout)); err != nil {
+		return err
+	}
+
+	c, err := smtp.NewClient(conn, m.host)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	if ok, _ := c.Extension("STARTTLS"); ok {
+		if err := c.StartTLS(&tls.Config{ServerName: m.host}); err != nil {
+			return err
+		}
+	}
+	if m.username != "" {
+		if err := c.Auth(smtp.PlainAuth("", m.username, m.password, m.host)); err != nil {
+			return err
+		}
+	}
+
+	if err := c.Mail(m.from); err != nil {
+		return err
+	}
+	for _, rcpt := range recipients {
+		if err := c.Rcpt(rcpt); err != nil {
+			return err
+		}
+	}
+
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(body); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return c.Quit()
+}
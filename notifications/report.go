@@ -0,0 +1,116 @@
+package notifications
+
+import (
+	"fmt"
+	"kasir-api/models"
+	"os"
+	"path/filepath"
+)
+
+// ReportMessage adalah satu file laporan terjadwal yang dikirim ke penerima job. Target tergantung channel,
+// lihat models.ReportJob. Tenant kosong untuk tenant default.
+type ReportMessage struct {
+	Tenant      string
+	Target      string
+	Subject     string
+	Body        string
+	FileName    string
+	ContentType string
+	Data        []byte
+}
+
+// ReportNotifier mengirim hasil laporan terjadwal. Implementasinya dipilih menurut channel job.
+type ReportNotifier interface {
+	SendReport(msg ReportMessage) error
+}
+
+// ReportNotifiers berisi notifier per channel. Channel yang tidak dikonfigurasi tidak ada di map.
+type ReportNotifiers map[string]ReportNotifier
+
+// TargetValidator diimplementasikan notifier yang bisa mengecek target job sebelum job disimpan.
+type TargetValidator interface {
+	ValidateTarget(target string) error
+}
+
+// NewReportNotifiers: mailer nil atau deliveryDir kosong berarti channel email/directory tidak tersedia.
+// Channel webhook selalu tersedia karena URL-nya ditentukan per job, webhookAllowedHosts kosong berarti
+// semua host publik boleh dipakai (lihat CheckWebhookURL).
+func NewReportNotifiers(mailer *Mailer, deliveryDir string, webhookAllowedHosts []string) ReportNotifiers {
+	notifiers := ReportNotifiers{models.ReportChannelWebhook: reportWebhook{allowedHosts: webhookAllowedHosts}}
+	if mailer != nil {
+		notifiers[models.ReportChannelEmail] = mailer
+	}
+	if deliveryDir != "" {
+		notifiers[models.ReportChannelDirectory] = NewDirectory(deliveryDir)
+	}
+	return notifiers
+}
+
+// reportWebhook mengirim file laporan (base64) ke URL target job dengan format payload yang sama seperti webhook lain.
+// URL target diisi tenant, jadi dikirim lewat NewPublicWebhook supaya tidak bisa dipakai mengakses jaringan internal.
+type reportWebhook struct {
+	allowedHosts []string
+}
+
+type reportWebhookData struct {
+	Subject     string `json:"subject"`
+	FileName    string `json:"file_name"`
+	ContentType string `json:"content_type"`
+	Content     []byte `json:"content"`
+}
+
+func (w reportWebhook) ValidateTarget(target string) error {
+	return CheckWebhookURL(target, w.allowedHosts)
+}
+
+func (w reportWebhook) SendReport(msg ReportMessage) error {
+	//dicek ulang karena daftar host bisa berubah setelah job dibuat
+	if err := w.ValidateTarget(msg.Target); err != nil {
+		return err
+	}
+	data := reportWebhookData{Subject: msg.Subject, FileName: msg.FileName, ContentType: msg.ContentType, Content: msg.Data}
+	return NewPublicWebhook(msg.Target, w.allowedHosts).WithTenant(msg.Tenant).Send("report.generated", data)
+}
+
+// Directory menyimpan salinan laporan ke folder lokal, misalnya folder yang disinkronkan ke cloud storage.
+// File tenant selain default masuk ke tenants/<kode>, Target job menjadi subfolder.
+type Directory struct {
+	root string
+}
+
+func NewDirectory(root string) *Directory {
+	return &Directory{root: root}
+}
+
+func (d *Directory) SendReport(msg ReportMessage) error {
+	if msg.Target != "" && (msg.Target != filepath.Base(msg.Target) || msg.Target == ".." || msg.Target == ".") {
+		return fmt.Errorf("nama folder %q tidak valid", msg.Target)
+	}
+
+	dir := d.root
+	if msg.Tenant != "" {
+		dir = filepath.Join(dir, "tenants", msg.Tenant)
+	}
+	if msg.Target != "" {
+		dir = filepath.Join(dir, msg.Target)
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+
+	//ditulis ke file sementara dulu supaya proses yang memantau folder tidak membaca file setengah jadi
+	tmp, err := os.CreateTemp(dir, ".report-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(msg.Data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), filepath.Join(dir, msg.FileName))
+}
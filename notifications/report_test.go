@@ -0,0 +1,38 @@
+package notifications
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDirectorySendReport(t *testing.T) {
+	root := t.TempDir()
+	d := NewDirectory(root)
+
+	tests := []struct {
+		tenant string
+		target string
+		want   string
+	}{
+		{target: "", want: "laporan.csv"},
+		{tenant: "toko-a", target: "", want: filepath.Join("tenants", "toko-a", "laporan.csv")},
+		{tenant: "toko-a", target: "harian", want: filepath.Join("tenants", "toko-a", "harian", "laporan.csv")},
+	}
+	for _, tt := range tests {
+		msg := ReportMessage{Tenant: tt.tenant, Target: tt.target, FileName: "laporan.csv", Data: []byte("a,b\n")}
+		if err := d.SendReport(msg); err != nil {
+			t.Fatalf("SendReport(tenant %q, target %q): %v", tt.tenant, tt.target, err)
+		}
+		data, err := os.ReadFile(filepath.Join(root, tt.want))
+		if err != nil || string(data) != "a,b\n" {
+			t.Fatalf("file %s: %q, %v", tt.want, data, err)
+		}
+	}
+
+	for _, target := range []string{".", "..", "../luar", "a/b"} {
+		if err := d.SendReport(ReportMessage{Target: target, FileName: "laporan.csv"}); err == nil {
+			t.Errorf("target %q diterima", target)
+		}
+	}
+}
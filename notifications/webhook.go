@@ -0,0 +1,156 @@
+package notifications
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+)
+
+const webhookTimeout = 10 * time.Second
+
+var errInternalAddress = errors.New("alamat internal tidak boleh dipakai untuk webhook")
+
+// alamat yang bukan private/loopback/link-local menurut netip tapi tetap tidak boleh dituju dari luar:
+// "this network", shared address space (CGNAT), IETF protocol assignments, benchmarking, reserved dan NAT64
+var internalPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+	netip.MustParsePrefix("192.0.0.0/24"),
+	netip.MustParsePrefix("198.18.0.0/15"),
+	netip.MustParsePrefix("240.0.0.0/4"),
+	netip.MustParsePrefix("64:ff9b::/96"),
+	netip.MustParsePrefix("64:ff9b:1::/48"),
+}
+
+// publicTransport dipakai untuk URL yang diisi user. Alamat dicek di Control dialer, yaitu setelah DNS
+// di-resolve dan tepat sebelum connect, jadi hostname yang mengarah (atau berganti arah) ke alamat internal
+// tetap ditolak, termasuk saat redirect. Proxy sengaja dimatikan karena yang di-dial jadi alamat proxy.
+var publicTransport = &http.Transport{
+	DialContext:         (&net.Dialer{Timeout: webhookTimeout, Control: rejectInternalAddress}).DialContext,
+	TLSHandshakeTimeout: webhookTimeout,
+	MaxIdleConns:        10,
+	IdleConnTimeout:     90 * time.Second,
+}
+
+// Webhook mengirim event sebagai JSON lewat HTTP POST.
+type Webhook struct {
+	url    string
+	tenant string
+	client *http.Client
+}
+
+func NewWebhook(url string) *Webhook {
+	return &Webhook{url: url, client: &http.Client{Timeout: webhookTimeout}}
+}
+
+// NewPublicWebhook untuk URL yang tidak berasal dari konfigurasi server, misalnya target laporan terjadwal.
+// Koneksi ke alamat private, loopback, link-local dan alamat internal lain ditolak. allowedHosts kosong berarti
+// semua host publik boleh, selain itu URL dan setiap redirect-nya harus lolos CheckWebhookURL.
+func NewPublicWebhook(url string, allowedHosts []string) *Webhook {
+	client := &http.Client{
+		Timeout:   webhookTimeout,
+		Transport: publicTransport,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= 10 {
+				return errors.New("terlalu banyak redirect")
+			}
+			return CheckWebhookURL(req.URL.String(), allowedHosts)
+		},
+	}
+	return &Webhook{url: url, client: client}
+}
+
+// CheckWebhookURL memastikan URL http/https, host-nya bukan localhost atau alamat IP internal, dan kalau
+// allowedHosts diisi, host-nya ada di daftar. Entry yang diawali titik (".example.com") berlaku untuk semua
+// subdomain. Hostname baru benar-benar dicek alamatnya saat dial, lihat publicTransport.
+func CheckWebhookURL(rawURL string, allowedHosts []string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Hostname() == "" {
+		return errors.New("target harus URL http/https")
+	}
+
+	host := strings.ToLower(strings.TrimSuffix(u.Hostname(), "."))
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return errInternalAddress
+	}
+	if addr, err := netip.ParseAddr(host); err == nil && !IsPublicAddr(addr) {
+		return errInternalAddress
+	}
+
+	if len(allowedHosts) == 0 {
+		return nil
+	}
+	for _, allowed := range allowedHosts {
+		allowed = strings.ToLower(allowed)
+		if host == allowed || (strings.HasPrefix(allowed, ".") && strings.HasSuffix(host, allowed)) {
+			return nil
+		}
+	}
+	return fmt.Errorf("host %s tidak ada di daftar webhook yang diizinkan", host)
+}
+
+// WithTenant mengembalikan salinan webhook yang menyertakan kode tenant di setiap payload,
+// supaya penerima bisa membedakan event dari tenant mana.
+func (w *Webhook) WithTenant(code string) *Webhook {
+	c := *w
+	c.tenant = code
+	return &c
+}
+
+type webhookPayload struct {
+	Event      string      `json:"event"`
+	Tenant     string      `json:"tenant,omitempty"`
+	OccurredAt time.Time   `json:"occurred_at"`
+	Data       interface{} `json:"data"`
+}
+
+func (w *Webhook) Send(event string, data interface{}) error {
+	body, err := json.Marshal(webhookPayload{Event: event, Tenant: w.tenant, OccurredAt: time.Now(), Data: data})
+	if err != nil {
+		return err
+	}
+
+	resp, err := w.client.Post(w.url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook %s membalas status %d", event, resp.StatusCode)
+	}
+	return nil
+}
+
+func rejectInternalAddress(network string, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return err
+	}
+	if !IsPublicAddr(addrPort.Addr()) {
+		return fmt.Errorf("%w: %s", errInternalAddress, addrPort.Addr())
+	}
+	return nil
+}
+
+// IsPublicAddr false untuk alamat private, loopback, link-local, multicast, unspecified dan alamat internal lain.
+func IsPublicAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	if !addr.IsGlobalUnicast() || addr.IsPrivate() {
+		return false
+	}
+	for _, prefix := range internalPrefixes {
+		if prefix.Contains(addr) {
+			return false
+		}
+	}
+	return true
+}
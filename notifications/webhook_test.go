@@ -0,0 +1,103 @@
+package notifications
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+)
+
+func TestIsPublicAddr(t *testing.T) {
+	tests := map[string]bool{
+		"8.8.8.8":                true,
+		"2606:4700::1111":        true,
+		"127.0.0.1":              false,
+		"10.1.2.3":               false,
+		"172.16.0.1":             false,
+		"192.168.1.1":            false,
+		"169.254.169.254":        false,
+		"100.64.0.1":             false,
+		"0.0.0.0":                false,
+		"0.1.2.3":                false,
+		"224.0.0.1":              false,
+		"255.255.255.255":        false,
+		"::1":                    false,
+		"::":                     false,
+		"fe80::1":                false,
+		"fd00::1":                false,
+		"::ffff:127.0.0.1":       false,
+		"::ffff:169.254.169.254": false,
+		"64:ff9b::a00:1":         false,
+	}
+	for raw, want := range tests {
+		if got := IsPublicAddr(netip.MustParseAddr(raw)); got != want {
+			t.Errorf("IsPublicAddr(%s) = %v, want %v", raw, got, want)
+		}
+	}
+}
+
+func TestCheckWebhookURL(t *testing.T) {
+	tests := []struct {
+		url     string
+		allowed []string
+		ok      bool
+	}{
+		{url: "https://hooks.example.com/laporan", ok: true},
+		{url: "http://203.0.113.10:8080/x", ok: true},
+		{url: "ftp://hooks.example.com/x"},
+		{url: "https:///tanpa-host"},
+		{url: "http://localhost:8080/"},
+		{url: "http://api.localhost/"},
+		{url: "http://127.0.0.1/"},
+		{url: "http://[::1]:9000/"},
+		{url: "http://169.254.169.254/latest/meta-data/"},
+		{url: "http://10.0.0.5/"},
+		{url: "https://hooks.example.com/x", allowed: []string{"hooks.example.com"}, ok: true},
+		{url: "https://HOOKS.example.com./x", allowed: []string{"hooks.example.com"}, ok: true},
+		{url: "https://a.b.example.com/x", allowed: []string{".example.com"}, ok: true},
+		{url: "https://example.com/x", allowed: []string{".example.com"}},
+		{url: "https://evil-example.com/x", allowed: []string{".example.com"}},
+		{url: "https://other.test/x", allowed: []string{"hooks.example.com"}},
+	}
+	for _, tt := range tests {
+		err := CheckWebhookURL(tt.url, tt.allowed)
+		if (err == nil) != tt.ok {
+			t.Errorf("CheckWebhookURL(%q, %q) = %v, want ok %v", tt.url, tt.allowed, err, tt.ok)
+		}
+	}
+}
+
+func TestPublicWebhookRejectsInternalAddressAtDial(t *testing.T) {
+	called := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+	defer server.Close()
+
+	//NewWebhook untuk URL dari konfigurasi server tetap boleh ke alamat internal
+	if err := NewWebhook(server.URL).Send("test", nil); err != nil || !called {
+		t.Fatalf("NewWebhook: err %v, called %v", err, called)
+	}
+
+	called = false
+	err := NewPublicWebhook(server.URL, nil).Send("test", nil)
+	if !errors.Is(err, errInternalAddress) || called {
+		t.Fatalf("NewPublicWebhook: err %v, called %v, want %v", err, called, errInternalAddress)
+	}
+}
+
+func TestPublicWebhookChecksRedirects(t *testing.T) {
+	client := NewPublicWebhook("https://hooks.example.com/", []string{"hooks.example.com"}).client
+
+	allowed, _ := http.NewRequest(http.MethodPost, "https://hooks.example.com/baru", nil)
+	if err := client.CheckRedirect(allowed, []*http.Request{{}}); err != nil {
+		t.Errorf("redirect ke host yang diizinkan ditolak: %v", err)
+	}
+	for _, target := range []string{"https://other.test/", "http://169.254.169.254/"} {
+		req, _ := http.NewRequest(http.MethodPost, target, nil)
+		if err := client.CheckRedirect(req, []*http.Request{{}}); err == nil {
+			t.Errorf("redirect ke %s diterima", target)
+		}
+	}
+}
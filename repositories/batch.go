@@ -0,0 +1,218 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+	"kasir-api/models"
+)
+
+const batchColumns = `b.id, b.product_id, p.name, b.outlet_id, b.batch_number, COALESCE(to_char(b.expiry_date, 'YYYY-MM-DD'), ''),
+					b.quantity, b.received_quantity, b.created_at,
+					COALESCE(b.expiry_date < CURRENT_DATE, FALSE), b.expiry_date - CURRENT_DATE`
+
+func scanBatch(row rowScanner, b *models.ProductBatch, extra ...interface{}) error {
+	dest := []interface{}{&b.ID, &b.ProductID, &b.ProductName, &b.OutletID, &b.BatchNumber, &b.ExpiryDate,
+		&b.Quantity, &b.ReceivedQuantity, &b.CreatedAt, &b.Expired, &b.DaysUntilExpiry}
+	return row.Scan(append(dest, extra...)...)
+}
+
+// GetProductBatches mengembalikan batch produk urut FEFO. Batch yang sudah habis hanya ikut kalau includeEmpty.
+func (repo *StockRepository) GetProductBatches(productID int, outletID *int, includeEmpty bool) ([]models.ProductBatch, error) {
+	var exists bool
+	err := repo.db.QueryRow("SELECT EXISTS (SELECT 1 FROM products WHERE id = $1)", productID).Scan(&exists)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, fmt.Errorf("%w: product id %d", ErrNotFound, productID)
+	}
+
+	query := "SELECT " + batchColumns + `
+				FROM product_batches AS b
+				JOIN products AS p ON p.id = b.product_id
+				WHERE b.product_id = $1`
+	args := []interface{}{productID}
+	if outletID != nil {
+		args = append(args, *outletID)
+		query += " AND b.outlet_id = $2"
+	}
+	if !includeEmpty {
+		query += " AND b.quantity > 0"
+	}
+	query += " ORDER BY b.expiry_date NULLS LAST, b.id"
+
+	rows, err := repo.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	batches := make([]models.ProductBatch, 0)
+	for rows.Next() {
+		var b models.ProductBatch
+		if err := scanBatch(rows, &b); err != nil {
+			return nil, err
+		}
+		batches = append(batches, b)
+	}
+
+	return batches, rows.Err()
+}
+
+// GetExpiringBatches mengembalikan batch yang masih ada stoknya dan kadaluarsa dalam `days` hari ke depan.
+func (repo *StockRepository) GetExpiringBatches(days int, outletID *int, includeExpired bool) (*models.ExpiryReport, error) {
+	query := "SELECT " + batchColumns + `, b.quantity * p.cost_price
+				FROM product_batches AS b
+				JOIN products AS p ON p.id = b.product_id
+				WHERE b.quantity > 0 AND b.expiry_date <= CURRENT_DATE + $1::int`
+	args := []interface{}{days}
+	if outletID != nil {
+		args = append(args, *outletID)
+		query += " AND b.outlet_id = $2"
+	}
+	if !includeExpired {
+		query += " AND b.expiry_date >= CURRENT_DATE"
+	}
+	query += " ORDER BY b.expiry_date, p.name, b.id"
+
+	rows, err := repo.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	report := &models.ExpiryReport{Days: days, Items: make([]models.ProductBatch, 0)}
+	for rows.Next() {
+		var b models.ProductBatch
+		if err := scanBatch(rows, &b, &b.Value); err != nil {
+			return nil, err
+		}
+		report.Items = append(report.Items, b)
+		report.TotalQuantity += b.Quantity
+		report.TotalValue += b.Value
+	}
+
+	return report, rows.Err()
+}
+
+// receiveIntoBatch menambah quantity batch di outlet, membuat batch baru kalau belum ada.
+// Batch yang sudah ada harus punya tanggal kadaluarsa yang sama.
+func receiveIntoBatch(tx *sql.Tx, outletID int, productID int, batchNumber string, expiryDate string, quantity int) (int, error) {
+	var batchID int
+	var currentExpiry string
+	err := tx.QueryRow(`INSERT INTO product_batches (outlet_id, product_id, batch_number, expiry_date, quantity, received_quantity)
+				VALUES ($1, $2, $3, NULLIF($4, '')::date, $5, $5)
+				ON CONFLICT (outlet_id, product_id, batch_number) DO UPDATE
+					SET quantity = product_batches.quantity + EXCLUDED.quantity,
+						received_quantity = product_batches.received_quantity + EXCLUDED.received_quantity
+				RETURNING id, COALESCE(to_char(expiry_date, 'YYYY-MM-DD'), '')`,
+		outletID, productID, batchNumber, expiryDate, quantity).Scan(&batchID, &currentExpiry)
+	if err != nil {
+		return 0, err
+	}
+	if expiryDate != "" && currentExpiry != expiryDate {
+		return 0, fmt.Errorf("%w: batch %s sudah tercatat dengan kadaluarsa %s", ErrValidation, batchNumber, currentExpiry)
+	}
+
+	return batchID, nil
+}
+
+// adjustBatch mengubah quantity satu batch, dipakai oleh stock adjustment yang menyebut batch_id.
+func adjustBatch(tx *sql.Tx, batchID int, productID int, outletID int, delta int) error {
+	var quantity int
+	err := tx.QueryRow("SELECT quantity FROM product_batches WHERE id = $1 AND product_id = $2 AND outlet_id = $3 FOR UPDATE",
+		batchID, productID, outletID).Scan(&quantity)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("%w: batch %d untuk produk %d di outlet %d", ErrNotFound, batchID, productID, outletID)
+	}
+	if err != nil {
+		return err
+	}
+	if quantity+delta < 0 {
+		return fmt.Errorf("%w: batch %d hanya tersisa %d", ErrValidation, batchID, quantity)
+	}
+
+	_, err = tx.Exec("UPDATE product_batches SET quantity = quantity + $1 WHERE id = $2", delta, batchID)
+	return err
+}
+
+// consumeBatchesFEFO mengambil quantity dari batch outlet yang belum kadaluarsa, mulai dari yang paling cepat
+// kadaluarsa. Sisa yang tidak tertutup batch diambil dari stok tanpa batch. stockAfter adalah
+// stok outlet setelah dikurangi. Setelah penjualan, stok tidak boleh lebih kecil dari total batch yang tersisa,
+// kalau iya berarti ada unit yang terjual tanpa batch padahal stok tanpa batch sudah habis (atau yang terjual unit kadaluarsa).
+func consumeBatchesFEFO(tx *sql.Tx, outletID int, productID int, productName string, quantity int, stockAfter int) ([]models.TransactionDetailBatch, error) {
+	consumed, err := takeFromBatches(tx, outletID, productID, quantity, false)
+	if err != nil {
+		return nil, err
+	}
+
+	var batched, expired int
+	err = tx.QueryRow(`SELECT COALESCE(SUM(quantity), 0), COALESCE(SUM(quantity) FILTER (WHERE expiry_date < CURRENT_DATE), 0)
+				FROM product_batches WHERE outlet_id = $1 AND product_id = $2`,
+		outletID, productID).Scan(&batched, &expired)
+	if err != nil {
+		return nil, err
+	}
+	if stockAfter < batched {
+		if expired > 0 {
+			return nil, fmt.Errorf("%w: stok %s yang belum kadaluarsa tidak cukup", ErrValidation, productName)
+		}
+		return nil, fmt.Errorf("%w: stok batch %s tidak cukup", ErrValidation, productName)
+	}
+
+	return consumed, nil
+}
+
+// deductBatches dipakai untuk stok keluar selain penjualan dan transfer (retur supplier, adjustment, opname, import),
+// supaya jumlah di batch tidak melebihi stok. Kalau batchID diisi hanya batch itu yang dikurangi. Kalau tidak,
+// FEFO termasuk batch kadaluarsa, karena barang kadaluarsa justru yang biasanya diretur atau dibuang.
+// Sisa yang tidak tertutup batch diambil dari stok tanpa batch.
+func deductBatches(tx *sql.Tx, outletID int, productID int, batchID *int, quantity int) error {
+	if batchID != nil {
+		return adjustBatch(tx, *batchID, productID, outletID, -quantity)
+	}
+	_, err := takeFromBatches(tx, outletID, productID, quantity, true)
+	return err
+}
+
+// takeFromBatches mengurangi batch outlet urut FEFO sampai quantity tertutup atau batch-nya habis.
+func takeFromBatches(tx *sql.Tx, outletID int, productID int, quantity int, includeExpired bool) ([]models.TransactionDetailBatch, error) {
+	query := `SELECT id, batch_number, COALESCE(to_char(expiry_date, 'YYYY-MM-DD'), ''), quantity
+				FROM product_batches
+				WHERE outlet_id = $1 AND product_id = $2 AND quantity > 0`
+	if !includeExpired {
+		query += " AND (expiry_date IS NULL OR expiry_date >= CURRENT_DATE)"
+	}
+	rows, err := tx.Query(query+" ORDER BY expiry_date NULLS LAST, id FOR UPDATE", outletID, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	consumed := make([]models.TransactionDetailBatch, 0)
+	remaining := quantity
+	for remaining > 0 && rows.Next() {
+		var b models.TransactionDetailBatch
+		var available int
+		if err := rows.Scan(&b.BatchID, &b.BatchNumber, &b.ExpiryDate, &available); err != nil {
+			rows.Close()
+			return nil, err
+		}
+
+		b.Quantity = min(available, remaining)
+		remaining -= b.Quantity
+		consumed = append(consumed, b)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, b := range consumed {
+		_, err := tx.Exec("UPDATE product_batches SET quantity = quantity - $1 WHERE id = $2", b.Quantity, b.BatchID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return consumed, nil
+}
@@ -4,8 +4,11 @@ import (
 	"database/sql"
 	"errors"
 	"kasir-api/models"
+	"time"
 )
 
+var ErrCategoryHasActiveProducts = errors.New("kategori masih punya produk aktif, gunakan force=true untuk ikut mengarsipkan produknya")
+
 type CategoryRepository struct {
 	db *sql.DB
 }
@@ -14,8 +17,11 @@ func NewCategoryRepository(db *sql.DB) *CategoryRepository {
 	return &CategoryRepository{db: db}
 }
 
-func (repo *CategoryRepository) GetAllCategories() ([]*models.Category, error) {
-	query := "SELECT id, name, description FROM categories"
+func (repo *CategoryRepository) GetAllCategories(includeArchived bool) ([]*models.Category, error) {
+	query := "SELECT id, name, description, archived_at FROM categories"
+	if !includeArchived {
+		query += " WHERE archived_at IS NULL"
+	}
 	rows, err := repo.db.Query(query)
 	if err != nil {
 		return nil, err
@@ -25,7 +31,7 @@ func (repo *CategoryRepository) GetAllCategories() ([]*models.Category, error) {
 	categories := make([]*models.Category, 0)
 	for rows.Next() {
 		var c models.Category
-		err := rows.Scan(&c.ID, &c.Name, &c.Description)
+		err := rows.Scan(&c.ID, &c.Name, &c.Description, &c.ArchivedAt)
 		if err != nil {
 			return nil, err
 		}
@@ -35,10 +41,10 @@ func (repo *CategoryRepository) GetAllCategories() ([]*models.Category, error) {
 }
 
 func (repo *CategoryRepository) GetCategoryByID(id int) (*models.Category, error) {
-	query := "SELECT id, name, description FROM categories WHERE id = $1"
+	query := "SELECT id, name, description, archived_at FROM categories WHERE id = $1"
 
 	var p models.Category
-	err := repo.db.QueryRow(query, id).Scan(&p.ID, &p.Name, &p.Description)
+	err := repo.db.QueryRow(query, id).Scan(&p.ID, &p.Name, &p.Description, &p.ArchivedAt)
 	if err == sql.ErrNoRows {
 		return nil, errors.New("kategori tidak ditemukan")
 	}
@@ -61,8 +67,94 @@ func (repo *CategoryRepository) UpdateCategory(category *models.Category) error
 	return err
 }
 
-func (repo *CategoryRepository) DeleteCategory(id int) error {
-	query := "DELETE FROM categories WHERE id = $1"
-	_, err := repo.db.Exec(query, id)
-	return err
+// ArchiveCategory mengarsipkan kategori. Kalau masih ada produk aktif, ditolak kecuali force,
+// dan dengan force produknya ikut diarsipkan.
+func (repo *CategoryRepository) ArchiveCategory(id int, force bool) error {
+	tx, err := repo.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var archivedAt *time.Time
+	err = tx.QueryRow("SELECT archived_at FROM categories WHERE id = $1 FOR UPDATE", id).Scan(&archivedAt)
+	if err == sql.ErrNoRows {
+		return errors.New("kategori tidak ditemukan")
+	}
+	if err != nil {
+		return err
+	}
+	if archivedAt != nil {
+		return errors.New("kategori sudah diarsipkan")
+	}
+
+	var activeProducts int
+	err = tx.QueryRow("SELECT COUNT(*) FROM products WHERE category_id = $1 AND archived_at IS NULL", id).Scan(&activeProducts)
+	if err != nil {
+		return err
+	}
+	if activeProducts > 0 && !force {
+		return ErrCategoryHasActiveProducts
+	}
+
+	//NOW() konstan dalam satu transaksi, jadi produk dan kategori dapat timestamp yang sama
+	_, err = tx.Exec("UPDATE products SET archived_at = NOW() WHERE category_id = $1 AND archived_at IS NULL", id)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec("UPDATE categories SET archived_at = NOW() WHERE id = $1", id)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// RestoreCategory mengembalikan kategori beserta produk yang ikut terarsip bersamanya.
+func (repo *CategoryRepository) RestoreCategory(id int) error {
+	tx, err := repo.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var archivedAt *time.Time
+	err = tx.QueryRow("SELECT archived_at FROM categories WHERE id = $1 FOR UPDATE", id).Scan(&archivedAt)
+	if err == sql.ErrNoRows {
+		return errors.New("kategori tidak ditemukan")
+	}
+	if err != nil {
+		return err
+	}
+	if archivedAt == nil {
+		return errors.New("kategori tidak sedang diarsipkan")
+	}
+
+	_, err = tx.Exec("UPDATE products SET archived_at = NULL WHERE category_id = $1 AND archived_at = $2", id, *archivedAt)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec("UPDATE categories SET archived_at = NULL WHERE id = $1", id)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func ensureActiveCategory(q dbExecutor, categoryID int) error {
+	var archivedAt *time.Time
+	err := q.QueryRow("SELECT archived_at FROM categories WHERE id = $1", categoryID).Scan(&archivedAt)
+	if err == sql.ErrNoRows {
+		return errors.New("kategori tidak ditemukan")
+	}
+	if err != nil {
+		return err
+	}
+	if archivedAt != nil {
+		return errors.New("kategori sudah diarsipkan")
+	}
+	return nil
 }
@@ -0,0 +1,93 @@
+package repositories
+
+// costLayer adalah sebagian barang masuk dengan harga pokok per unit yang sama.
+type costLayer struct {
+	UnitCost int
+	Quantity int
+}
+
+// addCostLayer membuat lapisan FIFO untuk barang masuk. Kalau stok sebelumnya minus, sebagian barang
+// langsung dipakai untuk menutup unit yang sudah terjual lebih dulu.
+func addCostLayer(q dbExecutor, productID int, movementID int64, unitCost int, quantity int, stockAfter int) error {
+	settled := 0
+	if stockBefore := stockAfter - quantity; stockBefore < 0 {
+		settled = min(quantity, -stockBefore)
+	}
+
+	var layerID int64
+	err := q.QueryRow(`INSERT INTO cost_layers (product_id, movement_id, unit_cost, quantity, remaining_quantity)
+				VALUES ($1, $2, $3, $4, $5) RETURNING id`,
+		productID, movementID, unitCost, quantity, quantity-settled).Scan(&layerID)
+	if err != nil {
+		return err
+	}
+
+	if settled > 0 {
+		_, err = q.Exec(`INSERT INTO cost_layer_consumptions (cost_layer_id, product_id, movement_id, quantity, unit_cost)
+					VALUES ($1, $2, $3, $4, $5)`,
+			layerID, productID, movementID, settled, unitCost)
+	}
+	return err
+}
+
+// consumeCostLayers mengambil quantity dari lapisan paling lama (FIFO). Unit yang tidak tertutup lapisan
+// (stok minus) dinilai dengan harga rata-rata supaya HPP FIFO tidak pernah nol.
+func consumeCostLayers(q dbExecutor, productID int, movementID int64, quantity int, averageCost int) (stockCost, error) {
+	rows, err := q.Query(`SELECT id, unit_cost, remaining_quantity
+				FROM cost_layers
+				WHERE product_id = $1 AND remaining_quantity > 0
+				ORDER BY created_at, id
+				FOR UPDATE`, productID)
+	if err != nil {
+		return stockCost{}, err
+	}
+
+	type layerUse struct {
+		layerID  *int64
+		unitCost int
+		quantity int
+	}
+	uses := make([]layerUse, 0)
+	remaining := quantity
+	for remaining > 0 && rows.Next() {
+		var layerID int64
+		var u layerUse
+		var available int
+		if err := rows.Scan(&layerID, &u.unitCost, &available); err != nil {
+			rows.Close()
+			return stockCost{}, err
+		}
+
+		u.layerID = &layerID
+		u.quantity = min(available, remaining)
+		remaining -= u.quantity
+		uses = append(uses, u)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return stockCost{}, err
+	}
+	if remaining > 0 {
+		uses = append(uses, layerUse{unitCost: averageCost, quantity: remaining})
+	}
+
+	cost := stockCost{Average: quantity * averageCost}
+	for _, u := range uses {
+		if u.layerID != nil {
+			_, err := q.Exec("UPDATE cost_layers SET remaining_quantity = remaining_quantity - $1 WHERE id = $2", u.quantity, *u.layerID)
+			if err != nil {
+				return stockCost{}, err
+			}
+		}
+
+		_, err := q.Exec(`INSERT INTO cost_layer_consumptions (cost_layer_id, product_id, movement_id, quantity, unit_cost)
+					VALUES ($1, $2, $3, $4, $5)`,
+			u.layerID, productID, movementID, u.quantity, u.unitCost)
+		if err != nil {
+			return stockCost{}, err
+		}
+		cost.FIFO += u.quantity * u.unitCost
+	}
+
+	return cost, nil
+}
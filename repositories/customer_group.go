@@ -0,0 +1,94 @@
+package repositories
+
+import (
+	"database/sql"
+	"errors"
+	"kasir-api/models"
+)
+
+type CustomerGroupRepository struct {
+	db *sql.DB
+}
+
+func NewCustomerGroupRepository(db *sql.DB) *CustomerGroupRepository {
+	return &CustomerGroupRepository{db: db}
+}
+
+func (repo *CustomerGroupRepository) GetAllCustomerGroups() ([]*models.CustomerGroup, error) {
+	query := "SELECT id, name, description FROM customer_groups ORDER BY id"
+	rows, err := repo.db.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	groups := make([]*models.CustomerGroup, 0)
+	for rows.Next() {
+		var g models.CustomerGroup
+		err := rows.Scan(&g.ID, &g.Name, &g.Description)
+		if err != nil {
+			return nil, err
+		}
+		groups = append(groups, &g)
+	}
+	return groups, nil
+}
+
+func (repo *CustomerGroupRepository) GetCustomerGroupByID(id int) (*models.CustomerGroup, error) {
+	query := "SELECT id, name, description FROM customer_groups WHERE id = $1"
+
+	var g models.CustomerGroup
+	err := repo.db.QueryRow(query, id).Scan(&g.ID, &g.Name, &g.Description)
+	if err == sql.ErrNoRows {
+		return nil, errors.New("customer group tidak ditemukan")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &g, nil
+}
+
+func (repo *CustomerGroupRepository) CreateCustomerGroup(group *models.CustomerGroup) error {
+	query := "INSERT INTO customer_groups (name, description) VALUES ($1, $2) RETURNING id"
+	err := repo.db.QueryRow(query, group.Name, group.Description).Scan(&group.ID)
+	return err
+}
+
+func (repo *CustomerGroupRepository) UpdateCustomerGroup(group *models.CustomerGroup) error {
+	query := "UPDATE customer_groups SET name = $1, description = $2 WHERE id = $3"
+	result, err := repo.db.Exec(query, group.Name, group.Description, group.ID)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return errors.New("customer group tidak ditemukan")
+	}
+
+	return nil
+}
+
+func (repo *CustomerGroupRepository) DeleteCustomerGroup(id int) error {
+	query := "DELETE FROM customer_groups WHERE id = $1"
+	result, err := repo.db.Exec(query, id)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return errors.New("customer group tidak ditemukan")
+	}
+
+	return nil
+}
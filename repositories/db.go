@@ -0,0 +1,10 @@
+package repositories
+
+import "database/sql"
+
+// dbExecutor dipenuhi oleh *sql.DB maupun *sql.Tx, supaya helper bisa dipakai di dalam atau di luar transaksi.
+type dbExecutor interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+	QueryRow(query string, args ...interface{}) *sql.Row
+}
@@ -0,0 +1,13 @@
+package repositories
+
+import "errors"
+
+// Error umum yang dipakai handler untuk menentukan status HTTP. Bungkus dengan fmt.Errorf("%w: ...")
+// supaya pesan aslinya tetap sampai ke client.
+var (
+	ErrNotFound      = errors.New("data tidak ditemukan")
+	ErrValidation    = errors.New("data tidak valid")
+	ErrInvalidStatus = errors.New("status tidak valid untuk aksi ini")
+	ErrUnauthorized  = errors.New("token tidak valid")
+	ErrForbidden     = errors.New("akses ditolak")
+)
@@ -0,0 +1,55 @@
+package repositories
+
+import (
+	"fmt"
+	"kasir-api/models"
+)
+
+// CreateGoodsReceipt mencatat barang datang tanpa PO. Stok dan harga pokok diubah sama seperti penerimaan PO.
+func (repo *PurchaseRepository) CreateGoodsReceipt(receipt *models.GoodsReceipt) error {
+	tx, err := repo.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	err = ensureActiveSupplier(tx, receipt.SupplierID)
+	if err != nil {
+		return err
+	}
+	receipt.OutletID, err = resolveOutlet(tx, receipt.OutletID)
+	if err != nil {
+		return err
+	}
+
+	err = postGoodsReceipt(tx, receipt)
+	if err != nil {
+		return err
+	}
+
+	err = tx.QueryRow("SELECT name FROM suppliers WHERE id = $1", receipt.SupplierID).Scan(&receipt.SupplierName)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (repo *PurchaseRepository) GetGoodsReceipts(supplierID *int) ([]models.GoodsReceipt, error) {
+	if supplierID != nil {
+		return getGoodsReceipts(repo.db, "gr.supplier_id = $1", *supplierID)
+	}
+	return getGoodsReceipts(repo.db, "TRUE")
+}
+
+func (repo *PurchaseRepository) GetGoodsReceiptByID(id int) (*models.GoodsReceipt, error) {
+	receipts, err := getGoodsReceipts(repo.db, "gr.id = $1", id)
+	if err != nil {
+		return nil, err
+	}
+	if len(receipts) == 0 {
+		return nil, fmt.Errorf("%w: goods receipt %d", ErrNotFound, id)
+	}
+
+	return &receipts[0], nil
+}
@@ -0,0 +1,145 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+	"kasir-api/models"
+)
+
+type ImageRepository struct {
+	db *sql.DB
+}
+
+func NewImageRepository(db *sql.DB) *ImageRepository {
+	return &ImageRepository{db: db}
+}
+
+func (repo *ImageRepository) ProductExists(productID int) (bool, error) {
+	var exists bool
+	err := repo.db.QueryRow("SELECT EXISTS (SELECT 1 FROM products WHERE id = $1)", productID).Scan(&exists)
+	return exists, err
+}
+
+func (repo *ImageRepository) GetProductImages(productID int) ([]*models.ProductImage, error) {
+	query := `SELECT id, product_id, storage_key, thumbnail_key, url, thumbnail_url, content_type,
+					size_bytes, width, height, is_primary, created_at
+				FROM product_images
+				WHERE product_id = $1
+				ORDER BY is_primary DESC, id`
+	rows, err := repo.db.Query(query, productID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	images := make([]*models.ProductImage, 0)
+	for rows.Next() {
+		var img models.ProductImage
+		err := rows.Scan(&img.ID, &img.ProductID, &img.StorageKey, &img.ThumbnailKey, &img.URL, &img.ThumbnailURL,
+			&img.ContentType, &img.SizeBytes, &img.Width, &img.Height, &img.IsPrimary, &img.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		images = append(images, &img)
+	}
+
+	return images, nil
+}
+
+func (repo *ImageRepository) GetProductImageByID(productID int, imageID int) (*models.ProductImage, error) {
+	query := `SELECT id, product_id, storage_key, thumbnail_key, url, thumbnail_url, content_type,
+					size_bytes, width, height, is_primary, created_at
+				FROM product_images
+				WHERE id = $1 AND product_id = $2`
+
+	var img models.ProductImage
+	err := repo.db.QueryRow(query, imageID, productID).Scan(&img.ID, &img.ProductID, &img.StorageKey, &img.ThumbnailKey,
+		&img.URL, &img.ThumbnailURL, &img.ContentType, &img.SizeBytes, &img.Width, &img.Height, &img.IsPrimary, &img.CreatedAt)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("%w: gambar %d tidak ditemukan", ErrNotFound, imageID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &img, nil
+}
+
+// CreateProductImage menyimpan metadata gambar. Gambar pertama sebuah produk otomatis jadi primary.
+func (repo *ImageRepository) CreateProductImage(img *models.ProductImage) error {
+	tx, err := repo.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := lockProductImages(tx, img.ProductID); err != nil {
+		return err
+	}
+
+	var hasPrimary bool
+	err = tx.QueryRow("SELECT EXISTS (SELECT 1 FROM product_images WHERE product_id = $1 AND is_primary)", img.ProductID).Scan(&hasPrimary)
+	if err != nil {
+		return err
+	}
+
+	if !hasPrimary {
+		img.IsPrimary = true
+	} else if img.IsPrimary {
+		_, err = tx.Exec("UPDATE product_images SET is_primary = FALSE WHERE product_id = $1 AND is_primary", img.ProductID)
+		if err != nil {
+			return err
+		}
+	}
+
+	query := `INSERT INTO product_images (product_id, storage_key, thumbnail_key, url, thumbnail_url, content_type,
+					size_bytes, width, height, is_primary)
+				VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10) RETURNING id, created_at`
+	err = tx.QueryRow(query, img.ProductID, img.StorageKey, img.ThumbnailKey, img.URL, img.ThumbnailURL, img.ContentType,
+		img.SizeBytes, img.Width, img.Height, img.IsPrimary).Scan(&img.ID, &img.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// DeleteProductImage menghapus metadata gambar. Kalau yang dihapus primary, gambar tertua berikutnya jadi primary.
+func (repo *ImageRepository) DeleteProductImage(img *models.ProductImage) error {
+	tx, err := repo.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := lockProductImages(tx, img.ProductID); err != nil {
+		return err
+	}
+
+	_, err = tx.Exec("DELETE FROM product_images WHERE id = $1", img.ID)
+	if err != nil {
+		return err
+	}
+
+	if img.IsPrimary {
+		_, err = tx.Exec(`UPDATE product_images SET is_primary = TRUE
+					WHERE id = (SELECT id FROM product_images WHERE product_id = $1 ORDER BY id LIMIT 1)`, img.ProductID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// lockProductImages mengunci baris produk supaya upload dan hapus gambar produk yang sama berjalan bergantian.
+// Tanpa ini dua upload pertama yang bersamaan sama-sama melihat belum ada primary dan salah satunya
+// gagal di unique index primary.
+func lockProductImages(tx *sql.Tx, productID int) error {
+	var id int
+	err := tx.QueryRow("SELECT id FROM products WHERE id = $1 FOR UPDATE", productID).Scan(&id)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("%w: produk %d tidak ditemukan", ErrNotFound, productID)
+	}
+	return err
+}
@@ -0,0 +1,247 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+	"kasir-api/models"
+)
+
+type OutletRepository struct {
+	db *sql.DB
+}
+
+func NewOutletRepository(db *sql.DB) *OutletRepository {
+	return &OutletRepository{db: db}
+}
+
+const outletColumns = "id, code, name, outlet_type, address, COALESCE(timezone, ''), is_default, created_at, archived_at"
+
+func scanOutlet(row rowScanner, o *models.Outlet) error {
+	return row.Scan(&o.ID, &o.Code, &o.Name, &o.Type, &o.Address, &o.Timezone, &o.IsDefault, &o.CreatedAt, &o.ArchivedAt)
+}
+
+func (repo *OutletRepository) GetAllOutlets(includeArchived bool) ([]*models.Outlet, error) {
+	query := "SELECT " + outletColumns + " FROM outlets"
+	if !includeArchived {
+		query += " WHERE archived_at IS NULL"
+	}
+	query += " ORDER BY is_default DESC, name, id"
+
+	rows, err := repo.db.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	outlets := make([]*models.Outlet, 0)
+	for rows.Next() {
+		var o models.Outlet
+		if err := scanOutlet(rows, &o); err != nil {
+			return nil, err
+		}
+		outlets = append(outlets, &o)
+	}
+	return outlets, rows.Err()
+}
+
+func (repo *OutletRepository) GetOutletByID(id int) (*models.Outlet, error) {
+	var o models.Outlet
+	err := scanOutlet(repo.db.QueryRow("SELECT "+outletColumns+" FROM outlets WHERE id = $1", id), &o)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("%w: outlet %d", ErrNotFound, id)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &o, nil
+}
+
+func (repo *OutletRepository) CreateOutlet(outlet *models.Outlet) error {
+	if err := repo.ensureUniqueCode(outlet.Code, 0); err != nil {
+		return err
+	}
+
+	query := `INSERT INTO outlets (code, name, outlet_type, address, timezone)
+				VALUES ($1, $2, $3, $4, NULLIF($5, '')) RETURNING id, is_default, created_at`
+	return repo.db.QueryRow(query, outlet.Code, outlet.Name, outlet.Type, outlet.Address, outlet.Timezone).
+		Scan(&outlet.ID, &outlet.IsDefault, &outlet.CreatedAt)
+}
+
+func (repo *OutletRepository) UpdateOutlet(outlet *models.Outlet) error {
+	if err := repo.ensureUniqueCode(outlet.Code, outlet.ID); err != nil {
+		return err
+	}
+
+	query := `UPDATE outlets SET code = $1, name = $2, outlet_type = $3, address = $4, timezone = NULLIF($5, '')
+				WHERE id = $6 RETURNING is_default, created_at, archived_at`
+	err := repo.db.QueryRow(query, outlet.Code, outlet.Name, outlet.Type, outlet.Address, outlet.Timezone, outlet.ID).
+		Scan(&outlet.IsDefault, &outlet.CreatedAt, &outlet.ArchivedAt)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("%w: outlet %d", ErrNotFound, outlet.ID)
+	}
+	return err
+}
+
+func (repo *OutletRepository) ensureUniqueCode(code string, exceptID int) error {
+	var exists bool
+	err := repo.db.QueryRow("SELECT EXISTS (SELECT 1 FROM outlets WHERE code = $1 AND id <> $2)", code, exceptID).Scan(&exists)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return fmt.Errorf("%w: kode outlet %s sudah dipakai", ErrValidation, code)
+	}
+	return nil
+}
+
+// ArchiveOutlet menolak outlet default dan outlet yang masih punya stok, supaya stok tidak "hilang" dari laporan.
+func (repo *OutletRepository) ArchiveOutlet(id int) error {
+	outlet, err := repo.GetOutletByID(id)
+	if err != nil {
+		return err
+	}
+	if outlet.IsDefault {
+		return fmt.Errorf("%w: outlet default tidak bisa diarsipkan", ErrValidation)
+	}
+	if outlet.ArchivedAt != nil {
+		return fmt.Errorf("%w: outlet sudah diarsipkan", ErrInvalidStatus)
+	}
+
+	var withStock int
+	err = repo.db.QueryRow("SELECT COUNT(*) FROM outlet_stocks WHERE outlet_id = $1 AND stock <> 0", id).Scan(&withStock)
+	if err != nil {
+		return err
+	}
+	if withStock > 0 {
+		return fmt.Errorf("%w: outlet masih punya stok untuk %d produk", ErrValidation, withStock)
+	}
+
+	_, err = repo.db.Exec("UPDATE outlets SET archived_at = NOW() WHERE id = $1", id)
+	return err
+}
+
+// GetOutletStocks mengembalikan stok dan harga yang berlaku di outlet untuk semua produk aktif.
+func (repo *OutletRepository) GetOutletStocks(outletID int, categoryID *int) ([]models.OutletStock, error) {
+	if _, err := repo.GetOutletByID(outletID); err != nil {
+		return nil, err
+	}
+
+	query := `SELECT p.id, COALESCE(p.sku, ''), p.name, p.category_id, COALESCE(os.stock, 0),
+					COALESCE(op.price, p.price), op.price
+				FROM products AS p
+				LEFT JOIN outlet_stocks AS os ON os.product_id = p.id AND os.outlet_id = $1
+				LEFT JOIN outlet_prices AS op ON op.product_id = p.id AND op.outlet_id = $1
+				WHERE p.archived_at IS NULL`
+	args := []interface{}{outletID}
+	if categoryID != nil {
+		query += " AND p.category_id = $2"
+		args = append(args, *categoryID)
+	}
+	query += " ORDER BY p.name, p.id"
+
+	rows, err := repo.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	stocks := make([]models.OutletStock, 0)
+	for rows.Next() {
+		var s models.OutletStock
+		err := rows.Scan(&s.ProductID, &s.SKU, &s.ProductName, &s.CategoryID, &s.Stock, &s.Price, &s.PriceOverride)
+		if err != nil {
+			return nil, err
+		}
+		stocks = append(stocks, s)
+	}
+	return stocks, rows.Err()
+}
+
+func (repo *OutletRepository) GetOutletPrices(outletID int) ([]models.OutletPrice, error) {
+	if _, err := repo.GetOutletByID(outletID); err != nil {
+		return nil, err
+	}
+
+	rows, err := repo.db.Query(`SELECT op.outlet_id, op.product_id, p.name, p.price, op.price, op.updated_by, op.updated_at
+				FROM outlet_prices AS op
+				JOIN products AS p ON p.id = op.product_id
+				WHERE op.outlet_id = $1
+				ORDER BY p.name, p.id`, outletID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	prices := make([]models.OutletPrice, 0)
+	for rows.Next() {
+		var p models.OutletPrice
+		err := rows.Scan(&p.OutletID, &p.ProductID, &p.ProductName, &p.BasePrice, &p.Price, &p.UpdatedBy, &p.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+		prices = append(prices, p)
+	}
+	return prices, rows.Err()
+}
+
+func (repo *OutletRepository) SetOutletPrice(price *models.OutletPrice) error {
+	if _, err := resolveOutlet(repo.db, price.OutletID); err != nil {
+		return err
+	}
+
+	err := repo.db.QueryRow("SELECT name, price FROM products WHERE id = $1", price.ProductID).Scan(&price.ProductName, &price.BasePrice)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("%w: product id %d", ErrNotFound, price.ProductID)
+	}
+	if err != nil {
+		return err
+	}
+
+	return repo.db.QueryRow(`INSERT INTO outlet_prices (outlet_id, product_id, price, updated_by) VALUES ($1, $2, $3, $4)
+				ON CONFLICT (outlet_id, product_id) DO UPDATE
+					SET price = EXCLUDED.price, updated_by = EXCLUDED.updated_by, updated_at = NOW()
+				RETURNING updated_at`,
+		price.OutletID, price.ProductID, price.Price, price.UpdatedBy).Scan(&price.UpdatedAt)
+}
+
+func (repo *OutletRepository) DeleteOutletPrice(outletID int, productID int) error {
+	result, err := repo.db.Exec("DELETE FROM outlet_prices WHERE outlet_id = $1 AND product_id = $2", outletID, productID)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return fmt.Errorf("%w: outlet %d tidak punya harga khusus untuk produk %d", ErrNotFound, outletID, productID)
+	}
+	return nil
+}
+
+// resolveOutlet mengembalikan outlet default kalau outletID 0. Outlet yang diarsipkan tidak boleh dipakai
+// untuk dokumen baru.
+func resolveOutlet(q dbExecutor, outletID int) (int, error) {
+	if outletID == 0 {
+		err := q.QueryRow("SELECT id FROM outlets WHERE is_default").Scan(&outletID)
+		if err == sql.ErrNoRows {
+			return 0, fmt.Errorf("%w: outlet default belum dibuat", ErrNotFound)
+		}
+		return outletID, err
+	}
+
+	var archived bool
+	err := q.QueryRow("SELECT archived_at IS NOT NULL FROM outlets WHERE id = $1", outletID).Scan(&archived)
+	if err == sql.ErrNoRows {
+		return 0, fmt.Errorf("%w: outlet %d", ErrNotFound, outletID)
+	}
+	if err != nil {
+		return 0, err
+	}
+	if archived {
+		return 0, fmt.Errorf("%w: outlet %d sudah diarsipkan", ErrValidation, outletID)
+	}
+
+	return outletID, nil
+}
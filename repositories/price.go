@@ -0,0 +1,273 @@
+package repositories
+
+import (
+	"database/sql"
+	"errors"
+	"kasir-api/models"
+	"time"
+)
+
+type PriceRepository struct {
+	db *sql.DB
+}
+
+func NewPriceRepository(db *sql.DB) *PriceRepository {
+	return &PriceRepository{db: db}
+}
+
+func (repo *PriceRepository) GetPriceHistory(productID int) ([]*models.PriceHistory, error) {
+	query := `SELECT id, product_id, old_price, new_price, changed_by, changed_at
+				FROM product_price_histories
+				WHERE product_id = $1
+				ORDER BY changed_at DESC, id DESC`
+	rows, err := repo.db.Query(query, productID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	histories := make([]*models.PriceHistory, 0)
+	for rows.Next() {
+		var h models.PriceHistory
+		err := rows.Scan(&h.ID, &h.ProductID, &h.OldPrice, &h.NewPrice, &h.ChangedBy, &h.ChangedAt)
+		if err != nil {
+			return nil, err
+		}
+		histories = append(histories, &h)
+	}
+
+	return histories, nil
+}
+
+func (repo *PriceRepository) GetScheduledPriceChanges(productID int) ([]*models.ScheduledPriceChange, error) {
+	query := `SELECT id, product_id, new_price, effective_at, status, created_by, created_at, applied_at, error
+				FROM scheduled_price_changes
+				WHERE product_id = $1
+				ORDER BY effective_at, id`
+	rows, err := repo.db.Query(query, productID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	changes := make([]*models.ScheduledPriceChange, 0)
+	for rows.Next() {
+		var c models.ScheduledPriceChange
+		err := rows.Scan(&c.ID, &c.ProductID, &c.NewPrice, &c.EffectiveAt, &c.Status, &c.CreatedBy, &c.CreatedAt, &c.AppliedAt, &c.Error)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, &c)
+	}
+
+	return changes, nil
+}
+
+func (repo *PriceRepository) CreateScheduledPriceChange(change *models.ScheduledPriceChange) error {
+	var exists bool
+	err := repo.db.QueryRow("SELECT EXISTS (SELECT 1 FROM products WHERE id = $1)", change.ProductID).Scan(&exists)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return errors.New("Produk tidak ditemukan")
+	}
+
+	query := `INSERT INTO scheduled_price_changes (product_id, new_price, effective_at, status, created_by)
+				VALUES ($1, $2, $3, $4, $5) RETURNING id, created_at`
+	change.Status = models.PriceChangePending
+	return repo.db.QueryRow(query, change.ProductID, change.NewPrice, change.EffectiveAt, change.Status, change.CreatedBy).
+		Scan(&change.ID, &change.CreatedAt)
+}
+
+func (repo *PriceRepository) CancelScheduledPriceChange(id int) error {
+	query := "UPDATE scheduled_price_changes SET status = $1 WHERE id = $2 AND status = $3"
+	result, err := repo.db.Exec(query, models.PriceChangeCancelled, id, models.PriceChangePending)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return errors.New("jadwal harga tidak ditemukan atau sudah diproses")
+	}
+
+	return nil
+}
+
+// ApplyDuePriceChanges menerapkan jadwal harga yang effective_at-nya sudah lewat, satu transaksi per jadwal.
+// Jadwal yang gagal ditandai failed beserta alasannya supaya tidak menahan jadwal berikutnya.
+// Dipanggil berkala oleh scheduler, SKIP LOCKED supaya aman kalau ada lebih dari satu instance.
+func (repo *PriceRepository) ApplyDuePriceChanges(now time.Time) (applied int, failed int, err error) {
+	for {
+		status, err := repo.applyNextPriceChange(now)
+		if err != nil {
+			return applied, failed, err
+		}
+		switch status {
+		case "":
+			return applied, failed, nil
+		case models.PriceChangeApplied:
+			applied++
+		case models.PriceChangeFailed:
+			failed++
+		}
+	}
+}
+
+// applyNextPriceChange menerapkan satu jadwal yang paling lama jatuh tempo dan mengembalikan status barunya,
+// string kosong kalau tidak ada lagi yang jatuh tempo.
+func (repo *PriceRepository) applyNextPriceChange(now time.Time) (string, error) {
+	tx, err := repo.db.Begin()
+	if err != nil {
+		return "", err
+	}
+	defer tx.Rollback()
+
+	var c models.ScheduledPriceChange
+	err = tx.QueryRow(`SELECT id, product_id, new_price, created_by
+				FROM scheduled_price_changes
+				WHERE status = $1 AND effective_at <= $2
+				ORDER BY effective_at, id
+				LIMIT 1
+				FOR UPDATE SKIP LOCKED`, models.PriceChangePending, now).Scan(&c.ID, &c.ProductID, &c.NewPrice, &c.CreatedBy)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	//savepoint supaya perubahan harga yang gagal bisa dibatalkan tanpa melepas lock jadwalnya
+	if _, err := tx.Exec("SAVEPOINT apply_price"); err != nil {
+		return "", err
+	}
+	c.Status = models.PriceChangeApplied
+	c.AppliedAt = &now
+	if applyErr := changePrice(tx, c.ProductID, c.NewPrice, c.CreatedBy); applyErr != nil {
+		if _, err := tx.Exec("ROLLBACK TO SAVEPOINT apply_price"); err != nil {
+			return "", err
+		}
+		c.Status = models.PriceChangeFailed
+		c.AppliedAt = nil
+		c.Error = applyErr.Error()
+	}
+
+	_, err = tx.Exec("UPDATE scheduled_price_changes SET status = $1, applied_at = $2, error = $3 WHERE id = $4",
+		c.Status, c.AppliedAt, c.Error, c.ID)
+	if err != nil {
+		return "", err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return "", err
+	}
+
+	return c.Status, nil
+}
+
+func (repo *PriceRepository) GetPriceTiers(productID int) ([]*models.PriceTier, error) {
+	query := `SELECT id, product_id, customer_group_id, min_quantity, price
+				FROM product_price_tiers
+				WHERE product_id = $1
+				ORDER BY customer_group_id NULLS FIRST, min_quantity`
+	rows, err := repo.db.Query(query, productID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tiers := make([]*models.PriceTier, 0)
+	for rows.Next() {
+		var t models.PriceTier
+		err := rows.Scan(&t.ID, &t.ProductID, &t.CustomerGroupID, &t.MinQuantity, &t.Price)
+		if err != nil {
+			return nil, err
+		}
+		tiers = append(tiers, &t)
+	}
+
+	return tiers, nil
+}
+
+func (repo *PriceRepository) CreatePriceTier(tier *models.PriceTier) error {
+	var exists bool
+	err := repo.db.QueryRow("SELECT EXISTS (SELECT 1 FROM products WHERE id = $1)", tier.ProductID).Scan(&exists)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return errors.New("Produk tidak ditemukan")
+	}
+
+	query := `INSERT INTO product_price_tiers (product_id, customer_group_id, min_quantity, price)
+				VALUES ($1, $2, $3, $4) RETURNING id`
+	return repo.db.QueryRow(query, tier.ProductID, tier.CustomerGroupID, tier.MinQuantity, tier.Price).Scan(&tier.ID)
+}
+
+func (repo *PriceRepository) DeletePriceTier(id int) error {
+	result, err := repo.db.Exec("DELETE FROM product_price_tiers WHERE id = $1", id)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return errors.New("price tier tidak ditemukan")
+	}
+
+	return nil
+}
+
+// resolveUnitPrice memilih harga satuan untuk checkout: yang termurah antara basePrice (harga produk,
+// atau harga outlet kalau ada) dan semua tier yang berlaku, baik tier umum maupun tier customer group.
+// Jadi tier hanya bisa menurunkan harga, dan anggota group tidak pernah membayar lebih mahal dari
+// pembeli biasa. quantity adalah jumlah produk ini di seluruh transaksi, bukan per baris.
+func resolveUnitPrice(tx *sql.Tx, productID int, basePrice int, quantity int, customerGroupID *int) (int, error) {
+	var price sql.NullInt64
+	err := tx.QueryRow(`SELECT MIN(price) FROM product_price_tiers
+				WHERE product_id = $1
+					AND min_quantity <= $2
+					AND (customer_group_id IS NULL OR customer_group_id = $3)`, productID, quantity, customerGroupID).Scan(&price)
+	if err != nil {
+		return 0, err
+	}
+	if !price.Valid || int(price.Int64) > basePrice {
+		return basePrice, nil
+	}
+
+	return int(price.Int64), nil
+}
+
+// changePrice mengubah harga produk di dalam transaksi dan mencatat riwayatnya kalau harganya berubah.
+func changePrice(tx *sql.Tx, productID int, newPrice int, changedBy string) error {
+	var oldPrice int
+	err := tx.QueryRow("SELECT price FROM products WHERE id = $1 FOR UPDATE", productID).Scan(&oldPrice)
+	if err == sql.ErrNoRows {
+		return errors.New("Produk tidak ditemukan")
+	}
+	if err != nil {
+		return err
+	}
+
+	if oldPrice == newPrice {
+		return nil
+	}
+
+	_, err = tx.Exec("UPDATE products SET price = $1 WHERE id = $2", newPrice, productID)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec("INSERT INTO product_price_histories (product_id, old_price, new_price, changed_by) VALUES ($1, $2, $3, $4)",
+		productID, oldPrice, newPrice, changedBy)
+	return err
+}
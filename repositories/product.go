@@ -2,8 +2,14 @@ package repositories
 
 import (
 	"database/sql"
+	"encoding/base64"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"kasir-api/models"
+	"strconv"
+	"strings"
+	"time"
 )
 
 type ProductRepository struct {
@@ -14,72 +20,253 @@ func NewProductRepository(db *sql.DB) *ProductRepository {
 	return &ProductRepository{db: db}
 }
 
-func (repo *ProductRepository) GetAllProducts(name string) ([]*models.ProductWithCategory, error) {
-	query := `SELECT p.id, p.name, p.price, p.stock, p.category_id, c.name AS category_name 
-				FROM products AS p 
-				JOIN categories AS c ON p.category_id = c.id`
+var ErrInvalidFilter = errors.New("filter tidak valid")
+
+var productSortColumns = map[string]string{
+	"id":         "p.id",
+	"name":       "p.name",
+	"price":      "p.price",
+	"stock":      "p.stock",
+	"created_at": "p.created_at",
+}
+
+// GetProducts mengembalikan satu halaman produk sesuai filter. Kalau filter.Cursor diisi, pagination
+// memakai keyset (sort column, id) dan Offset diabaikan.
+func (repo *ProductRepository) GetProducts(filter models.ProductFilter) (*models.ProductPage, error) {
+	sortColumn, ok := productSortColumns[filter.Sort]
+	if !ok {
+		return nil, fmt.Errorf("%w: sort %q tidak didukung", ErrInvalidFilter, filter.Sort)
+	}
+	direction := "ASC"
+	comparator := ">"
+	if filter.Order == "desc" {
+		direction = "DESC"
+		comparator = "<"
+	}
 
-	var args []interface{}
-	if name != "" {
-		query += " WHERE p.name ILIKE $1"
-		args = append(args, "%"+name+"%")
+	conditions, args := productFilterConditions(filter)
+	where := ""
+	if len(conditions) > 0 {
+		where = " WHERE " + strings.Join(conditions, " AND ")
 	}
 
-	rows, err := repo.db.Query(query, args...)
+	page := &models.ProductPage{
+		Data: make([]*models.ProductWithCategory, 0),
+		Meta: models.PageMeta{Limit: filter.Limit, Offset: filter.Offset},
+	}
+
+	countQuery := "SELECT COUNT(*) FROM products AS p JOIN categories AS c ON p.category_id = c.id" + where
+	err := repo.db.QueryRow(countQuery, args...).Scan(&page.Meta.Total)
 	if err != nil {
 		return nil, err
 	}
-	defer rows.Close()
 
-	products := make([]*models.ProductWithCategory, 0)
-	for rows.Next() {
-		var p models.ProductWithCategory
-		err := rows.Scan(&p.ID, &p.Name, &p.Price, &p.Stock, &p.CategoryID, &p.CategoryName)
+	if filter.Cursor != "" {
+		value, id, err := decodeProductCursor(filter.Cursor, filter.Sort, filter.Order)
 		if err != nil {
 			return nil, err
 		}
-		products = append(products, &p)
+		args = append(args, value, id)
+		cursorCondition := fmt.Sprintf("(%s, p.id) %s ($%d, $%d)", sortColumn, comparator, len(args)-1, len(args))
+		if where == "" {
+			where = " WHERE " + cursorCondition
+		} else {
+			where += " AND " + cursorCondition
+		}
+		page.Meta.Offset = 0
 	}
 
-	return products, nil
-}
+	query := `SELECT p.id, COALESCE(p.sku, ''), COALESCE(p.barcode, ''), p.name, p.price, p.cost_price, p.reorder_point, p.reorder_quantity, p.stock, p.category_id, p.created_at, p.archived_at, c.name AS category_name,
+					COALESCE(pi.url, ''), COALESCE(pi.thumbnail_url, '')
+				FROM products AS p 
+				JOIN categories AS c ON p.category_id = c.id
+				LEFT JOIN product_images AS pi ON pi.product_id = p.id AND pi.is_primary` + where
 
-func (repo *ProductRepository) GetAllProductsByCategoryID(categoryID int) ([]*models.ProductWithCategory, error) {
-	query := `SELECT p.id, p.name, p.price, p.stock, p.category_id, c.name AS category_name 
-				FROM products AS p JOIN categories AS c ON p.category_id = c.id 
-				WHERE p.category_id = $1`
-	rows, err := repo.db.Query(query, categoryID)
+	//ambil satu baris lebih untuk tahu masih ada halaman berikutnya atau tidak
+	args = append(args, filter.Limit+1)
+	query += fmt.Sprintf(" ORDER BY %s %s, p.id %s LIMIT $%d", sortColumn, direction, direction, len(args))
+	if filter.Cursor == "" && filter.Offset > 0 {
+		args = append(args, filter.Offset)
+		query += fmt.Sprintf(" OFFSET $%d", len(args))
+	}
+
+	rows, err := repo.db.Query(query, args...)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	products := make([]*models.ProductWithCategory, 0)
 	for rows.Next() {
 		var p models.ProductWithCategory
-		err := rows.Scan(&p.ID, &p.Name, &p.Price, &p.Stock, &p.CategoryID, &p.CategoryName)
+		err := rows.Scan(&p.ID, &p.SKU, &p.Barcode, &p.Name, &p.Price, &p.CostPrice, &p.ReorderPoint, &p.ReorderQuantity, &p.Stock, &p.CategoryID, &p.CreatedAt, &p.ArchivedAt, &p.CategoryName, &p.ImageURL, &p.ImageThumbnailURL)
 		if err != nil {
 			return nil, err
 		}
-		products = append(products, &p)
+		page.Data = append(page.Data, &p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(page.Data) > filter.Limit {
+		page.Data = page.Data[:filter.Limit]
+		page.Meta.NextCursor = encodeProductCursor(filter.Sort, filter.Order, page.Data[len(page.Data)-1])
 	}
 
-	return products, nil
+	return page, nil
 }
 
-func (repo *ProductRepository) CreateProduct(product *models.Product) error {
-	query := "INSERT INTO products (name, price, stock, category_id) VALUES ($1, $2, $3, $4) RETURNING id"
-	err := repo.db.QueryRow(query, product.Name, product.Price, product.Stock, product.CategoryID).Scan(&product.ID)
-	return err
+func productFilterConditions(filter models.ProductFilter) ([]string, []interface{}) {
+	conditions := []string{}
+	args := []interface{}{}
+	add := func(condition string, values ...interface{}) {
+		placeholders := make([]interface{}, len(values))
+		for i, v := range values {
+			args = append(args, v)
+			placeholders[i] = len(args)
+		}
+		conditions = append(conditions, fmt.Sprintf(condition, placeholders...))
+	}
+
+	if !filter.IncludeArchived {
+		conditions = append(conditions, "p.archived_at IS NULL")
+	}
+	if filter.Name != "" {
+		query, tsQuery, skuPrefix := searchArgs(filter.Name)
+		add(productSearchMatch, query, tsQuery, skuPrefix)
+	}
+	if filter.CategoryID != nil {
+		add("p.category_id = $%d", *filter.CategoryID)
+	}
+	if filter.MinPrice != nil {
+		add("p.price >= $%d", *filter.MinPrice)
+	}
+	if filter.MaxPrice != nil {
+		add("p.price <= $%d", *filter.MaxPrice)
+	}
+	if filter.InStock != nil {
+		if *filter.InStock {
+			conditions = append(conditions, "p.stock > 0")
+		} else {
+			conditions = append(conditions, "p.stock <= 0")
+		}
+	}
+	if filter.LowStock {
+		add("p.stock > 0 AND p.stock <= $%d", filter.LowStockThreshold)
+	}
+
+	return conditions, args
+}
+
+// productCursor ikut menyimpan sort dan order, karena Value hanya bisa dibandingkan dengan kolom yang sama.
+type productCursor struct {
+	Sort  string `json:"s"`
+	Order string `json:"o"`
+	Value string `json:"v"`
+	ID    int    `json:"id"`
+}
+
+func encodeProductCursor(sort string, order string, p *models.ProductWithCategory) string {
+	c := productCursor{Sort: sort, Order: order, ID: p.ID}
+	switch sort {
+	case "id":
+		c.Value = strconv.Itoa(p.ID)
+	case "name":
+		c.Value = p.Name
+	case "price":
+		c.Value = strconv.Itoa(p.Price)
+	case "stock":
+		c.Value = strconv.Itoa(p.Stock)
+	case "created_at":
+		c.Value = p.CreatedAt.Format(time.RFC3339Nano)
+	}
+
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func decodeProductCursor(cursor string, sort string, order string) (string, int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return "", 0, fmt.Errorf("%w: cursor tidak valid", ErrInvalidFilter)
+	}
+
+	var c productCursor
+	if err := json.Unmarshal(b, &c); err != nil {
+		return "", 0, fmt.Errorf("%w: cursor tidak valid", ErrInvalidFilter)
+	}
+	if c.Sort != sort || c.Order != order {
+		return "", 0, fmt.Errorf("%w: cursor dibuat untuk sort %s %s, ulangi dari halaman pertama tanpa cursor", ErrInvalidFilter, c.Sort, c.Order)
+	}
+
+	//nilai dicek di sini supaya cursor yang diubah client jadi 400, bukan error tipe data dari database
+	switch sort {
+	case "id", "price", "stock":
+		_, err = strconv.Atoi(c.Value)
+	case "created_at":
+		_, err = time.Parse(time.RFC3339Nano, c.Value)
+	}
+	if err != nil {
+		return "", 0, fmt.Errorf("%w: cursor tidak valid", ErrInvalidFilter)
+	}
+
+	return c.Value, c.ID, nil
+}
+
+func (repo *ProductRepository) CreateProduct(product *models.Product, createdBy string) error {
+	tx, err := repo.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	err = ensureActiveCategory(tx, product.CategoryID)
+	if err != nil {
+		return err
+	}
+
+	//stok awal masuk ke outlet default
+	outletID, err := resolveOutlet(tx, 0)
+	if err != nil {
+		return err
+	}
+
+	//stok awal dicatat lewat ledger, jadi insert dengan stok 0 dulu
+	query := `INSERT INTO products (sku, barcode, name, price, cost_price, reorder_point, reorder_quantity, stock, category_id)
+				VALUES (NULLIF($1, ''), NULLIF($2, ''), $3, $4, $5, $6, $7, 0, $8) RETURNING id, created_at`
+	err = tx.QueryRow(query, product.SKU, product.Barcode, product.Name, product.Price, product.CostPrice,
+		product.ReorderPoint, product.ReorderQuantity, product.CategoryID).
+		Scan(&product.ID, &product.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	product.Stock, err = applyStockChange(tx, stockChange{
+		ProductID:     product.ID,
+		OutletID:      outletID,
+		Type:          models.StockMovementAdjustment,
+		Quantity:      product.Stock,
+		ReferenceType: "product",
+		ReferenceID:   &product.ID,
+		Note:          "stok awal produk baru",
+		CreatedBy:     createdBy,
+	})
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
 }
 
 func (repo *ProductRepository) GetProductByID(id int) (*models.ProductWithCategory, error) {
-	query := `SELECT p.id, p.name, p.price, p.stock, p.category_id, c.name AS category_name 
+	query := `SELECT p.id, COALESCE(p.sku, ''), COALESCE(p.barcode, ''), p.name, p.price, p.cost_price, p.reorder_point, p.reorder_quantity, p.stock, p.category_id, p.created_at, p.archived_at, c.name AS category_name,
+					COALESCE(pi.url, ''), COALESCE(pi.thumbnail_url, '')
 				FROM products AS p JOIN categories AS c ON p.category_id = c.id 
+				LEFT JOIN product_images AS pi ON pi.product_id = p.id AND pi.is_primary
 				WHERE p.id = $1`
 
 	var p models.ProductWithCategory
-	err := repo.db.QueryRow(query, id).Scan(&p.ID, &p.Name, &p.Price, &p.Stock, &p.CategoryID, &p.CategoryName)
+	err := repo.db.QueryRow(query, id).Scan(&p.ID, &p.SKU, &p.Barcode, &p.Name, &p.Price, &p.CostPrice, &p.ReorderPoint, &p.ReorderQuantity, &p.Stock, &p.CategoryID, &p.CreatedAt, &p.ArchivedAt, &p.CategoryName, &p.ImageURL, &p.ImageThumbnailURL)
 	if err == sql.ErrNoRows {
 		return nil, errors.New("Produk tidak ditemukan")
 	}
@@ -90,27 +277,41 @@ func (repo *ProductRepository) GetProductByID(id int) (*models.ProductWithCatego
 	return &p, nil
 }
 
-func (repo *ProductRepository) UpdateProduct(product *models.Product) error {
-	query := "UPDATE products SET name = $1, price = $2, stock = $3, category_id = $4 WHERE id = $5"
-	result, err := repo.db.Exec(query, product.Name, product.Price, product.Stock, product.CategoryID, product.ID)
+// UpdateProduct tidak menyentuh stok dan harga pokok. Perubahan stok harus lewat stock adjustment supaya ada
+// alasannya, harga pokok dihitung ulang saat barang diterima dari supplier.
+func (repo *ProductRepository) UpdateProduct(product *models.Product, changedBy string) error {
+	tx, err := repo.db.Begin()
 	if err != nil {
 		return err
 	}
+	defer tx.Rollback()
 
-	rows, err := result.RowsAffected()
+	err = ensureActiveCategory(tx, product.CategoryID)
 	if err != nil {
 		return err
 	}
 
-	if rows == 0 {
-		return errors.New("Produk tidak ditemukan")
+	//harga lewat changePrice supaya riwayatnya tercatat
+	err = changePrice(tx, product.ID, product.Price, changedBy)
+	if err != nil {
+		return err
 	}
 
-	return nil
+	query := `UPDATE products SET sku = NULLIF($1, ''), barcode = NULLIF($2, ''), name = $3, category_id = $4,
+					reorder_point = $5, reorder_quantity = $6
+				WHERE id = $7`
+	_, err = tx.Exec(query, product.SKU, product.Barcode, product.Name, product.CategoryID,
+		product.ReorderPoint, product.ReorderQuantity, product.ID)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
 }
 
-func (repo *ProductRepository) DeleteProduct(id int) error {
-	query := "DELETE FROM products WHERE id = $1"
+// ArchiveProduct menggantikan hard delete supaya transaction_details dan laporan lama tetap utuh.
+func (repo *ProductRepository) ArchiveProduct(id int) error {
+	query := "UPDATE products SET archived_at = NOW() WHERE id = $1 AND archived_at IS NULL"
 	result, err := repo.db.Exec(query, id)
 	if err != nil {
 		return err
@@ -121,8 +322,53 @@ func (repo *ProductRepository) DeleteProduct(id int) error {
 	}
 
 	if rows == 0 {
+		return repo.notFoundOrState(id, "produk sudah diarsipkan")
+	}
+
+	return nil
+}
+
+func (repo *ProductRepository) RestoreProduct(id int) error {
+	tx, err := repo.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var categoryID int
+	var archivedAt *time.Time
+	err = tx.QueryRow("SELECT category_id, archived_at FROM products WHERE id = $1 FOR UPDATE", id).Scan(&categoryID, &archivedAt)
+	if err == sql.ErrNoRows {
 		return errors.New("Produk tidak ditemukan")
 	}
+	if err != nil {
+		return err
+	}
+	if archivedAt == nil {
+		return errors.New("produk tidak sedang diarsipkan")
+	}
 
-	return err
+	err = ensureActiveCategory(tx, categoryID)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec("UPDATE products SET archived_at = NULL WHERE id = $1", id)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (repo *ProductRepository) notFoundOrState(id int, stateMessage string) error {
+	var exists bool
+	err := repo.db.QueryRow("SELECT EXISTS (SELECT 1 FROM products WHERE id = $1)", id).Scan(&exists)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("%w: produk %d tidak ditemukan", ErrNotFound, id)
+	}
+	return fmt.Errorf("%w: %s", ErrInvalidStatus, stateMessage)
 }
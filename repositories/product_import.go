@@ -0,0 +1,189 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+	"kasir-api/models"
+	"strings"
+)
+
+// ImportProducts meng-upsert produk berdasarkan SKU dalam satu transaksi dan membuat kategori yang belum ada.
+// Pada dry run transaksinya di-rollback, jadi hitungan created/updated tetap akurat tanpa mengubah data.
+// Kolom stok adalah stok total semua outlet, sama dengan hasil export, supaya file export yang diimport ulang
+// tanpa diubah tidak mengubah stok. Selisihnya masuk ke outlet default, jadi kalau ada lebih dari satu outlet aktif
+// perubahan stok produk lama diabaikan dengan warning dan harus lewat stock adjustment atau transfer.
+func (repo *ProductRepository) ImportProducts(rows []models.ProductImportRow, changedBy string, dryRun bool) (*models.ProductImportResult, error) {
+	tx, err := repo.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	categoryIDs, err := activeCategoryIDs(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	//stok produk baru dan selisih stok produk lama masuk ke outlet default
+	outletID, err := resolveOutlet(tx, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	var outletCount int
+	if err := tx.QueryRow("SELECT COUNT(*) FROM outlets WHERE archived_at IS NULL").Scan(&outletCount); err != nil {
+		return nil, err
+	}
+
+	result := &models.ProductImportResult{
+		DryRun:            dryRun,
+		TotalRows:         len(rows),
+		CategoriesCreated: make([]string, 0),
+		Errors:            make([]models.ProductImportError, 0),
+		Warnings:          make([]models.ProductImportError, 0),
+	}
+
+	for _, row := range rows {
+		key := strings.ToLower(row.CategoryName)
+		categoryID, ok := categoryIDs[key]
+		if !ok {
+			err := tx.QueryRow("INSERT INTO categories (name, description) VALUES ($1, '') RETURNING id", row.CategoryName).Scan(&categoryID)
+			if err != nil {
+				return nil, err
+			}
+			categoryIDs[key] = categoryID
+			result.CategoriesCreated = append(result.CategoriesCreated, row.CategoryName)
+		}
+
+		movement := stockChange{
+			OutletID:      outletID,
+			Type:          models.StockMovementAdjustment,
+			ReferenceType: "import",
+			Note:          fmt.Sprintf("import produk baris %d", row.Row),
+			CreatedBy:     changedBy,
+		}
+
+		var productID, currentStock int
+		err := tx.QueryRow("SELECT id, stock FROM products WHERE sku = $1 FOR UPDATE", row.SKU).Scan(&productID, &currentStock)
+		if err == sql.ErrNoRows {
+			err = tx.QueryRow(`INSERT INTO products (sku, barcode, name, price, stock, category_id)
+						VALUES ($1, NULLIF($2, ''), $3, $4, 0, $5) RETURNING id`,
+				row.SKU, row.Barcode, row.Name, row.Price, categoryID).Scan(&productID)
+			if err != nil {
+				return nil, err
+			}
+
+			if row.Stock != nil {
+				movement.ProductID = productID
+				movement.Quantity = *row.Stock
+				if _, err := applyStockChange(tx, movement); err != nil {
+					return nil, err
+				}
+			}
+			result.Created++
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		//produknya tetap diupdate, hanya stoknya yang tidak diubah
+		if row.Stock != nil && *row.Stock != currentStock && outletCount > 1 {
+			result.Warnings = append(result.Warnings, models.ProductImportError{
+				Row:     row.Row,
+				Field:   "stock",
+				Message: fmt.Sprintf("stok %d berbeda dengan stok total %d dan diabaikan, dengan lebih dari satu outlet perubahan stok harus lewat stock adjustment", *row.Stock, currentStock),
+			})
+			row.Stock = nil
+		}
+
+		err = changePrice(tx, productID, row.Price, changedBy)
+		if err != nil {
+			return nil, err
+		}
+
+		_, err = tx.Exec(`UPDATE products SET name = $1, category_id = $2, barcode = COALESCE(NULLIF($3, ''), barcode)
+				WHERE id = $4`,
+			row.Name, categoryID, row.Barcode, productID)
+		if err != nil {
+			return nil, err
+		}
+
+		if row.Stock != nil {
+			movement.ProductID = productID
+			movement.Quantity = *row.Stock - currentStock
+			if _, err := applyStockChange(tx, movement); err != nil {
+				return nil, err
+			}
+			if movement.Quantity < 0 {
+				if err := deductBatches(tx, outletID, productID, nil, -movement.Quantity); err != nil {
+					return nil, err
+				}
+			}
+		}
+		result.Updated++
+	}
+
+	if dryRun {
+		return result, nil
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	result.Applied = true
+
+	return result, nil
+}
+
+// StreamProducts memanggil fn untuk setiap produk tanpa menampung semuanya di memori. Stock adalah total semua outlet.
+func (repo *ProductRepository) StreamProducts(includeArchived bool, fn func(p *models.ProductWithCategory) error) error {
+	query := `SELECT p.id, COALESCE(p.sku, ''), COALESCE(p.barcode, ''), p.name, p.price, p.stock, p.category_id, p.created_at, p.archived_at, c.name
+				FROM products AS p
+				JOIN categories AS c ON p.category_id = c.id`
+	if !includeArchived {
+		query += " WHERE p.archived_at IS NULL"
+	}
+	query += " ORDER BY p.id"
+
+	rows, err := repo.db.Query(query)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var p models.ProductWithCategory
+		err := rows.Scan(&p.ID, &p.SKU, &p.Barcode, &p.Name, &p.Price, &p.Stock, &p.CategoryID, &p.CreatedAt, &p.ArchivedAt, &p.CategoryName)
+		if err != nil {
+			return err
+		}
+		if err := fn(&p); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+func activeCategoryIDs(q dbExecutor) (map[string]int, error) {
+	rows, err := q.Query("SELECT id, name FROM categories WHERE archived_at IS NULL ORDER BY id")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ids := make(map[string]int)
+	for rows.Next() {
+		var id int
+		var name string
+		if err := rows.Scan(&id, &name); err != nil {
+			return nil, err
+		}
+		if _, exists := ids[strings.ToLower(name)]; !exists {
+			ids[strings.ToLower(name)] = id
+		}
+	}
+
+	return ids, rows.Err()
+}
@@ -0,0 +1,92 @@
+package repositories
+
+import (
+	"fmt"
+	"kasir-api/models"
+	"strings"
+	"unicode"
+)
+
+// productSearchMatch adalah format string untuk fmt.Sprintf dengan tiga placeholder:
+// teks pencarian, tsquery prefix dan prefix sku. Trigram menangani salah ketik ("indomi" -> "Indomie").
+// Setiap kondisi hanya memakai kolom products supaya Postgres bisa menggabungkan index GIN search_vector,
+// trigram name/sku dan index barcode dengan BitmapOr. search_vector sudah berisi nama kategori
+// (migration 007), salah ketik nama kategori dicocokkan lewat ARRAY(subquery) yang dihitung sekali.
+// <% memakai pg_trgm.word_similarity_threshold 0.3 yang diset di database oleh migration 007,
+// word_similarity di dalam fungsi tidak bisa memakai index.
+const productSearchMatch = `(p.search_vector @@ to_tsquery('simple', $%[2]d)
+					OR $%[1]d <%% p.name
+					OR p.sku ILIKE $%[3]d
+					OR p.barcode = $%[1]d
+					OR p.category_id = ANY (ARRAY(SELECT id FROM categories
+						WHERE $%[1]d <%% name AND word_similarity($%[1]d, name) >= 0.5)))`
+
+// SearchProducts mencari produk dengan full-text search dan trigram, diurutkan berdasarkan relevansi.
+func (repo *ProductRepository) SearchProducts(q string, limit int, categoryID *int, includeArchived bool) ([]*models.ProductSearchResult, error) {
+	query, tsQuery, skuPrefix := searchArgs(q)
+	args := []interface{}{query, tsQuery, skuPrefix}
+
+	where := fmt.Sprintf(productSearchMatch, 1, 2, 3)
+	if !includeArchived {
+		where += " AND p.archived_at IS NULL"
+	}
+	if categoryID != nil {
+		args = append(args, *categoryID)
+		where += fmt.Sprintf(" AND p.category_id = $%d", len(args))
+	}
+	args = append(args, limit)
+
+	sqlQuery := fmt.Sprintf(`SELECT p.id, COALESCE(p.sku, ''), COALESCE(p.barcode, ''), p.name, p.price, p.cost_price, p.reorder_point, p.reorder_quantity, p.stock, p.category_id,
+					p.created_at, p.archived_at, c.name AS category_name,
+					COALESCE(pi.url, ''), COALESCE(pi.thumbnail_url, ''),
+					(COALESCE(ts_rank(p.search_vector, to_tsquery('simple', $2)), 0)
+						+ word_similarity($1, p.name)
+						+ CASE WHEN lower(p.sku) = $1 OR p.barcode = $1 THEN 1 ELSE 0 END) AS rank,
+					COALESCE(ts_headline('simple', p.name, to_tsquery('simple', $2), 'StartSel=<mark>, StopSel=</mark>, HighlightAll=true'), p.name)
+				FROM products AS p
+				JOIN categories AS c ON p.category_id = c.id
+				LEFT JOIN product_images AS pi ON pi.product_id = p.id AND pi.is_primary
+				WHERE %[1]s
+				ORDER BY rank DESC, p.name, p.id
+				LIMIT $%[2]d`, where, len(args))
+
+	rows, err := repo.db.Query(sqlQuery, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	results := make([]*models.ProductSearchResult, 0)
+	for rows.Next() {
+		var r models.ProductSearchResult
+		err := rows.Scan(&r.ID, &r.SKU, &r.Barcode, &r.Name, &r.Price, &r.CostPrice, &r.ReorderPoint, &r.ReorderQuantity, &r.Stock, &r.CategoryID, &r.CreatedAt, &r.ArchivedAt,
+			&r.CategoryName, &r.ImageURL, &r.ImageThumbnailURL, &r.Rank, &r.Highlight)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, &r)
+	}
+
+	return results, rows.Err()
+}
+
+// searchArgs menyiapkan argumen pencarian: teks yang dinormalisasi, tsquery dengan prefix matching
+// untuk type-ahead ("indomi gor" -> "indomi:* & gor:*"), dan pola prefix sku untuk ILIKE.
+// tsquery nil kalau tidak ada token yang bisa dipakai, supaya to_tsquery tidak dipanggil dengan string kosong.
+func searchArgs(q string) (string, interface{}, string) {
+	normalized := strings.ToLower(strings.TrimSpace(q))
+
+	tokens := strings.FieldsFunc(normalized, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	var tsQuery interface{}
+	if len(tokens) > 0 {
+		for i, t := range tokens {
+			tokens[i] = t + ":*"
+		}
+		tsQuery = strings.Join(tokens, " & ")
+	}
+
+	likeEscaper := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+	return normalized, tsQuery, likeEscaper.Replace(normalized) + "%"
+}
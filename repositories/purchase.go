@@ -0,0 +1,482 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+	"kasir-api/models"
+	"time"
+)
+
+type PurchaseRepository struct {
+	db *sql.DB
+}
+
+func NewPurchaseRepository(db *sql.DB) *PurchaseRepository {
+	return &PurchaseRepository{db: db}
+}
+
+const purchaseOrderColumns = `po.id, po.supplier_id, s.name, po.outlet_id, po.status, COALESCE(to_char(po.expected_date, 'YYYY-MM-DD'), ''),
+					po.note, po.total_amount, po.created_by, po.created_at, po.updated_at`
+
+func (repo *PurchaseRepository) GetPurchaseOrders(status string, supplierID *int) ([]*models.PurchaseOrder, error) {
+	query := "SELECT " + purchaseOrderColumns + " FROM purchase_orders AS po JOIN suppliers AS s ON s.id = po.supplier_id WHERE TRUE"
+	args := []interface{}{}
+	if status != "" {
+		args = append(args, status)
+		query += fmt.Sprintf(" AND po.status = $%d", len(args))
+	}
+	if supplierID != nil {
+		args = append(args, *supplierID)
+		query += fmt.Sprintf(" AND po.supplier_id = $%d", len(args))
+	}
+	query += " ORDER BY po.created_at DESC, po.id DESC"
+
+	rows, err := repo.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	orders := make([]*models.PurchaseOrder, 0)
+	for rows.Next() {
+		var po models.PurchaseOrder
+		err := rows.Scan(&po.ID, &po.SupplierID, &po.SupplierName, &po.OutletID, &po.Status, &po.ExpectedDate,
+			&po.Note, &po.TotalAmount, &po.CreatedBy, &po.CreatedAt, &po.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+		orders = append(orders, &po)
+	}
+
+	return orders, rows.Err()
+}
+
+func (repo *PurchaseRepository) GetPurchaseOrderByID(id int) (*models.PurchaseOrder, error) {
+	po, err := getPurchaseOrder(repo.db, id, false)
+	if err != nil {
+		return nil, err
+	}
+
+	po.Receipts, err = getGoodsReceipts(repo.db, "gr.purchase_order_id = $1", id)
+	if err != nil {
+		return nil, err
+	}
+
+	return po, nil
+}
+
+func (repo *PurchaseRepository) CreatePurchaseOrder(po *models.PurchaseOrder) error {
+	tx, err := repo.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	err = ensureActiveSupplier(tx, po.SupplierID)
+	if err != nil {
+		return err
+	}
+	po.OutletID, err = resolveOutlet(tx, po.OutletID)
+	if err != nil {
+		return err
+	}
+
+	po.Status = models.PurchaseOrderDraft
+	err = tx.QueryRow(`INSERT INTO purchase_orders (supplier_id, outlet_id, status, expected_date, note, created_by)
+				VALUES ($1, $2, $3, NULLIF($4, '')::date, $5, $6) RETURNING id, created_at, updated_at`,
+		po.SupplierID, po.OutletID, po.Status, po.ExpectedDate, po.Note, po.CreatedBy).Scan(&po.ID, &po.CreatedAt, &po.UpdatedAt)
+	if err != nil {
+		return err
+	}
+
+	err = insertPurchaseOrderLines(tx, po)
+	if err != nil {
+		return err
+	}
+
+	err = tx.QueryRow("SELECT name FROM suppliers WHERE id = $1", po.SupplierID).Scan(&po.SupplierName)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// UpdatePurchaseOrder hanya untuk PO yang masih draft. Semua line diganti dengan yang baru.
+func (repo *PurchaseRepository) UpdatePurchaseOrder(po *models.PurchaseOrder) error {
+	tx, err := repo.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	current, err := getPurchaseOrder(tx, po.ID, true)
+	if err != nil {
+		return err
+	}
+	if current.Status != models.PurchaseOrderDraft {
+		return fmt.Errorf("%w: PO %d sudah %s, hanya draft yang boleh diubah", ErrInvalidStatus, po.ID, current.Status)
+	}
+
+	err = ensureActiveSupplier(tx, po.SupplierID)
+	if err != nil {
+		return err
+	}
+	po.OutletID, err = resolveOutlet(tx, po.OutletID)
+	if err != nil {
+		return err
+	}
+
+	err = tx.QueryRow(`UPDATE purchase_orders SET supplier_id = $1, outlet_id = $2, expected_date = NULLIF($3, '')::date, note = $4, updated_at = NOW()
+				WHERE id = $5 RETURNING status, created_by, created_at, updated_at`,
+		po.SupplierID, po.OutletID, po.ExpectedDate, po.Note, po.ID).Scan(&po.Status, &po.CreatedBy, &po.CreatedAt, &po.UpdatedAt)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec("DELETE FROM purchase_order_lines WHERE purchase_order_id = $1", po.ID)
+	if err != nil {
+		return err
+	}
+
+	err = insertPurchaseOrderLines(tx, po)
+	if err != nil {
+		return err
+	}
+
+	err = tx.QueryRow("SELECT name FROM suppliers WHERE id = $1", po.SupplierID).Scan(&po.SupplierName)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// SetPurchaseOrderStatus dipakai untuk transisi yang tidak menyentuh stok (kirim ke supplier, batal).
+func (repo *PurchaseRepository) SetPurchaseOrderStatus(id int, status string, allowedFrom ...string) (*models.PurchaseOrder, error) {
+	tx, err := repo.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	po, err := getPurchaseOrder(tx, id, true)
+	if err != nil {
+		return nil, err
+	}
+
+	allowed := false
+	for _, from := range allowedFrom {
+		if po.Status == from {
+			allowed = true
+			break
+		}
+	}
+	if !allowed {
+		return nil, fmt.Errorf("%w: PO %d berstatus %s, tidak bisa diubah jadi %s", ErrInvalidStatus, id, po.Status, status)
+	}
+
+	err = tx.QueryRow("UPDATE purchase_orders SET status = $1, updated_at = NOW() WHERE id = $2 RETURNING status, updated_at",
+		status, id).Scan(&po.Status, &po.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return po, nil
+}
+
+// ReceivePurchaseOrder mencatat kedatangan barang untuk PO. Boleh sebagian, sisa yang belum datang tetap
+// bisa diterima lewat receipt berikutnya. Stok dan harga pokok diubah dalam transaksi yang sama.
+func (repo *PurchaseRepository) ReceivePurchaseOrder(id int, receipt *models.GoodsReceipt) (*models.PurchaseOrder, error) {
+	tx, err := repo.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	po, err := getPurchaseOrder(tx, id, true)
+	if err != nil {
+		return nil, err
+	}
+	if po.Status != models.PurchaseOrderSent && po.Status != models.PurchaseOrderPartiallyReceived {
+		return nil, fmt.Errorf("%w: PO %d berstatus %s, barang hanya bisa diterima setelah PO dikirim", ErrInvalidStatus, id, po.Status)
+	}
+
+	orderLines := make(map[int]*models.PurchaseOrderLine)
+	for i := range po.Lines {
+		orderLines[po.Lines[i].ProductID] = &po.Lines[i]
+	}
+
+	for i, line := range receipt.Lines {
+		orderLine, ok := orderLines[line.ProductID]
+		if !ok {
+			return nil, fmt.Errorf("%w: produk %d tidak ada di PO %d", ErrValidation, line.ProductID, id)
+		}
+		remaining := orderLine.Quantity - orderLine.ReceivedQuantity
+		if line.Quantity > remaining {
+			return nil, fmt.Errorf("%w: produk %s hanya tersisa %d yang belum diterima", ErrValidation, orderLine.ProductName, remaining)
+		}
+		if line.UnitCost == nil {
+			unitCost := orderLine.UnitCost
+			receipt.Lines[i].UnitCost = &unitCost
+		}
+
+		orderLine.ReceivedQuantity += line.Quantity
+		_, err = tx.Exec("UPDATE purchase_order_lines SET received_quantity = $1 WHERE id = $2", orderLine.ReceivedQuantity, orderLine.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	receipt.PurchaseOrderID = &po.ID
+	receipt.SupplierID = po.SupplierID
+	receipt.SupplierName = po.SupplierName
+	receipt.OutletID = po.OutletID
+	err = postGoodsReceipt(tx, receipt)
+	if err != nil {
+		return nil, err
+	}
+
+	status := models.PurchaseOrderReceived
+	for _, l := range po.Lines {
+		if l.ReceivedQuantity < l.Quantity {
+			status = models.PurchaseOrderPartiallyReceived
+			break
+		}
+	}
+	err = tx.QueryRow("UPDATE purchase_orders SET status = $1, updated_at = NOW() WHERE id = $2 RETURNING status, updated_at",
+		status, id).Scan(&po.Status, &po.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	po.Receipts, err = getGoodsReceipts(tx, "gr.purchase_order_id = $1", id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return po, nil
+}
+
+func getPurchaseOrder(q dbExecutor, id int, forUpdate bool) (*models.PurchaseOrder, error) {
+	query := "SELECT " + purchaseOrderColumns + " FROM purchase_orders AS po JOIN suppliers AS s ON s.id = po.supplier_id WHERE po.id = $1"
+	if forUpdate {
+		query += " FOR UPDATE OF po"
+	}
+
+	var po models.PurchaseOrder
+	err := q.QueryRow(query, id).Scan(&po.ID, &po.SupplierID, &po.SupplierName, &po.OutletID, &po.Status, &po.ExpectedDate,
+		&po.Note, &po.TotalAmount, &po.CreatedBy, &po.CreatedAt, &po.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("%w: purchase order %d", ErrNotFound, id)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := q.Query(`SELECT l.id, l.product_id, p.name, l.quantity, l.received_quantity, l.unit_cost, l.subtotal
+				FROM purchase_order_lines AS l
+				JOIN products AS p ON p.id = l.product_id
+				WHERE l.purchase_order_id = $1
+				ORDER BY l.id`, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	po.Lines = make([]models.PurchaseOrderLine, 0)
+	for rows.Next() {
+		var l models.PurchaseOrderLine
+		if err := rows.Scan(&l.ID, &l.ProductID, &l.ProductName, &l.Quantity, &l.ReceivedQuantity, &l.UnitCost, &l.Subtotal); err != nil {
+			return nil, err
+		}
+		po.Lines = append(po.Lines, l)
+	}
+
+	return &po, rows.Err()
+}
+
+func insertPurchaseOrderLines(tx *sql.Tx, po *models.PurchaseOrder) error {
+	po.TotalAmount = 0
+	for i, line := range po.Lines {
+		var productName string
+		var archivedAt *time.Time
+		err := tx.QueryRow("SELECT name, archived_at FROM products WHERE id = $1", line.ProductID).Scan(&productName, &archivedAt)
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("%w: product id %d", ErrNotFound, line.ProductID)
+		}
+		if err != nil {
+			return err
+		}
+		if archivedAt != nil {
+			return fmt.Errorf("%w: produk %s sudah diarsipkan", ErrValidation, productName)
+		}
+
+		subtotal := line.Quantity * line.UnitCost
+		err = tx.QueryRow(`INSERT INTO purchase_order_lines (purchase_order_id, product_id, quantity, unit_cost, subtotal)
+					VALUES ($1, $2, $3, $4, $5) RETURNING id`,
+			po.ID, line.ProductID, line.Quantity, line.UnitCost, subtotal).Scan(&po.Lines[i].ID)
+		if err != nil {
+			return err
+		}
+
+		po.Lines[i].ProductName = productName
+		po.Lines[i].ReceivedQuantity = 0
+		po.Lines[i].Subtotal = subtotal
+		po.TotalAmount += subtotal
+	}
+
+	_, err := tx.Exec("UPDATE purchase_orders SET total_amount = $1 WHERE id = $2", po.TotalAmount, po.ID)
+	return err
+}
+
+func ensureActiveSupplier(q dbExecutor, supplierID int) error {
+	var archivedAt *time.Time
+	err := q.QueryRow("SELECT archived_at FROM suppliers WHERE id = $1", supplierID).Scan(&archivedAt)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("%w: supplier %d", ErrNotFound, supplierID)
+	}
+	if err != nil {
+		return err
+	}
+	if archivedAt != nil {
+		return fmt.Errorf("%w: supplier sudah diarsipkan", ErrValidation)
+	}
+	return nil
+}
+
+// postGoodsReceipt menyimpan receipt beserta line-nya, menambah stok lewat ledger dan
+// menghitung ulang harga pokok rata-rata tertimbang. Semua line harus sudah punya UnitCost.
+func postGoodsReceipt(tx *sql.Tx, receipt *models.GoodsReceipt) error {
+	receipt.TotalAmount = 0
+	for i, line := range receipt.Lines {
+		receipt.Lines[i].Subtotal = line.Quantity * *line.UnitCost
+		receipt.TotalAmount += receipt.Lines[i].Subtotal
+	}
+
+	err := tx.QueryRow(`INSERT INTO goods_receipts (purchase_order_id, supplier_id, outlet_id, reference, note, total_amount, received_by)
+				VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id, received_at`,
+		receipt.PurchaseOrderID, receipt.SupplierID, receipt.OutletID, receipt.Reference, receipt.Note, receipt.TotalAmount, receipt.ReceivedBy).
+		Scan(&receipt.ID, &receipt.ReceivedAt)
+	if err != nil {
+		return err
+	}
+
+	for i, line := range receipt.Lines {
+		err := tx.QueryRow(`INSERT INTO goods_receipt_lines (goods_receipt_id, product_id, quantity, unit_cost, subtotal)
+					VALUES ($1, $2, $3, $4, $5) RETURNING id`,
+			receipt.ID, line.ProductID, line.Quantity, *line.UnitCost, line.Subtotal).Scan(&receipt.Lines[i].ID)
+		if err != nil {
+			return err
+		}
+
+		if line.BatchNumber != "" || line.ExpiryDate != "" {
+			if line.BatchNumber == "" {
+				receipt.Lines[i].BatchNumber = fmt.Sprintf("GR%d-%d", receipt.ID, receipt.Lines[i].ID)
+			}
+			batchID, err := receiveIntoBatch(tx, receipt.OutletID, line.ProductID, receipt.Lines[i].BatchNumber, line.ExpiryDate, line.Quantity)
+			if err != nil {
+				return err
+			}
+			receipt.Lines[i].BatchID = &batchID
+
+			_, err = tx.Exec("UPDATE goods_receipt_lines SET batch_id = $1 WHERE id = $2", batchID, receipt.Lines[i].ID)
+			if err != nil {
+				return err
+			}
+		}
+
+		//harga pokok dihitung dari stok sebelum barang masuk, jadi kunci dan update dulu sebelum stok berubah
+		var stock, costPrice int
+		err = tx.QueryRow("SELECT name, stock, cost_price FROM products WHERE id = $1 FOR UPDATE", line.ProductID).
+			Scan(&receipt.Lines[i].ProductName, &stock, &costPrice)
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("%w: product id %d", ErrNotFound, line.ProductID)
+		}
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Exec("UPDATE products SET cost_price = $1 WHERE id = $2",
+			weightedAverageCost(stock, costPrice, line.Quantity, *line.UnitCost), line.ProductID)
+		if err != nil {
+			return err
+		}
+
+		_, err = applyStockChange(tx, stockChange{
+			ProductID:     line.ProductID,
+			OutletID:      receipt.OutletID,
+			Type:          models.StockMovementReceipt,
+			Quantity:      line.Quantity,
+			ReferenceType: "goods_receipt",
+			ReferenceID:   &receipt.ID,
+			Note:          receipt.Reference,
+			CreatedBy:     receipt.ReceivedBy,
+			UnitCost:      line.UnitCost,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// weightedAverageCost menghitung harga pokok baru, dibulatkan ke rupiah terdekat.
+// Stok negatif atau nol dianggap tidak punya nilai, jadi harga pokok langsung ikut harga beli terakhir.
+func weightedAverageCost(stock int, costPrice int, quantity int, unitCost int) int {
+	if stock <= 0 {
+		return unitCost
+	}
+	total := int64(stock)*int64(costPrice) + int64(quantity)*int64(unitCost)
+	units := int64(stock + quantity)
+	return int((total + units/2) / units)
+}
+
+func getGoodsReceipts(q dbExecutor, condition string, args ...interface{}) ([]models.GoodsReceipt, error) {
+	rows, err := q.Query(`SELECT gr.id, gr.purchase_order_id, gr.supplier_id, s.name, gr.outlet_id, gr.reference, gr.note, gr.total_amount, gr.received_by, gr.received_at,
+					l.id, l.product_id, p.name, l.quantity, l.unit_cost, l.subtotal,
+					l.batch_id, COALESCE(b.batch_number, ''), COALESCE(to_char(b.expiry_date, 'YYYY-MM-DD'), '')
+				FROM goods_receipts AS gr
+				JOIN suppliers AS s ON s.id = gr.supplier_id
+				JOIN goods_receipt_lines AS l ON l.goods_receipt_id = gr.id
+				JOIN products AS p ON p.id = l.product_id
+				LEFT JOIN product_batches AS b ON b.id = l.batch_id
+				WHERE `+condition+`
+				ORDER BY gr.received_at DESC, gr.id DESC, l.id`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	receipts := make([]models.GoodsReceipt, 0)
+	for rows.Next() {
+		var gr models.GoodsReceipt
+		var l models.GoodsReceiptLine
+		var unitCost int
+		err := rows.Scan(&gr.ID, &gr.PurchaseOrderID, &gr.SupplierID, &gr.SupplierName, &gr.OutletID, &gr.Reference, &gr.Note, &gr.TotalAmount, &gr.ReceivedBy, &gr.ReceivedAt,
+			&l.ID, &l.ProductID, &l.ProductName, &l.Quantity, &unitCost, &l.Subtotal,
+			&l.BatchID, &l.BatchNumber, &l.ExpiryDate)
+		if err != nil {
+			return nil, err
+		}
+		l.UnitCost = &unitCost
+
+		//baris sudah terurut per receipt, cukup bandingkan dengan receipt terakhir
+		if len(receipts) == 0 || receipts[len(receipts)-1].ID != gr.ID {
+			receipts = append(receipts, gr)
+		}
+		last := &receipts[len(receipts)-1]
+		last.Lines = append(last.Lines, l)
+	}
+
+	return receipts, rows.Err()
+}
@@ -0,0 +1,130 @@
+package repositories
+
+import (
+	"fmt"
+	"kasir-api/models"
+	"math"
+	"sort"
+	"time"
+)
+
+const lowStockColumns = `p.id, COALESCE(p.sku, ''), p.name, p.category_id, c.name, p.stock, p.reorder_point, p.reorder_quantity`
+
+// GetLowStockProducts mengembalikan produk aktif yang stoknya sudah di reorder point atau di bawahnya.
+func (repo *StockRepository) GetLowStockProducts(categoryID *int) ([]models.LowStockProduct, error) {
+	query := "SELECT " + lowStockColumns + `
+				FROM products AS p
+				JOIN categories AS c ON c.id = p.category_id
+				WHERE p.archived_at IS NULL AND p.reorder_point > 0 AND p.stock <= p.reorder_point`
+	args := []interface{}{}
+	if categoryID != nil {
+		query += " AND p.category_id = $1"
+		args = append(args, *categoryID)
+	}
+	query += " ORDER BY p.stock - p.reorder_point, p.name, p.id"
+
+	rows, err := repo.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	products := make([]models.LowStockProduct, 0)
+	for rows.Next() {
+		var p models.LowStockProduct
+		err := rows.Scan(&p.ProductID, &p.SKU, &p.Name, &p.CategoryID, &p.CategoryName, &p.Stock, &p.ReorderPoint, &p.ReorderQuantity)
+		if err != nil {
+			return nil, err
+		}
+		products = append(products, p)
+	}
+
+	return products, rows.Err()
+}
+
+// GetReorderSuggestions menghitung rata-rata penjualan harian selama windowDays terakhir, lalu menyarankan
+// jumlah pesanan supaya stok cukup untuk coverDays ke depan. Barang yang sudah dipesan (PO belum diterima)
+// ikut diperhitungkan.
+func (repo *StockRepository) GetReorderSuggestions(windowDays int, coverDays int, categoryID *int) (*models.ReorderSuggestionReport, error) {
+	query := "SELECT " + lowStockColumns + `, COALESCE(sold.quantity, 0), COALESCE(ordered.quantity, 0)
+				FROM products AS p
+				JOIN categories AS c ON c.id = p.category_id
+				LEFT JOIN (
+					SELECT td.product_id, SUM(td.quantity) AS quantity
+					FROM transaction_details AS td
+					JOIN transactions AS t ON t.id = td.transaction_id
+					WHERE t.created_at >= NOW() - make_interval(days => $1)
+					GROUP BY td.product_id
+				) AS sold ON sold.product_id = p.id
+				LEFT JOIN (
+					SELECT l.product_id, SUM(l.quantity - l.received_quantity) AS quantity
+					FROM purchase_order_lines AS l
+					JOIN purchase_orders AS po ON po.id = l.purchase_order_id
+					WHERE po.status IN ($2, $3)
+					GROUP BY l.product_id
+				) AS ordered ON ordered.product_id = p.id
+				WHERE p.archived_at IS NULL`
+	args := []interface{}{windowDays, models.PurchaseOrderSent, models.PurchaseOrderPartiallyReceived}
+	if categoryID != nil {
+		args = append(args, *categoryID)
+		query += fmt.Sprintf(" AND p.category_id = $%d", len(args))
+	}
+
+	rows, err := repo.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	report := &models.ReorderSuggestionReport{
+		WindowDays:  windowDays,
+		CoverDays:   coverDays,
+		GeneratedAt: time.Now(),
+		Items:       make([]models.ReorderSuggestion, 0),
+	}
+	for rows.Next() {
+		var s models.ReorderSuggestion
+		err := rows.Scan(&s.ProductID, &s.SKU, &s.Name, &s.CategoryID, &s.CategoryName, &s.Stock, &s.ReorderPoint, &s.ReorderQuantity,
+			&s.SoldQuantity, &s.OnOrderQuantity)
+		if err != nil {
+			return nil, err
+		}
+
+		s.AvgDailySales = float64(s.SoldQuantity) / float64(windowDays)
+		if s.AvgDailySales > 0 {
+			daysLeft := math.Max(float64(s.Stock), 0) / s.AvgDailySales
+			s.DaysOfStockLeft = &daysLeft
+		}
+
+		belowReorderPoint := s.ReorderPoint > 0 && s.Stock <= s.ReorderPoint
+		runningOut := s.DaysOfStockLeft != nil && *s.DaysOfStockLeft < float64(coverDays)
+		if !belowReorderPoint && !runningOut {
+			continue
+		}
+
+		needed := int(math.Ceil(s.AvgDailySales*float64(coverDays))) - s.Stock - s.OnOrderQuantity
+		if needed < s.ReorderQuantity && s.OnOrderQuantity == 0 {
+			needed = s.ReorderQuantity
+		}
+		if needed < 0 {
+			needed = 0
+		}
+		s.SuggestedQuantity = needed
+
+		report.Items = append(report.Items, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	//yang paling cepat habis di atas, produk tanpa penjualan di bawah
+	sort.SliceStable(report.Items, func(i, j int) bool {
+		a, b := report.Items[i].DaysOfStockLeft, report.Items[j].DaysOfStockLeft
+		if a == nil || b == nil {
+			return a != nil && b == nil
+		}
+		return *a < *b
+	})
+
+	return report, nil
+}
@@ -0,0 +1,185 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+	"kasir-api/models"
+	"time"
+)
+
+type ReportJobRepository struct {
+	db *sql.DB
+}
+
+func NewReportJobRepository(db *sql.DB) *ReportJobRepository {
+	return &ReportJobRepository{db: db}
+}
+
+const reportJobColumns = `id, name, cron, COALESCE(timezone, ''), report_type, date_range, format, outlet_id, compare,
+	channel, target, active, next_run_at, last_run_at, created_by, created_at`
+
+func scanReportJob(row rowScanner, j *models.ReportJob) error {
+	return row.Scan(&j.ID, &j.Name, &j.Cron, &j.Timezone, &j.ReportType, &j.Range, &j.Format, &j.OutletID, &j.Compare,
+		&j.Channel, &j.Target, &j.Active, &j.NextRunAt, &j.LastRunAt, &j.CreatedBy, &j.CreatedAt)
+}
+
+func (repo *ReportJobRepository) GetReportJobs() ([]models.ReportJob, error) {
+	return repo.queryReportJobs("SELECT " + reportJobColumns + " FROM report_jobs ORDER BY name, id")
+}
+
+// GetDueReportJobs mengembalikan job aktif yang jadwalnya sudah lewat.
+func (repo *ReportJobRepository) GetDueReportJobs(now time.Time) ([]models.ReportJob, error) {
+	return repo.queryReportJobs("SELECT "+reportJobColumns+` FROM report_jobs
+		WHERE active AND next_run_at <= $1 ORDER BY next_run_at, id`, now)
+}
+
+func (repo *ReportJobRepository) queryReportJobs(query string, args ...interface{}) ([]models.ReportJob, error) {
+	rows, err := repo.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	jobs := make([]models.ReportJob, 0)
+	for rows.Next() {
+		var j models.ReportJob
+		if err := scanReportJob(rows, &j); err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, j)
+	}
+	return jobs, rows.Err()
+}
+
+func (repo *ReportJobRepository) GetReportJobByID(id int) (*models.ReportJob, error) {
+	var j models.ReportJob
+	err := scanReportJob(repo.db.QueryRow("SELECT "+reportJobColumns+" FROM report_jobs WHERE id = $1", id), &j)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("%w: report job %d", ErrNotFound, id)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &j, nil
+}
+
+func (repo *ReportJobRepository) CreateReportJob(job *models.ReportJob) error {
+	if job.OutletID != nil {
+		if err := ensureOutletExists(repo.db, *job.OutletID); err != nil {
+			return err
+		}
+	}
+
+	query := `INSERT INTO report_jobs (name, cron, timezone, report_type, date_range, format, outlet_id, compare,
+					channel, target, active, next_run_at, created_by)
+				VALUES ($1, $2, NULLIF($3, ''), $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+				RETURNING id, created_at`
+	return repo.db.QueryRow(query, job.Name, job.Cron, job.Timezone, job.ReportType, job.Range, job.Format, job.OutletID,
+		job.Compare, job.Channel, job.Target, job.Active, job.NextRunAt, job.CreatedBy).Scan(&job.ID, &job.CreatedAt)
+}
+
+func (repo *ReportJobRepository) UpdateReportJob(job *models.ReportJob) error {
+	if job.OutletID != nil {
+		if err := ensureOutletExists(repo.db, *job.OutletID); err != nil {
+			return err
+		}
+	}
+
+	query := `UPDATE report_jobs SET name = $1, cron = $2, timezone = NULLIF($3, ''), report_type = $4, date_range = $5,
+					format = $6, outlet_id = $7, compare = $8, channel = $9, target = $10, active = $11, next_run_at = $12
+				WHERE id = $13 RETURNING last_run_at, created_by, created_at`
+	err := repo.db.QueryRow(query, job.Name, job.Cron, job.Timezone, job.ReportType, job.Range, job.Format, job.OutletID,
+		job.Compare, job.Channel, job.Target, job.Active, job.NextRunAt, job.ID).Scan(&job.LastRunAt, &job.CreatedBy, &job.CreatedAt)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("%w: report job %d", ErrNotFound, job.ID)
+	}
+	return err
+}
+
+// DeleteReportJob ikut menghapus riwayat run-nya (ON DELETE CASCADE). File hasilnya dihapus oleh service.
+func (repo *ReportJobRepository) DeleteReportJob(id int) error {
+	result, err := repo.db.Exec("DELETE FROM report_jobs WHERE id = $1", id)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return fmt.Errorf("%w: report job %d", ErrNotFound, id)
+	}
+	return nil
+}
+
+// ClaimReportJob memajukan jadwal job hanya kalau next_run_at masih sama dengan yang dibaca scheduler,
+// supaya job tidak jalan dua kali kalau ada lebih dari satu instance server. false berarti sudah diambil instance lain.
+func (repo *ReportJobRepository) ClaimReportJob(id int, scheduledAt time.Time, nextRunAt *time.Time) (bool, error) {
+	result, err := repo.db.Exec(`UPDATE report_jobs SET next_run_at = $1, last_run_at = NOW()
+		WHERE id = $2 AND active AND next_run_at = $3`, nextRunAt, id, scheduledAt)
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
+func (repo *ReportJobRepository) CreateReportRun(run *models.ReportRun) error {
+	query := `INSERT INTO report_runs (report_job_id, period_from, period_to, status)
+				VALUES ($1, $2, $3, $4) RETURNING id, started_at`
+	return repo.db.QueryRow(query, run.ReportJobID, run.PeriodFrom, run.PeriodTo, run.Status).Scan(&run.ID, &run.StartedAt)
+}
+
+func (repo *ReportJobRepository) FinishReportRun(run *models.ReportRun) error {
+	query := `UPDATE report_runs SET status = $1, file_name = $2, file_path = $3, file_size = $4, delivered = $5,
+					error = $6, finished_at = NOW()
+				WHERE id = $7 RETURNING finished_at`
+	return repo.db.QueryRow(query, run.Status, run.FileName, run.FilePath, run.FileSize, run.Delivered, run.Error, run.ID).
+		Scan(&run.FinishedAt)
+}
+
+const reportRunColumns = `id, report_job_id, to_char(period_from, 'YYYY-MM-DD'), to_char(period_to, 'YYYY-MM-DD'), status,
+	file_name, file_path, file_size, delivered, error, started_at, finished_at`
+
+func scanReportRun(row rowScanner, r *models.ReportRun) error {
+	return row.Scan(&r.ID, &r.ReportJobID, &r.PeriodFrom, &r.PeriodTo, &r.Status, &r.FileName, &r.FilePath, &r.FileSize,
+		&r.Delivered, &r.Error, &r.StartedAt, &r.FinishedAt)
+}
+
+func (repo *ReportJobRepository) GetReportRuns(jobID int, limit int) ([]models.ReportRun, error) {
+	if _, err := repo.GetReportJobByID(jobID); err != nil {
+		return nil, err
+	}
+
+	rows, err := repo.db.Query("SELECT "+reportRunColumns+` FROM report_runs
+		WHERE report_job_id = $1 ORDER BY started_at DESC, id DESC LIMIT $2`, jobID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	runs := make([]models.ReportRun, 0)
+	for rows.Next() {
+		var r models.ReportRun
+		if err := scanReportRun(rows, &r); err != nil {
+			return nil, err
+		}
+		runs = append(runs, r)
+	}
+	return runs, rows.Err()
+}
+
+func (repo *ReportJobRepository) GetReportRunByID(id int) (*models.ReportRun, error) {
+	var r models.ReportRun
+	err := scanReportRun(repo.db.QueryRow("SELECT "+reportRunColumns+" FROM report_runs WHERE id = $1", id), &r)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("%w: report run %d", ErrNotFound, id)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
@@ -0,0 +1,160 @@
+package repositories
+
+import (
+	"fmt"
+	"kasir-api/models"
+	"math"
+	"time"
+)
+
+// GetSalesTimeseries mengelompokkan penjualan di [start, end) per granularity menurut jam dinding di timezone.
+// Bucket dibuat dengan generate_series supaya jam/hari tanpa transaksi tetap muncul dengan nilai 0.
+func (repo *TransactionRepository) GetSalesTimeseries(start time.Time, end time.Time, granularity string, timezone string, outletID *int) ([]models.SalesBucket, error) {
+	args := []interface{}{start, end, granularity, timezone}
+	outletFilter := ""
+	if outletID != nil {
+		if err := ensureOutletExists(repo.db, *outletID); err != nil {
+			return nil, err
+		}
+		args = append(args, *outletID)
+		outletFilter = " AND t.outlet_id = $5"
+	}
+
+	//bucket dihitung dalam waktu lokal ($4) lalu dikembalikan sebagai timestamptz
+	query := `WITH buckets AS (
+					SELECT generate_series(
+						date_trunc($3::text, $1::timestamptz AT TIME ZONE $4::text),
+						($2::timestamptz AT TIME ZONE $4::text) - interval '1 microsecond',
+						('1 ' || $3::text)::interval) AS bucket
+				), sales AS (
+					SELECT date_trunc($3::text, t.created_at::timestamptz AT TIME ZONE $4::text) AS bucket,
+						SUM(t.total_amount) AS revenue, COUNT(*) AS transactions
+					FROM transactions AS t
+					WHERE t.created_at >= $1::timestamptz AND t.created_at < $2::timestamptz` + outletFilter + `
+					GROUP BY 1
+				), items AS (
+					SELECT date_trunc($3::text, t.created_at::timestamptz AT TIME ZONE $4::text) AS bucket, SUM(td.quantity) AS items
+					FROM transaction_details AS td
+					JOIN transactions AS t ON t.id = td.transaction_id
+					WHERE t.created_at >= $1::timestamptz AND t.created_at < $2::timestamptz` + outletFilter + `
+					GROUP BY 1
+				)
+				SELECT b.bucket AT TIME ZONE $4::text, (b.bucket + ('1 ' || $3::text)::interval) AT TIME ZONE $4::text,
+					COALESCE(s.revenue, 0), COALESCE(s.transactions, 0), COALESCE(i.items, 0)
+				FROM buckets AS b
+				LEFT JOIN sales AS s ON s.bucket = b.bucket
+				LEFT JOIN items AS i ON i.bucket = b.bucket
+				ORDER BY b.bucket`
+
+	rows, err := repo.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	buckets := make([]models.SalesBucket, 0)
+	for rows.Next() {
+		var b models.SalesBucket
+		if err := rows.Scan(&b.Start, &b.End, &b.Revenue, &b.TransactionCount, &b.ItemsSold); err != nil {
+			return nil, err
+		}
+		if b.TransactionCount > 0 {
+			b.AverageBasket = b.Revenue / b.TransactionCount
+		}
+		buckets = append(buckets, b)
+	}
+
+	return buckets, rows.Err()
+}
+
+// kolom urutan ranking, nilai by dan order sudah divalidasi di service
+var rankingColumns = map[string]string{
+	models.RankByQuantity: "quantity",
+	models.RankByRevenue:  "revenue",
+	models.RankByProfit:   "profit",
+}
+
+// GetProductRankings meranking produk di [start, end). Produk aktif yang tidak terjual ikut dihitung
+// dengan nilai 0 supaya muncul sebagai slow mover. Klasifikasi ABC selalu berdasarkan revenue,
+// apa pun kolom urutannya, dan dihitung dari semua produk sebelum limit diterapkan.
+func (repo *TransactionRepository) GetProductRankings(start time.Time, end time.Time, by string, ascending bool, limit int, categoryID *int, outletID *int) (int, []models.ProductRanking, error) {
+	args := []interface{}{start, end}
+	salesFilter := ""
+	if outletID != nil {
+		if err := ensureOutletExists(repo.db, *outletID); err != nil {
+			return 0, nil, err
+		}
+		args = append(args, *outletID)
+		salesFilter = fmt.Sprintf(" AND t.outlet_id = $%d", len(args))
+	}
+	productFilter := ""
+	if categoryID != nil {
+		args = append(args, *categoryID)
+		productFilter = fmt.Sprintf(" AND p.category_id = $%d", len(args))
+	}
+	args = append(args, limit)
+
+	column := rankingColumns[by]
+	direction := "DESC"
+	if ascending {
+		direction = "ASC"
+	}
+
+	query := `WITH sales AS (
+					SELECT td.product_id, SUM(td.quantity) AS quantity, SUM(td.subtotal) AS revenue,
+						SUM(td.subtotal - td.cogs_fifo) AS profit
+					FROM transaction_details AS td
+					JOIN transactions AS t ON t.id = td.transaction_id
+					WHERE t.created_at >= $1::timestamptz AND t.created_at < $2::timestamptz` + salesFilter + `
+					GROUP BY td.product_id
+				), products_sales AS (
+					SELECT p.id, COALESCE(p.sku, '') AS sku, p.name, p.category_id, c.name AS category_name,
+						COALESCE(s.quantity, 0) AS quantity, COALESCE(s.revenue, 0) AS revenue, COALESCE(s.profit, 0) AS profit
+					FROM products AS p
+					JOIN categories AS c ON c.id = p.category_id
+					LEFT JOIN sales AS s ON s.product_id = p.id
+					WHERE (p.archived_at IS NULL OR s.product_id IS NOT NULL)` + productFilter + `
+				), classified AS (
+					SELECT *,
+						SUM(revenue) OVER () AS total_revenue,
+						SUM(revenue) OVER (ORDER BY revenue DESC, name, id) - revenue AS revenue_before
+					FROM products_sales
+				)
+				SELECT RANK() OVER (ORDER BY ` + column + ` ` + direction + `), id, sku, name, category_id, category_name,
+					quantity, revenue, profit, total_revenue, revenue_before
+				FROM classified
+				ORDER BY ` + column + ` ` + direction + `, name, id
+				LIMIT $` + fmt.Sprint(len(args))
+
+	rows, err := repo.db.Query(query, args...)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer rows.Close()
+
+	totalRevenue := 0
+	rankings := make([]models.ProductRanking, 0)
+	for rows.Next() {
+		var r models.ProductRanking
+		var revenueBefore int
+		err := rows.Scan(&r.Rank, &r.ProductID, &r.SKU, &r.ProductName, &r.CategoryID, &r.CategoryName,
+			&r.Quantity, &r.Revenue, &r.Profit, &totalRevenue, &revenueBefore)
+		if err != nil {
+			return 0, nil, err
+		}
+
+		r.ABCClass = "C"
+		if totalRevenue > 0 && r.Revenue > 0 {
+			r.RevenueContribution = math.Round(float64(r.Revenue)/float64(totalRevenue)*10000) / 100
+			switch before := float64(revenueBefore) / float64(totalRevenue); {
+			case before < 0.8:
+				r.ABCClass = "A"
+			case before < 0.95:
+				r.ABCClass = "B"
+			}
+		}
+		rankings = append(rankings, r)
+	}
+
+	return totalRevenue, rankings, rows.Err()
+}
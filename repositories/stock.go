@@ -0,0 +1,211 @@
+package repositories
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"kasir-api/models"
+)
+
+type StockRepository struct {
+	db *sql.DB
+}
+
+func NewStockRepository(db *sql.DB) *StockRepository {
+	return &StockRepository{db: db}
+}
+
+func (repo *StockRepository) GetStockMovements(productID int, limit int, offset int) (*models.StockMovementPage, error) {
+	page := &models.StockMovementPage{
+		Data: make([]*models.StockMovement, 0),
+		Meta: models.PageMeta{Limit: limit, Offset: offset},
+	}
+
+	var exists bool
+	err := repo.db.QueryRow("SELECT EXISTS (SELECT 1 FROM products WHERE id = $1)", productID).Scan(&exists)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.New("Produk tidak ditemukan")
+	}
+
+	err = repo.db.QueryRow("SELECT COUNT(*) FROM stock_movements WHERE product_id = $1", productID).Scan(&page.Meta.Total)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `SELECT id, product_id, outlet_id, movement_type, quantity, stock_after, outlet_stock_after, reference_type, reference_id, note, created_by, created_at
+				FROM stock_movements
+				WHERE product_id = $1
+				ORDER BY created_at DESC, id DESC
+				LIMIT $2 OFFSET $3`
+	rows, err := repo.db.Query(query, productID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var m models.StockMovement
+		err := rows.Scan(&m.ID, &m.ProductID, &m.OutletID, &m.Type, &m.Quantity, &m.StockAfter, &m.OutletStockAfter, &m.ReferenceType, &m.ReferenceID,
+			&m.Note, &m.CreatedBy, &m.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		page.Data = append(page.Data, &m)
+	}
+
+	return page, rows.Err()
+}
+
+// CheckConsistency menghitung ulang stok dari ledger dan dari jumlah stok semua outlet, lalu membandingkannya
+// dengan products.stock.
+func (repo *StockRepository) CheckConsistency(productID *int) (*models.StockConsistencyReport, error) {
+	query := `SELECT p.id, p.name, p.stock, COALESCE(SUM(sm.quantity), 0) AS ledger_stock,
+					(SELECT COALESCE(SUM(os.stock), 0) FROM outlet_stocks AS os WHERE os.product_id = p.id) AS outlet_stock
+				FROM products AS p
+				LEFT JOIN stock_movements AS sm ON sm.product_id = p.id`
+	args := []interface{}{}
+	if productID != nil {
+		query += " WHERE p.id = $1"
+		args = append(args, *productID)
+	}
+	query += " GROUP BY p.id, p.name, p.stock ORDER BY p.id"
+
+	rows, err := repo.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	report := &models.StockConsistencyReport{Issues: make([]models.StockConsistencyIssue, 0)}
+	for rows.Next() {
+		var issue models.StockConsistencyIssue
+		err := rows.Scan(&issue.ProductID, &issue.ProductName, &issue.Stock, &issue.LedgerStock, &issue.OutletStock)
+		if err != nil {
+			return nil, err
+		}
+		report.CheckedProducts++
+
+		if issue.Stock != issue.LedgerStock || issue.Stock != issue.OutletStock {
+			issue.Difference = issue.Stock - issue.LedgerStock
+			report.Issues = append(report.Issues, issue)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if productID != nil && report.CheckedProducts == 0 {
+		return nil, errors.New("Produk tidak ditemukan")
+	}
+	report.Consistent = len(report.Issues) == 0
+
+	return report, nil
+}
+
+// stockChange adalah satu perubahan stok di satu outlet. Quantity bertanda: positif menambah, negatif mengurangi.
+type stockChange struct {
+	ProductID     int
+	OutletID      int
+	Type          string
+	Quantity      int
+	ReferenceType string
+	ReferenceID   *int
+	Note          string
+	CreatedBy     string
+	// harga per unit untuk lapisan FIFO barang masuk, nil berarti pakai cost_price produk
+	UnitCost *int
+	// lapisan FIFO barang masuk kalau harganya tidak seragam (transfer), jumlahnya harus sama dengan Quantity
+	CostLayers []costLayer
+}
+
+// stockCost adalah harga pokok barang keluar dari satu perubahan stok.
+type stockCost struct {
+	FIFO    int
+	Average int
+}
+
+// applyStockChange mengubah stok outlet, products.stock (total semua outlet) dan menulis baris ledger-nya sekaligus.
+// Semua jalur yang mengubah stok wajib lewat sini, di dalam transaksi yang sama dengan perubahan bisnisnya.
+// Yang dikembalikan adalah stok di outlet tersebut.
+func applyStockChange(q dbExecutor, c stockChange) (int, error) {
+	stockAfter, _, err := applyStockChangeWithCost(q, c)
+	return stockAfter, err
+}
+
+// applyStockChangeWithCost sama dengan applyStockChange, ditambah mencatat lapisan harga pokok dan
+// mengembalikan HPP barang keluar, dipakai checkout untuk mencatat HPP per baris.
+func applyStockChangeWithCost(q dbExecutor, c stockChange) (int, stockCost, error) {
+	if c.OutletID == 0 {
+		return 0, stockCost{}, fmt.Errorf("perubahan stok product id %d tanpa outlet", c.ProductID)
+	}
+
+	if c.Quantity == 0 {
+		var stock int
+		err := q.QueryRow(`SELECT COALESCE(os.stock, 0) FROM products AS p
+					LEFT JOIN outlet_stocks AS os ON os.product_id = p.id AND os.outlet_id = $2
+					WHERE p.id = $1`, c.ProductID, c.OutletID).Scan(&stock)
+		if err == sql.ErrNoRows {
+			return 0, stockCost{}, fmt.Errorf("product id %d not found", c.ProductID)
+		}
+		return stock, stockCost{}, err
+	}
+
+	var totalStock, averageCost int
+	err := q.QueryRow("UPDATE products SET stock = stock + $1 WHERE id = $2 RETURNING stock, cost_price", c.Quantity, c.ProductID).
+		Scan(&totalStock, &averageCost)
+	if err == sql.ErrNoRows {
+		return 0, stockCost{}, fmt.Errorf("product id %d not found", c.ProductID)
+	}
+	if err != nil {
+		return 0, stockCost{}, err
+	}
+
+	var stockAfter int
+	err = q.QueryRow(`INSERT INTO outlet_stocks (outlet_id, product_id, stock) VALUES ($1, $2, $3)
+				ON CONFLICT (outlet_id, product_id) DO UPDATE SET stock = outlet_stocks.stock + EXCLUDED.stock
+				RETURNING stock`, c.OutletID, c.ProductID, c.Quantity).Scan(&stockAfter)
+	if err != nil {
+		return 0, stockCost{}, err
+	}
+
+	var movementID int64
+	err = q.QueryRow(`INSERT INTO stock_movements (product_id, outlet_id, movement_type, quantity, stock_after, outlet_stock_after,
+					reference_type, reference_id, note, created_by, average_cost)
+				VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11) RETURNING id`,
+		c.ProductID, c.OutletID, c.Type, c.Quantity, totalStock, stockAfter,
+		c.ReferenceType, c.ReferenceID, c.Note, c.CreatedBy, averageCost).Scan(&movementID)
+	if err != nil {
+		return 0, stockCost{}, err
+	}
+
+	//lapisan harga pokok dihitung per produk, bukan per outlet, jadi pakai stok total
+	if c.Quantity > 0 {
+		layers := c.CostLayers
+		if len(layers) == 0 {
+			unitCost := averageCost
+			if c.UnitCost != nil {
+				unitCost = *c.UnitCost
+			}
+			layers = []costLayer{{UnitCost: unitCost, Quantity: c.Quantity}}
+		}
+
+		stock := totalStock - c.Quantity
+		for _, l := range layers {
+			stock += l.Quantity
+			if err := addCostLayer(q, c.ProductID, movementID, l.UnitCost, l.Quantity, stock); err != nil {
+				return 0, stockCost{}, err
+			}
+		}
+		return stockAfter, stockCost{}, nil
+	}
+
+	cost, err := consumeCostLayers(q, c.ProductID, movementID, -c.Quantity, averageCost)
+	if err != nil {
+		return 0, stockCost{}, err
+	}
+
+	return stockAfter, cost, nil
+}
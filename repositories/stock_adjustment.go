@@ -0,0 +1,250 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+	"kasir-api/models"
+	"strings"
+)
+
+// CreateStockAdjustment menyimpan adjustment beserta line-nya. Kalau apply true, stok langsung diubah
+// dalam transaksi yang sama, kalau tidak statusnya pending menunggu approval.
+func (repo *StockRepository) CreateStockAdjustment(adj *models.StockAdjustment, apply bool) error {
+	tx, err := repo.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	adj.OutletID, err = resolveOutlet(tx, adj.OutletID)
+	if err != nil {
+		return err
+	}
+
+	adj.Status = models.AdjustmentStatusPending
+	err = tx.QueryRow("INSERT INTO stock_adjustments (outlet_id, status, note, created_by) VALUES ($1, $2, $3, $4) RETURNING id, created_at",
+		adj.OutletID, adj.Status, adj.Note, adj.CreatedBy).Scan(&adj.ID, &adj.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	for i, line := range adj.Lines {
+		var productName string
+		err := tx.QueryRow("SELECT name FROM products WHERE id = $1", line.ProductID).Scan(&productName)
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("%w: product id %d", ErrNotFound, line.ProductID)
+		}
+		if err != nil {
+			return err
+		}
+		adj.Lines[i].ProductName = productName
+
+		err = tx.QueryRow(`INSERT INTO stock_adjustment_lines (adjustment_id, product_id, batch_id, quantity, reason, note)
+					VALUES ($1, $2, $3, $4, $5, $6) RETURNING id`,
+			adj.ID, line.ProductID, line.BatchID, line.Quantity, line.Reason, line.Note).Scan(&adj.Lines[i].ID)
+		if err != nil {
+			return err
+		}
+	}
+
+	if apply {
+		err = applyStockAdjustment(tx, adj, adj.CreatedBy, "")
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (repo *StockRepository) GetStockAdjustments(status string) ([]*models.StockAdjustment, error) {
+	query := `SELECT id, outlet_id, status, note, created_by, created_at, reviewed_by, reviewed_at, review_note
+				FROM stock_adjustments`
+	args := []interface{}{}
+	if status != "" {
+		query += " WHERE status = $1"
+		args = append(args, status)
+	}
+	query += " ORDER BY created_at DESC, id DESC"
+
+	rows, err := repo.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	adjustments := make([]*models.StockAdjustment, 0)
+	byID := make(map[int]*models.StockAdjustment)
+	ids := make([]interface{}, 0)
+	for rows.Next() {
+		var a models.StockAdjustment
+		err := rows.Scan(&a.ID, &a.OutletID, &a.Status, &a.Note, &a.CreatedBy, &a.CreatedAt, &a.ReviewedBy, &a.ReviewedAt, &a.ReviewNote)
+		if err != nil {
+			return nil, err
+		}
+		a.Lines = make([]models.StockAdjustmentLine, 0)
+		adjustments = append(adjustments, &a)
+		byID[a.ID] = &a
+		ids = append(ids, a.ID)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(ids) == 0 {
+		return adjustments, nil
+	}
+
+	placeholders := make([]string, len(ids))
+	for i := range ids {
+		placeholders[i] = fmt.Sprintf("$%d", i+1)
+	}
+	lineRows, err := repo.db.Query(`SELECT l.adjustment_id, l.id, l.product_id, p.name, l.batch_id, l.quantity, l.reason, l.note
+				FROM stock_adjustment_lines AS l
+				JOIN products AS p ON p.id = l.product_id
+				WHERE l.adjustment_id IN (`+strings.Join(placeholders, ", ")+`)
+				ORDER BY l.id`, ids...)
+	if err != nil {
+		return nil, err
+	}
+	defer lineRows.Close()
+
+	for lineRows.Next() {
+		var adjustmentID int
+		var l models.StockAdjustmentLine
+		err := lineRows.Scan(&adjustmentID, &l.ID, &l.ProductID, &l.ProductName, &l.BatchID, &l.Quantity, &l.Reason, &l.Note)
+		if err != nil {
+			return nil, err
+		}
+		byID[adjustmentID].Lines = append(byID[adjustmentID].Lines, l)
+	}
+
+	return adjustments, lineRows.Err()
+}
+
+func (repo *StockRepository) GetStockAdjustmentByID(id int) (*models.StockAdjustment, error) {
+	return getStockAdjustment(repo.db, id, false)
+}
+
+// ReviewStockAdjustment meng-approve (stok langsung diubah) atau me-reject adjustment yang masih pending.
+func (repo *StockRepository) ReviewStockAdjustment(id int, approve bool, reviewedBy string, note string) (*models.StockAdjustment, error) {
+	tx, err := repo.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	adj, err := getStockAdjustment(tx, id, true)
+	if err != nil {
+		return nil, err
+	}
+	if adj.Status != models.AdjustmentStatusPending {
+		return nil, fmt.Errorf("%w: adjustment %d sudah %s", ErrInvalidStatus, id, adj.Status)
+	}
+	if adj.CreatedBy == reviewedBy {
+		return nil, fmt.Errorf("%w: adjustment tidak boleh di-review oleh pembuatnya sendiri", ErrValidation)
+	}
+
+	if approve {
+		err = applyStockAdjustment(tx, adj, reviewedBy, note)
+	} else {
+		adj.Status = models.AdjustmentStatusRejected
+		err = markAdjustmentReviewed(tx, adj, reviewedBy, note)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return adj, nil
+}
+
+func getStockAdjustment(q dbExecutor, id int, forUpdate bool) (*models.StockAdjustment, error) {
+	query := `SELECT id, outlet_id, status, note, created_by, created_at, reviewed_by, reviewed_at, review_note
+				FROM stock_adjustments WHERE id = $1`
+	if forUpdate {
+		query += " FOR UPDATE"
+	}
+
+	var a models.StockAdjustment
+	err := q.QueryRow(query, id).Scan(&a.ID, &a.OutletID, &a.Status, &a.Note, &a.CreatedBy, &a.CreatedAt, &a.ReviewedBy, &a.ReviewedAt, &a.ReviewNote)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("%w: stock adjustment %d", ErrNotFound, id)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := q.Query(`SELECT l.id, l.product_id, p.name, l.batch_id, l.quantity, l.reason, l.note
+				FROM stock_adjustment_lines AS l
+				JOIN products AS p ON p.id = l.product_id
+				WHERE l.adjustment_id = $1
+				ORDER BY l.id`, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	a.Lines = make([]models.StockAdjustmentLine, 0)
+	for rows.Next() {
+		var l models.StockAdjustmentLine
+		if err := rows.Scan(&l.ID, &l.ProductID, &l.ProductName, &l.BatchID, &l.Quantity, &l.Reason, &l.Note); err != nil {
+			return nil, err
+		}
+		a.Lines = append(a.Lines, l)
+	}
+
+	return &a, rows.Err()
+}
+
+// applyStockAdjustment menerapkan semua line ke stok lewat ledger. Stok tidak boleh jadi negatif.
+func applyStockAdjustment(tx *sql.Tx, adj *models.StockAdjustment, reviewedBy string, reviewNote string) error {
+	for _, line := range adj.Lines {
+		if line.BatchID != nil {
+			err := adjustBatch(tx, *line.BatchID, line.ProductID, adj.OutletID, line.Quantity)
+			if err != nil {
+				return err
+			}
+		}
+
+		note := line.Reason
+		if line.Note != "" {
+			note += ": " + line.Note
+		}
+
+		stockAfter, err := applyStockChange(tx, stockChange{
+			ProductID:     line.ProductID,
+			OutletID:      adj.OutletID,
+			Type:          models.StockMovementAdjustment,
+			Quantity:      line.Quantity,
+			ReferenceType: "stock_adjustment",
+			ReferenceID:   &adj.ID,
+			Note:          note,
+			CreatedBy:     reviewedBy,
+		})
+		if err != nil {
+			return err
+		}
+		if stockAfter < 0 {
+			return fmt.Errorf("%w: stok produk %d tidak cukup untuk adjustment %d", ErrValidation, line.ProductID, line.Quantity)
+		}
+		//adjustment keluar tanpa batch_id tetap mengurangi batch, supaya batch tidak melebihi stok
+		if line.BatchID == nil && line.Quantity < 0 {
+			if err := deductBatches(tx, adj.OutletID, line.ProductID, nil, -line.Quantity); err != nil {
+				return err
+			}
+		}
+	}
+
+	adj.Status = models.AdjustmentStatusApplied
+	return markAdjustmentReviewed(tx, adj, reviewedBy, reviewNote)
+}
+
+func markAdjustmentReviewed(tx *sql.Tx, adj *models.StockAdjustment, reviewedBy string, note string) error {
+	return tx.QueryRow(`UPDATE stock_adjustments SET status = $1, reviewed_by = $2, reviewed_at = NOW(), review_note = $3
+				WHERE id = $4 RETURNING reviewed_by, reviewed_at, review_note`,
+		adj.Status, reviewedBy, note, adj.ID).Scan(&adj.ReviewedBy, &adj.ReviewedAt, &adj.ReviewNote)
+}
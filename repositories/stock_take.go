@@ -0,0 +1,394 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+	"kasir-api/models"
+	"strconv"
+	"strings"
+)
+
+const stockTakeColumns = `t.id, t.outlet_id, t.status, t.note, t.created_by, t.created_at, t.finalized_by, t.finalized_at,
+					COALESCE((SELECT string_agg(c.category_id::text, ',' ORDER BY c.category_id)
+						FROM stock_take_categories AS c WHERE c.stock_take_id = t.id), ''),
+					(SELECT COUNT(*) FROM stock_take_items AS i WHERE i.stock_take_id = t.id),
+					(SELECT COUNT(i.counted_quantity) FROM stock_take_items AS i WHERE i.stock_take_id = t.id)`
+
+// StartStockTake membuka sesi opname di satu outlet dan membekukan stok outlet saat ini sebagai expected_quantity.
+// Produk yang sedang ada di sesi opname lain yang masih terbuka di outlet yang sama tidak boleh dihitung dua kali.
+func (repo *StockRepository) StartStockTake(st *models.StockTake) error {
+	tx, err := repo.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, categoryID := range st.CategoryIDs {
+		var exists bool
+		err := tx.QueryRow("SELECT EXISTS (SELECT 1 FROM categories WHERE id = $1 AND archived_at IS NULL)", categoryID).Scan(&exists)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return fmt.Errorf("%w: kategori %d", ErrNotFound, categoryID)
+		}
+	}
+
+	st.OutletID, err = resolveOutlet(tx, st.OutletID)
+	if err != nil {
+		return err
+	}
+
+	//sesi di outlet yang sama dibuat bergantian, kalau tidak dua sesi yang dibuat bersamaan sama-sama lolos
+	//pengecekan tumpang tindih di bawah. NO KEY UPDATE supaya checkout di outlet ini tidak ikut tertahan
+	_, err = tx.Exec("SELECT id FROM outlets WHERE id = $1 FOR NO KEY UPDATE", st.OutletID)
+	if err != nil {
+		return err
+	}
+
+	st.Status = models.StockTakeStatusOpen
+	err = tx.QueryRow("INSERT INTO stock_takes (outlet_id, status, note, created_by) VALUES ($1, $2, $3, $4) RETURNING id, created_at",
+		st.OutletID, st.Status, st.Note, st.CreatedBy).Scan(&st.ID, &st.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	for _, categoryID := range st.CategoryIDs {
+		_, err := tx.Exec("INSERT INTO stock_take_categories (stock_take_id, category_id) VALUES ($1, $2)", st.ID, categoryID)
+		if err != nil {
+			return err
+		}
+	}
+
+	//satu statement supaya semua expected_quantity diambil dari snapshot yang sama
+	query := `INSERT INTO stock_take_items (stock_take_id, product_id, expected_quantity, unit_price)
+				SELECT $1, p.id, COALESCE(os.stock, 0), COALESCE(op.price, p.price)
+				FROM products AS p
+				LEFT JOIN outlet_stocks AS os ON os.product_id = p.id AND os.outlet_id = $2
+				LEFT JOIN outlet_prices AS op ON op.product_id = p.id AND op.outlet_id = $2
+				WHERE p.archived_at IS NULL`
+	if len(st.CategoryIDs) > 0 {
+		query += " AND p.category_id IN (SELECT category_id FROM stock_take_categories WHERE stock_take_id = $1)"
+	}
+	result, err := tx.Exec(query, st.ID, st.OutletID)
+	if err != nil {
+		return err
+	}
+	items, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if items == 0 {
+		return fmt.Errorf("%w: tidak ada produk aktif untuk dihitung", ErrValidation)
+	}
+	st.TotalItems = int(items)
+
+	var overlapping int
+	err = tx.QueryRow(`SELECT COUNT(*) FROM stock_take_items AS i
+				JOIN stock_takes AS t ON t.id = i.stock_take_id
+				WHERE t.status = $1 AND t.id <> $2 AND t.outlet_id = $3
+					AND i.product_id IN (SELECT product_id FROM stock_take_items WHERE stock_take_id = $2)`,
+		models.StockTakeStatusOpen, st.ID, st.OutletID).Scan(&overlapping)
+	if err != nil {
+		return err
+	}
+	if overlapping > 0 {
+		return fmt.Errorf("%w: %d produk sedang dihitung di sesi opname lain yang masih terbuka", ErrInvalidStatus, overlapping)
+	}
+
+	return tx.Commit()
+}
+
+func (repo *StockRepository) GetStockTakes(status string) ([]*models.StockTake, error) {
+	query := "SELECT " + stockTakeColumns + " FROM stock_takes AS t"
+	args := []interface{}{}
+	if status != "" {
+		query += " WHERE t.status = $1"
+		args = append(args, status)
+	}
+	query += " ORDER BY t.created_at DESC, t.id DESC"
+
+	rows, err := repo.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	takes := make([]*models.StockTake, 0)
+	for rows.Next() {
+		st, err := scanStockTake(rows)
+		if err != nil {
+			return nil, err
+		}
+		takes = append(takes, st)
+	}
+
+	return takes, rows.Err()
+}
+
+func (repo *StockRepository) GetStockTakeByID(id int) (*models.StockTake, error) {
+	return getStockTake(repo.db, id, "")
+}
+
+// RecordStockTakeCounts menyimpan hasil hitungan. Beberapa device boleh mengirim bersamaan,
+// mode add dijumlahkan langsung di database supaya tidak saling menimpa.
+func (repo *StockRepository) RecordStockTakeCounts(id int, req models.StockTakeCountRequest, countedBy string) ([]models.StockTakeItem, error) {
+	tx, err := repo.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	//FOR SHARE: device lain tetap bisa input, tapi finalize harus menunggu
+	st, err := getStockTake(tx, id, "FOR SHARE")
+	if err != nil {
+		return nil, err
+	}
+	if st.Status != models.StockTakeStatusOpen {
+		return nil, fmt.Errorf("%w: sesi opname %d sudah %s", ErrInvalidStatus, id, st.Status)
+	}
+
+	counted := make(map[int]bool)
+	for _, c := range req.Counts {
+		var total int
+		err := tx.QueryRow(`UPDATE stock_take_items
+					SET counted_quantity = CASE WHEN $1 = 'add' THEN COALESCE(counted_quantity, 0) + $2 ELSE $2 END,
+						updated_at = NOW()
+					WHERE stock_take_id = $3 AND product_id = $4
+					RETURNING counted_quantity`,
+			c.Mode, c.Quantity, id, c.ProductID).Scan(&total)
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("%w: produk %d tidak termasuk dalam sesi opname ini", ErrValidation, c.ProductID)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if total < 0 {
+			return nil, fmt.Errorf("%w: hasil hitungan produk %d jadi negatif", ErrValidation, c.ProductID)
+		}
+
+		_, err = tx.Exec(`INSERT INTO stock_take_counts (stock_take_id, product_id, mode, quantity, device, counted_by)
+					VALUES ($1, $2, $3, $4, $5, $6)`,
+			id, c.ProductID, c.Mode, c.Quantity, req.Device, countedBy)
+		if err != nil {
+			return nil, err
+		}
+		counted[c.ProductID] = true
+	}
+
+	items, err := getStockTakeItems(tx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	updated := make([]models.StockTakeItem, 0, len(counted))
+	for _, item := range items {
+		if counted[item.ProductID] {
+			updated = append(updated, item)
+		}
+	}
+
+	return updated, nil
+}
+
+func (repo *StockRepository) GetStockTakeVariance(id int, onlyDiscrepancies bool) (*models.StockTakeVariance, error) {
+	st, err := getStockTake(repo.db, id, "")
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := getStockTakeItems(repo.db, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return buildStockTakeVariance(st, items, onlyDiscrepancies), nil
+}
+
+// FinalizeStockTake memposting selisih (counted - expected) setiap produk ke stok sebagai movement opname.
+// Memakai selisih, bukan menimpa stok, supaya penjualan selama opname berlangsung tidak hilang.
+func (repo *StockRepository) FinalizeStockTake(id int, zeroUncounted bool, finalizedBy string) (*models.StockTakeVariance, error) {
+	tx, err := repo.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	st, err := getStockTake(tx, id, "FOR UPDATE")
+	if err != nil {
+		return nil, err
+	}
+	if st.Status != models.StockTakeStatusOpen {
+		return nil, fmt.Errorf("%w: sesi opname %d sudah %s", ErrInvalidStatus, id, st.Status)
+	}
+
+	if zeroUncounted {
+		_, err = tx.Exec("UPDATE stock_take_items SET counted_quantity = 0, updated_at = NOW() WHERE stock_take_id = $1 AND counted_quantity IS NULL", id)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	items, err := getStockTakeItems(tx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, item := range items {
+		if item.Variance == nil || *item.Variance == 0 {
+			continue
+		}
+
+		stockAfter, err := applyStockChange(tx, stockChange{
+			ProductID:     item.ProductID,
+			OutletID:      st.OutletID,
+			Type:          models.StockMovementOpname,
+			Quantity:      *item.Variance,
+			ReferenceType: "stock_take",
+			ReferenceID:   &id,
+			Note:          fmt.Sprintf("opname: expected %d, counted %d", item.ExpectedQuantity, *item.CountedQuantity),
+			CreatedBy:     finalizedBy,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if stockAfter < 0 {
+			return nil, fmt.Errorf("%w: stok %s jadi %d setelah opname, cek ulang hitungannya", ErrValidation, item.ProductName, stockAfter)
+		}
+		if *item.Variance < 0 {
+			if err := deductBatches(tx, st.OutletID, item.ProductID, nil, -*item.Variance); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	err = tx.QueryRow(`UPDATE stock_takes SET status = $1, finalized_by = $2, finalized_at = NOW()
+				WHERE id = $3 RETURNING status, finalized_by, finalized_at`,
+		models.StockTakeStatusFinalized, finalizedBy, id).Scan(&st.Status, &st.FinalizedBy, &st.FinalizedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return buildStockTakeVariance(st, items, false), nil
+}
+
+func (repo *StockRepository) CancelStockTake(id int) error {
+	result, err := repo.db.Exec("UPDATE stock_takes SET status = $1 WHERE id = $2 AND status = $3",
+		models.StockTakeStatusCancelled, id, models.StockTakeStatusOpen)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		st, err := getStockTake(repo.db, id, "")
+		if err != nil {
+			return err
+		}
+		return fmt.Errorf("%w: sesi opname %d sudah %s", ErrInvalidStatus, id, st.Status)
+	}
+
+	return nil
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanStockTake(row rowScanner) (*models.StockTake, error) {
+	var st models.StockTake
+	var categoryIDs string
+	err := row.Scan(&st.ID, &st.OutletID, &st.Status, &st.Note, &st.CreatedBy, &st.CreatedAt, &st.FinalizedBy, &st.FinalizedAt,
+		&categoryIDs, &st.TotalItems, &st.CountedItems)
+	if err != nil {
+		return nil, err
+	}
+
+	st.CategoryIDs = make([]int, 0)
+	if categoryIDs != "" {
+		for _, raw := range strings.Split(categoryIDs, ",") {
+			categoryID, err := strconv.Atoi(raw)
+			if err != nil {
+				return nil, err
+			}
+			st.CategoryIDs = append(st.CategoryIDs, categoryID)
+		}
+	}
+
+	return &st, nil
+}
+
+func getStockTake(q dbExecutor, id int, lock string) (*models.StockTake, error) {
+	query := "SELECT " + stockTakeColumns + " FROM stock_takes AS t WHERE t.id = $1"
+	if lock != "" {
+		query += " " + lock + " OF t"
+	}
+
+	st, err := scanStockTake(q.QueryRow(query, id))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("%w: sesi opname %d", ErrNotFound, id)
+	}
+	return st, err
+}
+
+func getStockTakeItems(q dbExecutor, id int) ([]models.StockTakeItem, error) {
+	rows, err := q.Query(`SELECT i.product_id, p.name, c.name, i.expected_quantity, i.counted_quantity, i.unit_price, i.updated_at
+				FROM stock_take_items AS i
+				JOIN products AS p ON p.id = i.product_id
+				JOIN categories AS c ON c.id = p.category_id
+				WHERE i.stock_take_id = $1
+				ORDER BY c.name, p.name, p.id`, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := make([]models.StockTakeItem, 0)
+	for rows.Next() {
+		var item models.StockTakeItem
+		err := rows.Scan(&item.ProductID, &item.ProductName, &item.CategoryName, &item.ExpectedQuantity,
+			&item.CountedQuantity, &item.UnitPrice, &item.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		if item.CountedQuantity != nil {
+			variance := *item.CountedQuantity - item.ExpectedQuantity
+			valueImpact := variance * item.UnitPrice
+			item.Variance = &variance
+			item.ValueImpact = &valueImpact
+		}
+		items = append(items, item)
+	}
+
+	return items, rows.Err()
+}
+
+func buildStockTakeVariance(st *models.StockTake, items []models.StockTakeItem, onlyDiscrepancies bool) *models.StockTakeVariance {
+	report := &models.StockTakeVariance{StockTake: *st, Items: make([]models.StockTakeItem, 0)}
+	for _, item := range items {
+		if item.Variance == nil {
+			report.UncountedItems++
+		} else {
+			report.TotalVariance += *item.Variance
+			report.TotalValueImpact += *item.ValueImpact
+		}
+
+		if onlyDiscrepancies && item.Variance != nil && *item.Variance == 0 {
+			continue
+		}
+		report.Items = append(report.Items, item)
+	}
+	return report
+}
@@ -0,0 +1,476 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+	"kasir-api/models"
+	"time"
+)
+
+const stockTransferColumns = `t.id, t.source_outlet_id, so.name, t.destination_outlet_id, do.name, t.status, t.note,
+					t.requested_by, t.requested_at, t.shipped_by, t.shipped_at, t.received_by, t.received_at`
+
+const stockTransferFrom = ` FROM stock_transfers AS t
+				JOIN outlets AS so ON so.id = t.source_outlet_id
+				JOIN outlets AS do ON do.id = t.destination_outlet_id`
+
+func scanStockTransfer(row rowScanner, t *models.StockTransfer) error {
+	return row.Scan(&t.ID, &t.SourceOutletID, &t.SourceOutletName, &t.DestinationOutletID, &t.DestinationOutletName,
+		&t.Status, &t.Note, &t.RequestedBy, &t.RequestedAt, &t.ShippedBy, &t.ShippedAt, &t.ReceivedBy, &t.ReceivedAt)
+}
+
+func (repo *StockRepository) CreateStockTransfer(t *models.StockTransfer) error {
+	tx, err := repo.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	t.SourceOutletID, err = resolveOutlet(tx, t.SourceOutletID)
+	if err != nil {
+		return err
+	}
+	t.DestinationOutletID, err = resolveOutlet(tx, t.DestinationOutletID)
+	if err != nil {
+		return err
+	}
+	if t.SourceOutletID == t.DestinationOutletID {
+		return fmt.Errorf("%w: outlet asal dan tujuan tidak boleh sama", ErrValidation)
+	}
+
+	t.Status = models.StockTransferRequested
+	err = tx.QueryRow(`INSERT INTO stock_transfers (source_outlet_id, destination_outlet_id, status, note, requested_by)
+				VALUES ($1, $2, $3, $4, $5) RETURNING id`,
+		t.SourceOutletID, t.DestinationOutletID, t.Status, t.Note, t.RequestedBy).Scan(&t.ID)
+	if err != nil {
+		return err
+	}
+
+	for i, line := range t.Lines {
+		var archivedAt *time.Time
+		err := tx.QueryRow("SELECT name, archived_at FROM products WHERE id = $1", line.ProductID).Scan(&t.Lines[i].ProductName, &archivedAt)
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("%w: product id %d", ErrNotFound, line.ProductID)
+		}
+		if err != nil {
+			return err
+		}
+		if archivedAt != nil {
+			return fmt.Errorf("%w: produk %s sudah diarsipkan", ErrValidation, t.Lines[i].ProductName)
+		}
+
+		err = tx.QueryRow("INSERT INTO stock_transfer_lines (stock_transfer_id, product_id, quantity) VALUES ($1, $2, $3) RETURNING id",
+			t.ID, line.ProductID, line.Quantity).Scan(&t.Lines[i].ID)
+		if err != nil {
+			return err
+		}
+	}
+
+	created, err := getStockTransfer(tx, t.ID, false)
+	if err != nil {
+		return err
+	}
+	*t = *created
+
+	return tx.Commit()
+}
+
+// GetStockTransfers tanpa line. outletID cocok dengan outlet asal maupun tujuan.
+func (repo *StockRepository) GetStockTransfers(status string, outletID *int) ([]*models.StockTransfer, error) {
+	query := "SELECT " + stockTransferColumns + stockTransferFrom + " WHERE TRUE"
+	args := []interface{}{}
+	if status != "" {
+		args = append(args, status)
+		query += fmt.Sprintf(" AND t.status = $%d", len(args))
+	}
+	if outletID != nil {
+		args = append(args, *outletID)
+		query += fmt.Sprintf(" AND (t.source_outlet_id = $%d OR t.destination_outlet_id = $%d)", len(args), len(args))
+	}
+	query += " ORDER BY t.requested_at DESC, t.id DESC"
+
+	rows, err := repo.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	transfers := make([]*models.StockTransfer, 0)
+	for rows.Next() {
+		var t models.StockTransfer
+		if err := scanStockTransfer(rows, &t); err != nil {
+			return nil, err
+		}
+		transfers = append(transfers, &t)
+	}
+
+	return transfers, rows.Err()
+}
+
+func (repo *StockRepository) GetStockTransferByID(id int) (*models.StockTransfer, error) {
+	return getStockTransfer(repo.db, id, false)
+}
+
+// ShipStockTransfer mengeluarkan barang dari outlet asal. Quantity yang dikirim boleh kurang dari permintaan,
+// produk yang tidak disebut di shipped dikirim sesuai permintaan. Batch diambil FEFO seperti penjualan.
+func (repo *StockRepository) ShipStockTransfer(id int, shipped map[int]models.StockTransferQuantity, shippedBy string) (*models.StockTransfer, error) {
+	tx, err := repo.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	t, err := getStockTransfer(tx, id, true)
+	if err != nil {
+		return nil, err
+	}
+	if t.Status != models.StockTransferRequested {
+		return nil, fmt.Errorf("%w: transfer %d sudah %s", ErrInvalidStatus, id, t.Status)
+	}
+	if err := ensureTransferProducts(t, shipped); err != nil {
+		return nil, err
+	}
+
+	totalShipped := 0
+	for i, line := range t.Lines {
+		quantity := line.Quantity
+		if s, ok := shipped[line.ProductID]; ok {
+			if s.Quantity > line.Quantity {
+				return nil, fmt.Errorf("%w: produk %s diminta %d, tidak bisa dikirim %d", ErrValidation, line.ProductName, line.Quantity, s.Quantity)
+			}
+			quantity = s.Quantity
+			t.Lines[i].Note = s.Note
+		}
+		t.Lines[i].ShippedQuantity = quantity
+		totalShipped += quantity
+
+		if quantity > 0 {
+			stockAfter, cost, err := applyStockChangeWithCost(tx, stockChange{
+				ProductID:     line.ProductID,
+				OutletID:      t.SourceOutletID,
+				Type:          models.StockMovementTransfer,
+				Quantity:      -quantity,
+				ReferenceType: "stock_transfer",
+				ReferenceID:   &t.ID,
+				Note:          "transfer ke " + t.DestinationOutletName,
+				CreatedBy:     shippedBy,
+			})
+			if err != nil {
+				return nil, err
+			}
+			if stockAfter < 0 {
+				return nil, fmt.Errorf("%w: stok %s di %s tidak cukup untuk dikirim", ErrValidation, line.ProductName, t.SourceOutletName)
+			}
+			t.Lines[i].ShippedCost = cost.FIFO
+
+			t.Lines[i].Batches, err = consumeBatchesFEFO(tx, t.SourceOutletID, line.ProductID, line.ProductName, quantity, stockAfter)
+			if err != nil {
+				return nil, err
+			}
+			for _, b := range t.Lines[i].Batches {
+				_, err := tx.Exec("INSERT INTO stock_transfer_line_batches (stock_transfer_line_id, batch_id, quantity) VALUES ($1, $2, $3)",
+					line.ID, b.BatchID, b.Quantity)
+				if err != nil {
+					return nil, err
+				}
+			}
+		}
+
+		_, err = tx.Exec("UPDATE stock_transfer_lines SET shipped_quantity = $1, shipped_cost = $2, note = $3 WHERE id = $4",
+			t.Lines[i].ShippedQuantity, t.Lines[i].ShippedCost, t.Lines[i].Note, line.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if totalShipped == 0 {
+		return nil, fmt.Errorf("%w: tidak ada barang yang dikirim, batalkan transfernya saja", ErrValidation)
+	}
+
+	err = tx.QueryRow(`UPDATE stock_transfers SET status = $1, shipped_by = $2, shipped_at = NOW()
+				WHERE id = $3 RETURNING status, shipped_by, shipped_at`,
+		models.StockTransferShipped, shippedBy, id).Scan(&t.Status, &t.ShippedBy, &t.ShippedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+// ReceiveStockTransfer memasukkan barang ke outlet tujuan. Produk yang tidak disebut di received dianggap
+// diterima utuh. Selisih dengan yang dikirim dicatat sebagai discrepancy dan tidak masuk ke stok mana pun.
+func (repo *StockRepository) ReceiveStockTransfer(id int, received map[int]models.StockTransferQuantity, receivedBy string) (*models.StockTransfer, error) {
+	tx, err := repo.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	t, err := getStockTransfer(tx, id, true)
+	if err != nil {
+		return nil, err
+	}
+	if t.Status != models.StockTransferShipped {
+		return nil, fmt.Errorf("%w: transfer %d berstatus %s, hanya transfer yang sudah dikirim yang bisa diterima", ErrInvalidStatus, id, t.Status)
+	}
+	if err := ensureTransferProducts(t, received); err != nil {
+		return nil, err
+	}
+
+	for i, line := range t.Lines {
+		quantity := line.ShippedQuantity
+		note := line.Note
+		if r, ok := received[line.ProductID]; ok {
+			if r.Quantity > line.ShippedQuantity {
+				return nil, fmt.Errorf("%w: produk %s hanya dikirim %d", ErrValidation, line.ProductName, line.ShippedQuantity)
+			}
+			quantity = r.Quantity
+			if r.Note != "" {
+				note = r.Note
+			}
+		}
+		t.Lines[i].ReceivedQuantity = &quantity
+		t.Lines[i].Discrepancy = line.ShippedQuantity - quantity
+		t.Lines[i].Note = note
+
+		if quantity > 0 {
+			//lapisan di tujuan sama dengan lapisan yang terpakai saat dikirim, jadi nilai persediaan tidak berubah karena dipindah
+			layers, err := shippedCostLayers(tx, t.ID, line.ProductID, quantity)
+			if err != nil {
+				return nil, err
+			}
+			_, err = applyStockChange(tx, stockChange{
+				ProductID:     line.ProductID,
+				OutletID:      t.DestinationOutletID,
+				Type:          models.StockMovementTransfer,
+				Quantity:      quantity,
+				ReferenceType: "stock_transfer",
+				ReferenceID:   &t.ID,
+				Note:          "transfer dari " + t.SourceOutletName,
+				CreatedBy:     receivedBy,
+				CostLayers:    layers,
+			})
+			if err != nil {
+				return nil, err
+			}
+
+			//batch diterima berurutan sesuai urutan FEFO saat dikirim, selisih dianggap dari batch terakhir
+			remaining := quantity
+			for _, b := range line.Batches {
+				if remaining == 0 {
+					break
+				}
+				q := min(b.Quantity, remaining)
+				remaining -= q
+				if _, err := receiveIntoBatch(tx, t.DestinationOutletID, line.ProductID, b.BatchNumber, b.ExpiryDate, q); err != nil {
+					return nil, err
+				}
+			}
+		}
+
+		_, err = tx.Exec("UPDATE stock_transfer_lines SET received_quantity = $1, note = $2 WHERE id = $3", quantity, note, line.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = tx.QueryRow(`UPDATE stock_transfers SET status = $1, received_by = $2, received_at = NOW()
+				WHERE id = $3 RETURNING status, received_by, received_at`,
+		models.StockTransferReceived, receivedBy, id).Scan(&t.Status, &t.ReceivedBy, &t.ReceivedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+// shippedCostLayers mengambil lapisan harga pokok yang terpakai saat produk ini dikirim, urut FIFO,
+// sebanyak quantity yang diterima. Selisih dianggap dari lapisan terakhir, sama seperti batch.
+func shippedCostLayers(tx *sql.Tx, transferID int, productID int, quantity int) ([]costLayer, error) {
+	rows, err := tx.Query(`SELECT c.unit_cost, c.quantity
+				FROM cost_layer_consumptions AS c
+				JOIN stock_movements AS sm ON sm.id = c.movement_id
+				WHERE sm.reference_type = 'stock_transfer' AND sm.reference_id = $1
+					AND sm.product_id = $2 AND sm.quantity < 0
+				ORDER BY c.id`, transferID, productID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	layers := make([]costLayer, 0)
+	remaining := quantity
+	for remaining > 0 && rows.Next() {
+		var l costLayer
+		if err := rows.Scan(&l.UnitCost, &l.Quantity); err != nil {
+			return nil, err
+		}
+		l.Quantity = min(l.Quantity, remaining)
+		remaining -= l.Quantity
+		layers = append(layers, l)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if remaining > 0 {
+		return nil, fmt.Errorf("harga pokok pengiriman produk id %d di transfer %d tidak lengkap", productID, transferID)
+	}
+
+	return layers, nil
+}
+
+// CancelStockTransfer hanya untuk transfer yang belum dikirim, setelah dikirim barang harus diterima dulu.
+func (repo *StockRepository) CancelStockTransfer(id int) (*models.StockTransfer, error) {
+	tx, err := repo.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	t, err := getStockTransfer(tx, id, true)
+	if err != nil {
+		return nil, err
+	}
+	if t.Status != models.StockTransferRequested {
+		return nil, fmt.Errorf("%w: transfer %d sudah %s", ErrInvalidStatus, id, t.Status)
+	}
+
+	err = tx.QueryRow("UPDATE stock_transfers SET status = $1 WHERE id = $2 RETURNING status",
+		models.StockTransferCancelled, id).Scan(&t.Status)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+// GetInTransitStock mengembalikan barang yang sudah dikirim tapi belum diterima, dinilai dengan HPP saat dikirim.
+func (repo *StockRepository) GetInTransitStock(outletID *int) (*models.InTransitReport, error) {
+	query := `SELECT t.id, t.source_outlet_id, t.destination_outlet_id, l.product_id, p.name, l.shipped_quantity, l.shipped_cost, t.shipped_at
+				FROM stock_transfers AS t
+				JOIN stock_transfer_lines AS l ON l.stock_transfer_id = t.id
+				JOIN products AS p ON p.id = l.product_id
+				WHERE t.status = $1 AND l.shipped_quantity > 0`
+	args := []interface{}{models.StockTransferShipped}
+	if outletID != nil {
+		args = append(args, *outletID)
+		query += " AND (t.source_outlet_id = $2 OR t.destination_outlet_id = $2)"
+	}
+	query += " ORDER BY t.shipped_at, t.id, p.name"
+
+	rows, err := repo.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	report := &models.InTransitReport{Items: make([]models.InTransitItem, 0)}
+	for rows.Next() {
+		var item models.InTransitItem
+		err := rows.Scan(&item.TransferID, &item.SourceOutletID, &item.DestinationOutletID, &item.ProductID, &item.ProductName,
+			&item.Quantity, &item.Value, &item.ShippedAt)
+		if err != nil {
+			return nil, err
+		}
+		report.Items = append(report.Items, item)
+		report.TotalQuantity += item.Quantity
+		report.TotalValue += item.Value
+	}
+
+	return report, rows.Err()
+}
+
+func ensureTransferProducts(t *models.StockTransfer, quantities map[int]models.StockTransferQuantity) error {
+	for productID := range quantities {
+		found := false
+		for _, line := range t.Lines {
+			if line.ProductID == productID {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("%w: produk %d tidak ada di transfer %d", ErrValidation, productID, t.ID)
+		}
+	}
+	return nil
+}
+
+func getStockTransfer(q dbExecutor, id int, forUpdate bool) (*models.StockTransfer, error) {
+	query := "SELECT " + stockTransferColumns + stockTransferFrom + " WHERE t.id = $1"
+	if forUpdate {
+		query += " FOR UPDATE OF t"
+	}
+
+	var t models.StockTransfer
+	err := scanStockTransfer(q.QueryRow(query, id), &t)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("%w: stock transfer %d", ErrNotFound, id)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := q.Query(`SELECT l.id, l.product_id, p.name, l.quantity, l.shipped_quantity, l.shipped_cost, l.received_quantity, l.note
+				FROM stock_transfer_lines AS l
+				JOIN products AS p ON p.id = l.product_id
+				WHERE l.stock_transfer_id = $1
+				ORDER BY l.id`, id)
+	if err != nil {
+		return nil, err
+	}
+
+	t.Lines = make([]models.StockTransferLine, 0)
+	byID := make(map[int]int)
+	for rows.Next() {
+		var l models.StockTransferLine
+		err := rows.Scan(&l.ID, &l.ProductID, &l.ProductName, &l.Quantity, &l.ShippedQuantity, &l.ShippedCost, &l.ReceivedQuantity, &l.Note)
+		if err != nil {
+			rows.Close()
+			return nil, err
+		}
+		if l.ReceivedQuantity != nil {
+			l.Discrepancy = l.ShippedQuantity - *l.ReceivedQuantity
+		}
+		byID[l.ID] = len(t.Lines)
+		t.Lines = append(t.Lines, l)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	batchRows, err := q.Query(`SELECT lb.stock_transfer_line_id, lb.batch_id, b.batch_number, COALESCE(to_char(b.expiry_date, 'YYYY-MM-DD'), ''), lb.quantity
+				FROM stock_transfer_line_batches AS lb
+				JOIN stock_transfer_lines AS l ON l.id = lb.stock_transfer_line_id
+				JOIN product_batches AS b ON b.id = lb.batch_id
+				WHERE l.stock_transfer_id = $1
+				ORDER BY lb.id`, id)
+	if err != nil {
+		return nil, err
+	}
+	defer batchRows.Close()
+
+	for batchRows.Next() {
+		var lineID int
+		var b models.TransactionDetailBatch
+		if err := batchRows.Scan(&lineID, &b.BatchID, &b.BatchNumber, &b.ExpiryDate, &b.Quantity); err != nil {
+			return nil, err
+		}
+		line := &t.Lines[byID[lineID]]
+		line.Batches = append(line.Batches, b)
+	}
+
+	return &t, batchRows.Err()
+}
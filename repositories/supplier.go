@@ -0,0 +1,94 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+	"kasir-api/models"
+)
+
+type SupplierRepository struct {
+	db *sql.DB
+}
+
+func NewSupplierRepository(db *sql.DB) *SupplierRepository {
+	return &SupplierRepository{db: db}
+}
+
+func (repo *SupplierRepository) GetAllSuppliers(includeArchived bool) ([]*models.Supplier, error) {
+	query := "SELECT id, name, contact_name, phone, email, address, created_at, archived_at FROM suppliers"
+	if !includeArchived {
+		query += " WHERE archived_at IS NULL"
+	}
+	query += " ORDER BY name, id"
+
+	rows, err := repo.db.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	suppliers := make([]*models.Supplier, 0)
+	for rows.Next() {
+		var s models.Supplier
+		err := rows.Scan(&s.ID, &s.Name, &s.ContactName, &s.Phone, &s.Email, &s.Address, &s.CreatedAt, &s.ArchivedAt)
+		if err != nil {
+			return nil, err
+		}
+		suppliers = append(suppliers, &s)
+	}
+	return suppliers, rows.Err()
+}
+
+func (repo *SupplierRepository) GetSupplierByID(id int) (*models.Supplier, error) {
+	query := "SELECT id, name, contact_name, phone, email, address, created_at, archived_at FROM suppliers WHERE id = $1"
+
+	var s models.Supplier
+	err := repo.db.QueryRow(query, id).Scan(&s.ID, &s.Name, &s.ContactName, &s.Phone, &s.Email, &s.Address, &s.CreatedAt, &s.ArchivedAt)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("%w: supplier %d", ErrNotFound, id)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &s, nil
+}
+
+func (repo *SupplierRepository) CreateSupplier(supplier *models.Supplier) error {
+	query := `INSERT INTO suppliers (name, contact_name, phone, email, address)
+				VALUES ($1, $2, $3, $4, $5) RETURNING id, created_at`
+	return repo.db.QueryRow(query, supplier.Name, supplier.ContactName, supplier.Phone, supplier.Email, supplier.Address).
+		Scan(&supplier.ID, &supplier.CreatedAt)
+}
+
+func (repo *SupplierRepository) UpdateSupplier(supplier *models.Supplier) error {
+	query := `UPDATE suppliers SET name = $1, contact_name = $2, phone = $3, email = $4, address = $5
+				WHERE id = $6 RETURNING created_at, archived_at`
+	err := repo.db.QueryRow(query, supplier.Name, supplier.ContactName, supplier.Phone, supplier.Email, supplier.Address, supplier.ID).
+		Scan(&supplier.CreatedAt, &supplier.ArchivedAt)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("%w: supplier %d", ErrNotFound, supplier.ID)
+	}
+	return err
+}
+
+// ArchiveSupplier dipakai sebagai pengganti delete supaya purchase order lama tetap bisa dibaca.
+func (repo *SupplierRepository) ArchiveSupplier(id int) error {
+	result, err := repo.db.Exec("UPDATE suppliers SET archived_at = NOW() WHERE id = $1 AND archived_at IS NULL", id)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		if _, err := repo.GetSupplierByID(id); err != nil {
+			return err
+		}
+		return fmt.Errorf("%w: supplier sudah diarsipkan", ErrInvalidStatus)
+	}
+
+	return nil
+}
@@ -0,0 +1,151 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+	"kasir-api/models"
+)
+
+// CreateSupplierReturn mengurangi stok untuk barang yang dikembalikan ke supplier. Harga pokok tidak berubah
+// karena barang keluar dinilai dengan harga pokok rata-rata, kecuali unit_cost diisi sesuai nota retur.
+// Batch dikurangi dari batch_id kalau diisi, kalau tidak FEFO.
+func (repo *PurchaseRepository) CreateSupplierReturn(ret *models.SupplierReturn) error {
+	tx, err := repo.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	err = tx.QueryRow("SELECT name FROM suppliers WHERE id = $1", ret.SupplierID).Scan(&ret.SupplierName)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("%w: supplier %d", ErrNotFound, ret.SupplierID)
+	}
+	if err != nil {
+		return err
+	}
+
+	ret.OutletID, err = resolveOutlet(tx, ret.OutletID)
+	if err != nil {
+		return err
+	}
+
+	err = tx.QueryRow(`INSERT INTO supplier_returns (supplier_id, outlet_id, reference, note, created_by)
+				VALUES ($1, $2, $3, $4, $5) RETURNING id, created_at`,
+		ret.SupplierID, ret.OutletID, ret.Reference, ret.Note, ret.CreatedBy).Scan(&ret.ID, &ret.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	ret.TotalAmount = 0
+	for i, line := range ret.Lines {
+		var productName string
+		var costPrice int
+		err := tx.QueryRow("SELECT name, cost_price FROM products WHERE id = $1 FOR UPDATE", line.ProductID).Scan(&productName, &costPrice)
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("%w: product id %d", ErrNotFound, line.ProductID)
+		}
+		if err != nil {
+			return err
+		}
+
+		unitCost := costPrice
+		if line.UnitCost != nil {
+			unitCost = *line.UnitCost
+		}
+		subtotal := line.Quantity * unitCost
+
+		err = tx.QueryRow(`INSERT INTO supplier_return_lines (supplier_return_id, product_id, batch_id, quantity, unit_cost, subtotal)
+					VALUES ($1, $2, $3, $4, $5, $6) RETURNING id`,
+			ret.ID, line.ProductID, line.BatchID, line.Quantity, unitCost, subtotal).Scan(&ret.Lines[i].ID)
+		if err != nil {
+			return err
+		}
+
+		stockAfter, err := applyStockChange(tx, stockChange{
+			ProductID:     line.ProductID,
+			OutletID:      ret.OutletID,
+			Type:          models.StockMovementReturn,
+			Quantity:      -line.Quantity,
+			ReferenceType: "supplier_return",
+			ReferenceID:   &ret.ID,
+			Note:          ret.Reference,
+			CreatedBy:     ret.CreatedBy,
+		})
+		if err != nil {
+			return err
+		}
+		if stockAfter < 0 {
+			return fmt.Errorf("%w: stok %s tidak cukup untuk diretur", ErrValidation, productName)
+		}
+		if err := deductBatches(tx, ret.OutletID, line.ProductID, line.BatchID, line.Quantity); err != nil {
+			return err
+		}
+
+		ret.Lines[i].ProductName = productName
+		ret.Lines[i].UnitCost = &unitCost
+		ret.Lines[i].Subtotal = subtotal
+		ret.TotalAmount += subtotal
+	}
+
+	_, err = tx.Exec("UPDATE supplier_returns SET total_amount = $1 WHERE id = $2", ret.TotalAmount, ret.ID)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (repo *PurchaseRepository) GetSupplierReturns(supplierID *int) ([]models.SupplierReturn, error) {
+	if supplierID != nil {
+		return getSupplierReturns(repo.db, "sr.supplier_id = $1", *supplierID)
+	}
+	return getSupplierReturns(repo.db, "TRUE")
+}
+
+func (repo *PurchaseRepository) GetSupplierReturnByID(id int) (*models.SupplierReturn, error) {
+	returns, err := getSupplierReturns(repo.db, "sr.id = $1", id)
+	if err != nil {
+		return nil, err
+	}
+	if len(returns) == 0 {
+		return nil, fmt.Errorf("%w: supplier return %d", ErrNotFound, id)
+	}
+
+	return &returns[0], nil
+}
+
+func getSupplierReturns(q dbExecutor, condition string, args ...interface{}) ([]models.SupplierReturn, error) {
+	rows, err := q.Query(`SELECT sr.id, sr.supplier_id, s.name, sr.outlet_id, sr.reference, sr.note, sr.total_amount, sr.created_by, sr.created_at,
+					l.id, l.product_id, p.name, l.batch_id, l.quantity, l.unit_cost, l.subtotal
+				FROM supplier_returns AS sr
+				JOIN suppliers AS s ON s.id = sr.supplier_id
+				JOIN supplier_return_lines AS l ON l.supplier_return_id = sr.id
+				JOIN products AS p ON p.id = l.product_id
+				WHERE `+condition+`
+				ORDER BY sr.created_at DESC, sr.id DESC, l.id`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	returns := make([]models.SupplierReturn, 0)
+	for rows.Next() {
+		var sr models.SupplierReturn
+		var l models.SupplierReturnLine
+		var unitCost int
+		err := rows.Scan(&sr.ID, &sr.SupplierID, &sr.SupplierName, &sr.OutletID, &sr.Reference, &sr.Note, &sr.TotalAmount, &sr.CreatedBy, &sr.CreatedAt,
+			&l.ID, &l.ProductID, &l.ProductName, &l.BatchID, &l.Quantity, &unitCost, &l.Subtotal)
+		if err != nil {
+			return nil, err
+		}
+		l.UnitCost = &unitCost
+
+		if len(returns) == 0 || returns[len(returns)-1].ID != sr.ID {
+			returns = append(returns, sr)
+		}
+		last := &returns[len(returns)-1]
+		last.Lines = append(last.Lines, l)
+	}
+
+	return returns, rows.Err()
+}
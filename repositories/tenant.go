@@ -0,0 +1,173 @@
+package repositories
+
+import (
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"kasir-api/models"
+)
+
+// TenantRepository memakai koneksi tanpa app.tenant_id, jadi hanya boleh menyentuh tabel tenants.
+// Data milik tenant diakses lewat koneksi dari database.TenantPool.
+type TenantRepository struct {
+	db *sql.DB
+}
+
+func NewTenantRepository(db *sql.DB) *TenantRepository {
+	return &TenantRepository{db: db}
+}
+
+const tenantColumns = "id, code, name, created_at, archived_at"
+
+func scanTenant(row rowScanner, t *models.Tenant) error {
+	return row.Scan(&t.ID, &t.Code, &t.Name, &t.CreatedAt, &t.ArchivedAt)
+}
+
+func (repo *TenantRepository) GetAllTenants(includeArchived bool) ([]*models.Tenant, error) {
+	query := "SELECT " + tenantColumns + " FROM tenants"
+	if !includeArchived {
+		query += " WHERE archived_at IS NULL"
+	}
+	query += " ORDER BY id"
+
+	rows, err := repo.db.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tenants := make([]*models.Tenant, 0)
+	for rows.Next() {
+		var t models.Tenant
+		if err := scanTenant(rows, &t); err != nil {
+			return nil, err
+		}
+		tenants = append(tenants, &t)
+	}
+	return tenants, rows.Err()
+}
+
+func (repo *TenantRepository) GetTenantByID(id int) (*models.Tenant, error) {
+	return repo.findTenant("id = $1", id)
+}
+
+func (repo *TenantRepository) GetTenantByCode(code string) (*models.Tenant, error) {
+	return repo.findTenant("code = $1", code)
+}
+
+func (repo *TenantRepository) GetTenantByToken(token string) (*models.Tenant, error) {
+	return repo.findTenant("api_token = $1", token)
+}
+
+func (repo *TenantRepository) findTenant(where string, arg interface{}) (*models.Tenant, error) {
+	var t models.Tenant
+	err := scanTenant(repo.db.QueryRow("SELECT "+tenantColumns+" FROM tenants WHERE "+where, arg), &t)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("%w: tenant tidak ditemukan", ErrNotFound)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &t, nil
+}
+
+// CreateTenant sekaligus membuat outlet default untuk tenant baru, karena checkout dan stok
+// tanpa outlet_id selalu jatuh ke outlet default.
+func (repo *TenantRepository) CreateTenant(t *models.Tenant) error {
+	tx, err := repo.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var exists bool
+	err = tx.QueryRow("SELECT EXISTS (SELECT 1 FROM tenants WHERE code = $1)", t.Code).Scan(&exists)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return fmt.Errorf("%w: kode tenant %s sudah dipakai", ErrValidation, t.Code)
+	}
+
+	t.APIToken, err = newAPIToken()
+	if err != nil {
+		return err
+	}
+	err = tx.QueryRow("INSERT INTO tenants (code, name, api_token) VALUES ($1, $2, $3) RETURNING id, created_at",
+		t.Code, t.Name, t.APIToken).Scan(&t.ID, &t.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	//set_config(..., true) hanya berlaku sampai transaksi ini selesai
+	_, err = tx.Exec("SELECT set_config('app.tenant_id', $1, true)", fmt.Sprint(t.ID))
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec("INSERT INTO outlets (code, name, is_default) VALUES ('PUSAT', 'Outlet Pusat', TRUE)")
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (repo *TenantRepository) UpdateTenant(t *models.Tenant) error {
+	err := repo.db.QueryRow("UPDATE tenants SET name = $1 WHERE id = $2 RETURNING code, created_at, archived_at", t.Name, t.ID).
+		Scan(&t.Code, &t.CreatedAt, &t.ArchivedAt)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("%w: tenant %d", ErrNotFound, t.ID)
+	}
+	return err
+}
+
+func (repo *TenantRepository) ArchiveTenant(id int) error {
+	if id == models.DefaultTenantID {
+		return fmt.Errorf("%w: tenant default tidak bisa diarsipkan", ErrValidation)
+	}
+
+	var archived bool
+	err := repo.db.QueryRow("SELECT archived_at IS NOT NULL FROM tenants WHERE id = $1", id).Scan(&archived)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("%w: tenant %d", ErrNotFound, id)
+	}
+	if err != nil {
+		return err
+	}
+	if archived {
+		return fmt.Errorf("%w: tenant sudah diarsipkan", ErrInvalidStatus)
+	}
+
+	_, err = repo.db.Exec("UPDATE tenants SET archived_at = NOW() WHERE id = $1", id)
+	return err
+}
+
+// RotateTenantToken mengganti api_token, token lama langsung tidak berlaku.
+func (repo *TenantRepository) RotateTenantToken(id int) (*models.Tenant, error) {
+	token, err := newAPIToken()
+	if err != nil {
+		return nil, err
+	}
+
+	var t models.Tenant
+	err = scanTenant(repo.db.QueryRow("UPDATE tenants SET api_token = $1 WHERE id = $2 RETURNING "+tenantColumns, token, id), &t)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("%w: tenant %d", ErrNotFound, id)
+	}
+	if err != nil {
+		return nil, err
+	}
+	t.APIToken = token
+
+	return &t, nil
+}
+
+func newAPIToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -15,19 +15,46 @@ func NewTransactionRepository(db *sql.DB) *TransactionRepository {
 	return &TransactionRepository{db: db}
 }
 
-func (repo *TransactionRepository) CreateTransaction(items []models.CheckoutItem) (*models.Transaction, error) {
+func (repo *TransactionRepository) CreateTransaction(req models.CheckoutRequest) (*models.Transaction, error) {
 	tx, err := repo.db.Begin() //untuk transaction
 	if err != nil {
 		return nil, err
 	}
 	defer tx.Rollback()
 
+	outletID, err := resolveOutlet(tx, req.OutletID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.CustomerGroupID != nil {
+		var exists bool
+		err := tx.QueryRow("SELECT EXISTS (SELECT 1 FROM customer_groups WHERE id = $1)", *req.CustomerGroupID).Scan(&exists)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			return nil, fmt.Errorf("customer group id %d not found", *req.CustomerGroupID)
+		}
+	}
+
+	//tier harga dihitung dari jumlah per produk, supaya memecah produk yang sama ke beberapa baris tidak melewati tier
+	productQuantity := make(map[int]int)
+	for _, item := range req.Items {
+		productQuantity[item.ProductID] += item.Quantity
+	}
+
 	totalAmount := 0
 	details := make([]models.TransactionDetail, 0)
-	for _, item := range items {
+	for _, item := range req.Items {
 		var productName string
-		var productID, price, stok int
-		err := tx.QueryRow("SELECT id, name, price, stock FROM products WHERE id = $1", item.ProductID).Scan(&productID, &productName, &price, &stok)
+		var productID, price int
+		var archivedAt *time.Time
+		//harga khusus outlet menggantikan harga dasar produk
+		err := tx.QueryRow(`SELECT p.id, p.name, COALESCE(op.price, p.price), p.archived_at
+					FROM products AS p
+					LEFT JOIN outlet_prices AS op ON op.product_id = p.id AND op.outlet_id = $2
+					WHERE p.id = $1 FOR UPDATE OF p`, item.ProductID, outletID).Scan(&productID, &productName, &price, &archivedAt)
 
 		//return error kalau product not found
 		if err == sql.ErrNoRows {
@@ -38,48 +65,117 @@ func (repo *TransactionRepository) CreateTransaction(items []models.CheckoutItem
 			return nil, err
 		}
 
-		//hitung subtotal
-		subtotal := item.Quantity * price
-		totalAmount += subtotal
+		if archivedAt != nil {
+			return nil, fmt.Errorf("product id %d is archived", item.ProductID)
+		}
 
-		//update stok
-		_, err = tx.Exec("UPDATE products SET stock = stock - $1 WHERE id = $2", item.Quantity, item.ProductID)
+		//harga sesuai customer group dan jumlah beli
+		unitPrice, err := resolveUnitPrice(tx, productID, price, productQuantity[productID], req.CustomerGroupID)
 		if err != nil {
 			return nil, err
 		}
 
+		//hitung subtotal
+		subtotal := item.Quantity * unitPrice
+		totalAmount += subtotal
+
 		//masukin ke transaction details
 		details = append(details, models.TransactionDetail{
 			ProductID:   productID,
 			ProductName: productName,
 			Quantity:    item.Quantity,
+			UnitPrice:   unitPrice,
 			Subtotal:    subtotal,
 		})
 	}
 
 	var transactionID int
-	err = tx.QueryRow("INSERT INTO transactions (total_amount) VALUES ($1) RETURNING ID", totalAmount).Scan(&transactionID)
+	err = tx.QueryRow("INSERT INTO transactions (outlet_id, total_amount, customer_group_id) VALUES ($1, $2, $3) RETURNING ID",
+		outletID, totalAmount, req.CustomerGroupID).Scan(&transactionID)
 	if err != nil {
 		return nil, err
 	}
 
 	if len(details) > 0 {
-		query := "INSERT INTO transaction_details (transaction_id, product_id, quantity, subtotal) VALUES "
+		query := "INSERT INTO transaction_details (transaction_id, product_id, quantity, unit_price, subtotal) VALUES "
 		values := []interface{}{}
 
 		for i, d := range details {
-			n := i * 4
-			query += fmt.Sprintf("($%d, $%d, $%d, $%d),", n+1, n+2, n+3, n+4)
-			values = append(values, transactionID, d.ProductID, d.Quantity, d.Subtotal)
+			n := i * 5
+			query += fmt.Sprintf("($%d, $%d, $%d, $%d, $%d),", n+1, n+2, n+3, n+4, n+5)
+			values = append(values, transactionID, d.ProductID, d.Quantity, d.UnitPrice, d.Subtotal)
 			details[i].TransactionID = transactionID
 		}
 
-		query = query[:len(query)-1]
-		_, err = tx.Exec(query, values...)
+		//RETURNING mengikuti urutan VALUES, jadi id bisa dipasangkan langsung ke details
+		query = query[:len(query)-1] + " RETURNING id"
+		rows, err := tx.Query(query, values...)
 		// fmt.Printf(query)
 		if err != nil {
 			return nil, err
 		}
+		for i := 0; rows.Next(); i++ {
+			if err := rows.Scan(&details[i].ID); err != nil {
+				rows.Close()
+				return nil, err
+			}
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	//update stok setelah transaksi punya ID, supaya ledger bisa merujuk ke transaksinya
+	lowStock := make([]models.LowStockProduct, 0)
+	for i, d := range details {
+		stockAfter, cost, err := applyStockChangeWithCost(tx, stockChange{
+			ProductID:     d.ProductID,
+			OutletID:      outletID,
+			Type:          models.StockMovementSale,
+			Quantity:      -d.Quantity,
+			ReferenceType: "transaction",
+			ReferenceID:   &transactionID,
+			CreatedBy:     req.CreatedBy,
+		})
+		if err != nil {
+			return nil, err
+		}
+		//stok dicek per outlet, stok di outlet lain tidak bisa dijual dari sini
+		if stockAfter < 0 {
+			return nil, fmt.Errorf("%w: stok %s di outlet ini tidak cukup, tersisa %d", ErrValidation, d.ProductName, stockAfter+d.Quantity)
+		}
+
+		details[i].COGSFIFO = cost.FIFO
+		details[i].COGSAverage = cost.Average
+		_, err = tx.Exec("UPDATE transaction_details SET cogs_fifo = $1, cogs_average = $2 WHERE id = $3", cost.FIFO, cost.Average, d.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		details[i].Batches, err = consumeBatchesFEFO(tx, outletID, d.ProductID, d.ProductName, d.Quantity, stockAfter)
+		if err != nil {
+			return nil, err
+		}
+		for _, b := range details[i].Batches {
+			_, err = tx.Exec("INSERT INTO transaction_detail_batches (transaction_detail_id, batch_id, quantity) VALUES ($1, $2, $3)",
+				d.ID, b.BatchID, b.Quantity)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		//reorder point berlaku untuk stok total semua outlet. Hanya yang baru melewati reorder point,
+		//supaya alert tidak terkirim di setiap penjualan
+		var p models.LowStockProduct
+		err = tx.QueryRow("SELECT "+lowStockColumns+" FROM products AS p JOIN categories AS c ON c.id = p.category_id WHERE p.id = $1",
+			d.ProductID).Scan(&p.ProductID, &p.SKU, &p.Name, &p.CategoryID, &p.CategoryName, &p.Stock, &p.ReorderPoint, &p.ReorderQuantity)
+		if err != nil {
+			return nil, err
+		}
+		if p.ReorderPoint > 0 && p.Stock <= p.ReorderPoint && p.Stock+d.Quantity > p.ReorderPoint {
+			lowStock = append(lowStock, p)
+		}
 	}
 
 	// for i, detail := range details {
@@ -98,48 +194,57 @@ func (repo *TransactionRepository) CreateTransaction(items []models.CheckoutItem
 	}
 
 	return &models.Transaction{
-		ID:          transactionID,
-		TotalAmount: totalAmount,
-		Details:     details,
+		ID:              transactionID,
+		OutletID:        outletID,
+		CustomerGroupID: req.CustomerGroupID,
+		TotalAmount:     totalAmount,
+		Details:         details,
+		LowStock:        lowStock,
 	}, nil
 }
 
-func (repo *TransactionRepository) GenerateReport(fromDate *time.Time, toDate *time.Time) (*models.Report, error) {
-	start := time.Date(fromDate.Year(), fromDate.Month(), fromDate.Day(), 0, 0, 0, 0, fromDate.Location())
-	var end time.Time
-	if toDate == nil {
-		end = time.Date(fromDate.Year(), fromDate.Month(), fromDate.Day(), 23, 59, 59, 0, fromDate.Location())
-	} else {
-		end = time.Date(toDate.Year(), toDate.Month(), toDate.Day(), 23, 59, 59, 0, toDate.Location())
+// GenerateReport menghitung penjualan di rentang setengah terbuka [start, end). start dan end adalah
+// waktu absolut (sudah dihitung di zona waktu toko oleh service), created_at dibandingkan sebagai timestamptz.
+func (repo *TransactionRepository) GenerateReport(start time.Time, end time.Time, outletID *int) (*models.Report, error) {
+	args := []interface{}{start, end}
+	outletFilter := ""
+	if outletID != nil {
+		if err := ensureOutletExists(repo.db, *outletID); err != nil {
+			return nil, err
+		}
+		args = append(args, *outletID)
+		outletFilter = " AND t.outlet_id = $3"
 	}
 
-	report := &models.Report{}
+	report := &models.Report{OutletID: outletID}
 	querySummary := `
         SELECT 
             COALESCE(SUM(total_amount), 0), 
             COUNT(id)
-        FROM transactions 
-        WHERE created_at BETWEEN $1 AND $2`
-	fmt.Println(querySummary, start, end)
-	err := repo.db.QueryRow(querySummary, start, end).Scan(
+        FROM transactions AS t
+        WHERE created_at >= $1::timestamptz AND created_at < $2::timestamptz` + outletFilter
+	err := repo.db.QueryRow(querySummary, args...).Scan(
 		&report.TotalRevenue,
 		&report.TotalTransaction,
 	)
 	if err != nil {
 		return nil, err
 	}
+	if report.TotalTransaction > 0 {
+		report.AverageBasket = report.TotalRevenue / report.TotalTransaction
+	}
 
 	queryPopular := `
         SELECT p.name, SUM(td.quantity) as total_sold
         	FROM transaction_details td
         	JOIN transactions t ON td.transaction_id = t.id
         	JOIN products p ON td.product_id = p.id
-        	WHERE t.created_at BETWEEN $1 AND $2
+        	WHERE t.created_at >= $1::timestamptz AND t.created_at < $2::timestamptz` + outletFilter + `
         	GROUP BY p.id, p.name
-        	ORDER BY total_sold DESC
+        	ORDER BY total_sold DESC, p.name, p.id
         	LIMIT 1`
 
-	err = repo.db.QueryRow(queryPopular, start, end).Scan(
+	err = repo.db.QueryRow(queryPopular, args...).Scan(
 		&report.PopularProduct.ProductName,
 		&report.PopularProduct.Quantity,
 	)
@@ -151,5 +256,120 @@ func (repo *TransactionRepository) GenerateReport(fromDate *time.Time, toDate *t
 		return nil, err
 	}
 
+	if outletID == nil {
+		report.Outlets, err = repo.salesPerOutlet(start, end)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return report, nil
 }
+
+// salesPerOutlet adalah rincian laporan gabungan. Outlet tanpa transaksi tetap ditampilkan dengan nilai 0.
+func (repo *TransactionRepository) salesPerOutlet(start time.Time, end time.Time) ([]models.OutletSales, error) {
+	rows, err := repo.db.Query(`SELECT o.id, o.name, COALESCE(SUM(t.total_amount), 0), COUNT(t.id)
+				FROM outlets AS o
+				LEFT JOIN transactions AS t ON t.outlet_id = o.id AND t.created_at >= $1::timestamptz AND t.created_at < $2::timestamptz
+				WHERE o.archived_at IS NULL OR t.id IS NOT NULL
+				GROUP BY o.id, o.name
+				ORDER BY o.name, o.id`, start, end)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	outlets := make([]models.OutletSales, 0)
+	for rows.Next() {
+		var o models.OutletSales
+		if err := rows.Scan(&o.OutletID, &o.OutletName, &o.TotalRevenue, &o.TotalTransaction); err != nil {
+			return nil, err
+		}
+		outlets = append(outlets, o)
+	}
+
+	return outlets, rows.Err()
+}
+
+// GetSalesByCategory menjumlahkan penjualan [start, end) dan periode pembanding [prevStart, start) per kategori
+// dalam satu query. Kategori aktif tanpa penjualan tetap muncul dengan nilai 0.
+func (repo *TransactionRepository) GetSalesByCategory(prevStart time.Time, start time.Time, end time.Time, outletID *int) ([]models.CategorySales, error) {
+	args := []interface{}{prevStart, start, end}
+	outletFilter := ""
+	if outletID != nil {
+		if err := ensureOutletExists(repo.db, *outletID); err != nil {
+			return nil, err
+		}
+		args = append(args, *outletID)
+		outletFilter = " AND t.outlet_id = $4"
+	}
+
+	query := `WITH sales AS (
+					SELECT p.category_id,
+						SUM(td.quantity) FILTER (WHERE t.created_at >= $2::timestamptz) AS quantity,
+						SUM(td.subtotal) FILTER (WHERE t.created_at >= $2::timestamptz) AS revenue,
+						SUM(td.quantity) FILTER (WHERE t.created_at < $2::timestamptz) AS previous_quantity,
+						SUM(td.subtotal) FILTER (WHERE t.created_at < $2::timestamptz) AS previous_revenue
+					FROM transaction_details AS td
+					JOIN transactions AS t ON t.id = td.transaction_id
+					JOIN products AS p ON p.id = td.product_id
+					WHERE t.created_at >= $1::timestamptz AND t.created_at < $3::timestamptz` + outletFilter + `
+					GROUP BY p.category_id
+				)
+				SELECT c.id, c.name, COALESCE(s.quantity, 0), COALESCE(s.revenue, 0),
+					COALESCE(s.previous_quantity, 0), COALESCE(s.previous_revenue, 0)
+				FROM categories AS c
+				LEFT JOIN sales AS s ON s.category_id = c.id
+				WHERE c.archived_at IS NULL OR s.category_id IS NOT NULL
+				ORDER BY COALESCE(s.revenue, 0) DESC, c.name, c.id`
+
+	rows, err := repo.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	categories := make([]models.CategorySales, 0)
+	for rows.Next() {
+		var c models.CategorySales
+		err := rows.Scan(&c.CategoryID, &c.CategoryName, &c.Quantity, &c.Revenue, &c.PreviousQuantity, &c.PreviousRevenue)
+		if err != nil {
+			return nil, err
+		}
+		categories = append(categories, c)
+	}
+
+	return categories, rows.Err()
+}
+
+// GetReportTimezone mengembalikan timezone outlet, atau outlet default kalau outletID kosong (laporan gabungan).
+// String kosong berarti outlet tidak punya timezone sendiri.
+func (repo *TransactionRepository) GetReportTimezone(outletID *int) (string, error) {
+	var timezone string
+	var err error
+	if outletID != nil {
+		err = repo.db.QueryRow("SELECT COALESCE(timezone, '') FROM outlets WHERE id = $1", *outletID).Scan(&timezone)
+	} else {
+		err = repo.db.QueryRow("SELECT COALESCE(timezone, '') FROM outlets WHERE is_default").Scan(&timezone)
+	}
+	if err == sql.ErrNoRows {
+		if outletID != nil {
+			return "", fmt.Errorf("%w: outlet %d", ErrNotFound, *outletID)
+		}
+		return "", nil
+	}
+	return timezone, err
+}
+
+// ensureOutletExists untuk filter laporan, outlet yang sudah diarsipkan tetap boleh dilaporkan.
+func ensureOutletExists(q dbExecutor, outletID int) error {
+	var exists bool
+	err := q.QueryRow("SELECT EXISTS (SELECT 1 FROM outlets WHERE id = $1)", outletID).Scan(&exists)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("%w: outlet %d", ErrNotFound, outletID)
+	}
+	return nil
+}
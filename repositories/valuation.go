@@ -0,0 +1,82 @@
+package repositories
+
+import (
+	"fmt"
+	"kasir-api/models"
+)
+
+// GetInventoryValuation menghitung nilai persediaan per akhir hari asOf (YYYY-MM-DD).
+// Jumlah dan harga rata-rata diambil dari baris ledger terakhir sebelum batas, nilai FIFO dari sisa
+// lapisan harga pokok setelah dikurangi pemakaian sebelum batas. Keduanya memakai data yang sama
+// dengan HPP yang dicatat di transaction_details.
+func (repo *StockRepository) GetInventoryValuation(asOf string, categoryID *int) (*models.InventoryValuation, error) {
+	query := `WITH last_movement AS (
+					SELECT DISTINCT ON (product_id) product_id, stock_after, average_cost
+					FROM stock_movements
+					WHERE created_at < $1::date + 1
+					ORDER BY product_id, created_at DESC, id DESC
+				), fifo AS (
+					SELECT cl.product_id, SUM((cl.quantity - COALESCE(used.quantity, 0))::bigint * cl.unit_cost) AS value
+					FROM cost_layers AS cl
+					LEFT JOIN (
+						SELECT cost_layer_id, SUM(quantity) AS quantity
+						FROM cost_layer_consumptions
+						WHERE cost_layer_id IS NOT NULL AND created_at < $1::date + 1
+						GROUP BY cost_layer_id
+					) AS used ON used.cost_layer_id = cl.id
+					WHERE cl.created_at < $1::date + 1
+					GROUP BY cl.product_id
+				)
+				SELECT p.id, COALESCE(p.sku, ''), p.name, p.category_id, c.name,
+					lm.stock_after, COALESCE(lm.average_cost, p.cost_price), COALESCE(f.value, 0)
+				FROM products AS p
+				JOIN categories AS c ON c.id = p.category_id
+				JOIN last_movement AS lm ON lm.product_id = p.id
+				LEFT JOIN fifo AS f ON f.product_id = p.id
+				WHERE (lm.stock_after <> 0 OR COALESCE(f.value, 0) <> 0)`
+	args := []interface{}{asOf}
+	if categoryID != nil {
+		args = append(args, *categoryID)
+		query += fmt.Sprintf(" AND p.category_id = $%d", len(args))
+	}
+	query += " ORDER BY c.name, c.id, p.name, p.id"
+
+	rows, err := repo.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	report := &models.InventoryValuation{
+		AsOf:       asOf,
+		Categories: make([]models.CategoryValuation, 0),
+		Products:   make([]models.ProductValuation, 0),
+	}
+	for rows.Next() {
+		var v models.ProductValuation
+		err := rows.Scan(&v.ProductID, &v.SKU, &v.ProductName, &v.CategoryID, &v.CategoryName,
+			&v.Quantity, &v.AverageCost, &v.FIFOValue)
+		if err != nil {
+			return nil, err
+		}
+		//stok minus tidak punya nilai, sama seperti perhitungan harga pokok saat barang masuk
+		v.AverageValue = max(v.Quantity, 0) * v.AverageCost
+		report.Products = append(report.Products, v)
+
+		//baris sudah urut per kategori, jadi cukup bandingkan dengan kategori terakhir
+		n := len(report.Categories)
+		if n == 0 || report.Categories[n-1].CategoryID != v.CategoryID {
+			report.Categories = append(report.Categories, models.CategoryValuation{CategoryID: v.CategoryID, CategoryName: v.CategoryName})
+			n++
+		}
+		report.Categories[n-1].Quantity += v.Quantity
+		report.Categories[n-1].FIFOValue += v.FIFOValue
+		report.Categories[n-1].AverageValue += v.AverageValue
+
+		report.TotalQuantity += v.Quantity
+		report.TotalFIFOValue += v.FIFOValue
+		report.TotalAverageValue += v.AverageValue
+	}
+
+	return report, rows.Err()
+}
@@ -2,44 +2,213 @@ package routes
 
 import (
 	"database/sql"
+	"kasir-api/config"
+	"kasir-api/database"
 	"kasir-api/handlers"
+	"kasir-api/models"
+	"kasir-api/notifications"
 	"kasir-api/repositories"
 	"kasir-api/services"
+	"kasir-api/storage"
 	"kasir-api/utils"
+	"log"
 	"net/http"
+	"path/filepath"
+	"time"
 
 	httpSwagger "github.com/swaggo/http-swagger"
 )
 
-func RegisterAllRoutes(db *sql.DB) {
+// RegisterAllRoutes: db dipakai untuk tabel tenants saja, data tenant diakses lewat koneksi dari tenantPool.
+// reportNotifiers dipakai bersama dengan scheduler laporan.
+func RegisterAllRoutes(db *sql.DB, tenantPool *database.TenantPool, reportNotifiers notifications.ReportNotifiers, cfg config.Config) {
 	http.Handle("/swagger/", httpSwagger.WrapHandler)
 
+	uploads := storage.NewLocalStorage(cfg.UploadDir, "/uploads")
+	http.Handle("/uploads/", http.StripPrefix("/uploads", uploads.FileServer()))
+
+	storeLocation, err := time.LoadLocation(cfg.StoreTimezone)
+	if err != nil {
+		log.Fatal("STORE_TIMEZONE tidak valid: ", err)
+	}
+
+	tenantRepo := repositories.NewTenantRepository(db)
+	tenantService := services.NewTenantService(tenantRepo, cfg.TenantRequireToken)
+	tenantHandler := handlers.NewTenantHandler(tenantService, cfg.AdminAPIKey)
+
+	http.HandleFunc("/api/admin/tenants", tenantHandler.HandleTenants)
+	http.HandleFunc("/api/admin/tenants/{id}", tenantHandler.HandleTenantByID)
+	http.HandleFunc("/api/admin/tenants/{id}/rotate-token", tenantHandler.HandleRotateTenantToken)
+
+	http.Handle("/api/", newTenantRouter(tenantService, tenantPool, storeLocation, reportNotifiers, cfg))
+
+	http.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
+		healthCheck(w, r)
+	})
+}
+
+func newTenantRouter(tenantService *services.TenantService, tenantPool *database.TenantPool, storeLocation *time.Location, reportNotifiers notifications.ReportNotifiers, cfg config.Config) *handlers.TenantRouter {
+	return handlers.NewTenantRouter(tenantService, cfg.MultiTenant, cfg.TenantBaseDomain,
+		func(tenant *models.Tenant) (http.Handler, error) {
+			tenantDB, err := tenantPool.DB(tenant.ID)
+			if err != nil {
+				return nil, err
+			}
+
+			mux := http.NewServeMux()
+			registerTenantRoutes(mux, tenantDB, tenant, storeLocation, reportNotifiers, cfg)
+			return mux, nil
+		})
+}
+
+// registerTenantRoutes mendaftarkan semua endpoint data toko untuk satu tenant. Semua repository
+// memakai db milik tenant, jadi isolasinya dijaga oleh row level security di database.
+func registerTenantRoutes(mux *http.ServeMux, db *sql.DB, tenant *models.Tenant, storeLocation *time.Location, reportNotifiers notifications.ReportNotifiers, cfg config.Config) {
+	//file upload tenant lain dipisah per folder supaya nama file tidak bentrok
+	imageStorage := storage.NewLocalStorage(cfg.UploadDir, "/uploads")
+	if tenant.ID != models.DefaultTenantID {
+		imageStorage = storage.NewLocalStorage(filepath.Join(cfg.UploadDir, "tenants", tenant.Code), "/uploads/tenants/"+tenant.Code)
+	}
+
 	categoryRepo := repositories.NewCategoryRepository(db)
 	categoryService := services.NewCategoryService(categoryRepo)
 	categoryHandler := handlers.NewCategoryHandler(categoryService)
 
-	http.HandleFunc("/api/categories", categoryHandler.HandleCategories)
-	http.HandleFunc("/api/categories/", categoryHandler.HandleCategoryByID)
+	mux.HandleFunc("/api/categories", categoryHandler.HandleCategories)
+	mux.HandleFunc("/api/categories/", categoryHandler.HandleCategoryByID)
+	mux.HandleFunc("/api/categories/{id}/restore", categoryHandler.HandleRestoreCategory)
 
 	productRepo := repositories.NewProductRepository(db)
 	productService := services.NewProductService(productRepo)
-	productHandler := handlers.NewProductHandler(productService)
+	productHandler := handlers.NewProductHandler(productService, cfg.MaxImportSize)
+
+	mux.HandleFunc("/api/produk", productHandler.HandleProducts)
+	mux.HandleFunc("/api/produk/", productHandler.HandleProductByID)
+	mux.HandleFunc("/api/produk/import", productHandler.HandleImportProducts)
+	mux.HandleFunc("/api/produk/export", productHandler.HandleExportProducts)
+	mux.HandleFunc("/api/produk/search", productHandler.HandleSearchProducts)
+	mux.HandleFunc("/api/categories/{id}/produk", productHandler.GetAllProductsByCategoryID)
+	mux.HandleFunc("/api/produk/{id}/restore", productHandler.HandleRestoreProduct)
+
+	priceRepo := repositories.NewPriceRepository(db)
+	priceService := services.NewPriceService(priceRepo)
+	priceHandler := handlers.NewPriceHandler(priceService)
 
-	http.HandleFunc("/api/produk", productHandler.HandleProducts)
-	http.HandleFunc("/api/produk/", productHandler.HandleProductByID)
-	http.HandleFunc("/api/categories/{id}/produk", productHandler.GetAllProductsByCategoryID)
+	mux.HandleFunc("/api/produk/{id}/price-history", priceHandler.HandlePriceHistory)
+	mux.HandleFunc("/api/produk/{id}/scheduled-prices", priceHandler.HandleScheduledPrices)
+	mux.HandleFunc("/api/scheduled-prices/{id}", priceHandler.HandleScheduledPriceByID)
+	mux.HandleFunc("/api/produk/{id}/price-tiers", priceHandler.HandlePriceTiers)
+	mux.HandleFunc("/api/price-tiers/{id}", priceHandler.HandlePriceTierByID)
+
+	imageRepo := repositories.NewImageRepository(db)
+	imageService := services.NewImageService(imageRepo, imageStorage)
+	imageHandler := handlers.NewImageHandler(imageService, cfg.MaxImageSize)
+
+	mux.HandleFunc("/api/produk/{id}/images", imageHandler.HandleProductImages)
+	mux.HandleFunc("/api/produk/{id}/images/{imageId}", imageHandler.HandleProductImageByID)
+
+	stockRepo := repositories.NewStockRepository(db)
+	stockService := services.NewStockService(stockRepo, cfg.StockAdjustmentRequiresApproval, cfg.StockAdjustmentApprovers, cfg.ReorderWindowDays)
+	stockHandler := handlers.NewStockHandler(stockService)
+
+	mux.HandleFunc("/api/produk/{id}/stock-movements", stockHandler.HandleStockMovements)
+	mux.HandleFunc("/api/stock/consistency", stockHandler.HandleStockConsistency)
+	mux.HandleFunc("/api/stock/low", stockHandler.HandleLowStock)
+	mux.HandleFunc("/api/stock/expiring", stockHandler.HandleExpiringBatches)
+	mux.HandleFunc("/api/produk/{id}/batches", stockHandler.HandleProductBatches)
+	mux.HandleFunc("/api/stock/reorder-suggestions", stockHandler.HandleReorderSuggestions)
+	mux.HandleFunc("/api/stock/valuation", stockHandler.HandleInventoryValuation)
+	mux.HandleFunc("/api/stock/in-transit", stockHandler.HandleInTransitStock)
+	mux.HandleFunc("/api/stock/adjustments", stockHandler.HandleStockAdjustments)
+	mux.HandleFunc("/api/stock/adjustments/{id}", stockHandler.HandleStockAdjustmentByID)
+	mux.HandleFunc("/api/stock/adjustments/{id}/approve", stockHandler.HandleApproveStockAdjustment)
+	mux.HandleFunc("/api/stock/adjustments/{id}/reject", stockHandler.HandleRejectStockAdjustment)
+	mux.HandleFunc("/api/stock-takes", stockHandler.HandleStockTakes)
+	mux.HandleFunc("/api/stock-takes/{id}", stockHandler.HandleStockTakeByID)
+	mux.HandleFunc("/api/stock-takes/{id}/counts", stockHandler.HandleStockTakeCounts)
+	mux.HandleFunc("/api/stock-takes/{id}/variance", stockHandler.HandleStockTakeVariance)
+	mux.HandleFunc("/api/stock-takes/{id}/finalize", stockHandler.HandleFinalizeStockTake)
+	mux.HandleFunc("/api/stock-takes/{id}/cancel", stockHandler.HandleCancelStockTake)
+	mux.HandleFunc("/api/stock-transfers", stockHandler.HandleStockTransfers)
+	mux.HandleFunc("/api/stock-transfers/{id}", stockHandler.HandleStockTransferByID)
+	mux.HandleFunc("/api/stock-transfers/{id}/ship", stockHandler.HandleShipStockTransfer)
+	mux.HandleFunc("/api/stock-transfers/{id}/receive", stockHandler.HandleReceiveStockTransfer)
+	mux.HandleFunc("/api/stock-transfers/{id}/cancel", stockHandler.HandleCancelStockTransfer)
+
+	outletRepo := repositories.NewOutletRepository(db)
+	outletService := services.NewOutletService(outletRepo)
+	outletHandler := handlers.NewOutletHandler(outletService)
+
+	mux.HandleFunc("/api/outlets", outletHandler.HandleOutlets)
+	mux.HandleFunc("/api/outlets/{id}", outletHandler.HandleOutletByID)
+	mux.HandleFunc("/api/outlets/{id}/stock", outletHandler.HandleOutletStocks)
+	mux.HandleFunc("/api/outlets/{id}/prices", outletHandler.HandleOutletPrices)
+	mux.HandleFunc("/api/outlets/{id}/prices/{product_id}", outletHandler.HandleOutletPriceByProduct)
+
+	supplierRepo := repositories.NewSupplierRepository(db)
+	supplierService := services.NewSupplierService(supplierRepo)
+	supplierHandler := handlers.NewSupplierHandler(supplierService)
+
+	mux.HandleFunc("/api/suppliers", supplierHandler.HandleSuppliers)
+	mux.HandleFunc("/api/suppliers/{id}", supplierHandler.HandleSupplierByID)
+
+	purchaseRepo := repositories.NewPurchaseRepository(db)
+	purchaseService := services.NewPurchaseService(purchaseRepo)
+	purchaseHandler := handlers.NewPurchaseHandler(purchaseService)
+
+	mux.HandleFunc("/api/purchase-orders", purchaseHandler.HandlePurchaseOrders)
+	mux.HandleFunc("/api/purchase-orders/{id}", purchaseHandler.HandlePurchaseOrderByID)
+	mux.HandleFunc("/api/purchase-orders/{id}/send", purchaseHandler.HandleSendPurchaseOrder)
+	mux.HandleFunc("/api/purchase-orders/{id}/receive", purchaseHandler.HandleReceivePurchaseOrder)
+	mux.HandleFunc("/api/purchase-orders/{id}/cancel", purchaseHandler.HandleCancelPurchaseOrder)
+	mux.HandleFunc("/api/goods-receipts", purchaseHandler.HandleGoodsReceipts)
+	mux.HandleFunc("/api/goods-receipts/{id}", purchaseHandler.HandleGoodsReceiptByID)
+	mux.HandleFunc("/api/supplier-returns", purchaseHandler.HandleSupplierReturns)
+	mux.HandleFunc("/api/supplier-returns/{id}", purchaseHandler.HandleSupplierReturnByID)
+
+	customerGroupRepo := repositories.NewCustomerGroupRepository(db)
+	customerGroupService := services.NewCustomerGroupService(customerGroupRepo)
+	customerGroupHandler := handlers.NewCustomerGroupHandler(customerGroupService)
+
+	mux.HandleFunc("/api/customer-groups", customerGroupHandler.HandleCustomerGroups)
+	mux.HandleFunc("/api/customer-groups/", customerGroupHandler.HandleCustomerGroupByID)
 
 	transactionRepo := repositories.NewTransactionRepository(db)
-	transactionService := services.NewTransactionService(transactionRepo)
+	var lowStockWebhook *notifications.Webhook
+	if cfg.LowStockWebhookURL != "" {
+		lowStockWebhook = notifications.NewWebhook(cfg.LowStockWebhookURL)
+		if cfg.MultiTenant {
+			lowStockWebhook = lowStockWebhook.WithTenant(tenant.Code)
+		}
+	}
+	reportBrand := cfg.ReportBrandName
+	if tenant.ID != models.DefaultTenantID {
+		reportBrand = tenant.Name
+	}
+	transactionService := services.NewTransactionService(transactionRepo, lowStockWebhook, storeLocation, reportBrand)
 	transactionHandler := handlers.NewTransactionHandler(transactionService)
 
-	http.HandleFunc("/api/checkout", transactionHandler.HandleCheckout)
-	http.HandleFunc("/api/report/hari-ini", transactionHandler.GetReportToday)
-	http.HandleFunc("/api/report", transactionHandler.GetReportByDate)
+	mux.HandleFunc("/api/checkout", transactionHandler.HandleCheckout)
+	mux.HandleFunc("/api/report/hari-ini", transactionHandler.GetReportToday)
+	mux.HandleFunc("/api/report", transactionHandler.GetReportByDate)
+	mux.HandleFunc("/api/report/sales-timeseries", transactionHandler.HandleSalesTimeseries)
+	mux.HandleFunc("/api/report/product-rankings", transactionHandler.HandleProductRankings)
+	mux.HandleFunc("/api/report/sales-by-category", transactionHandler.HandleSalesByCategory)
 
-	http.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
-		healthCheck(w, r)
-	})
+	//hasil laporan terjadwal tenant lain dipisah per folder, sama seperti file upload
+	reportDir, reportTenant := cfg.ReportDir, ""
+	if tenant.ID != models.DefaultTenantID {
+		reportDir, reportTenant = filepath.Join(cfg.ReportDir, "tenants", tenant.Code), tenant.Code
+	}
+	reportJobRepo := repositories.NewReportJobRepository(db)
+	reportJobService := services.NewReportJobService(reportJobRepo, transactionService, reportNotifiers, reportDir, reportTenant)
+	reportJobHandler := handlers.NewReportJobHandler(reportJobService)
+
+	mux.HandleFunc("/api/report-jobs", reportJobHandler.HandleReportJobs)
+	mux.HandleFunc("/api/report-jobs/{id}", reportJobHandler.HandleReportJobByID)
+	mux.HandleFunc("/api/report-jobs/{id}/run", reportJobHandler.HandleRunReportJob)
+	mux.HandleFunc("/api/report-jobs/{id}/runs", reportJobHandler.HandleReportRuns)
+	mux.HandleFunc("/api/report-runs/{id}/download", reportJobHandler.HandleDownloadReportRun)
 }
 
 // healthCheck godoc
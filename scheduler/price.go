@@ -0,0 +1,68 @@
+package scheduler
+
+import (
+	"context"
+	"kasir-api/database"
+	"kasir-api/repositories"
+	"kasir-api/services"
+	"log"
+	"time"
+)
+
+// PriceScheduler menerapkan jadwal harga untuk semua tenant aktif, masing-masing lewat koneksi tenant-nya.
+type PriceScheduler struct {
+	tenants    *services.TenantService
+	tenantPool *database.TenantPool
+	interval   time.Duration
+}
+
+func NewPriceScheduler(tenants *services.TenantService, tenantPool *database.TenantPool, interval time.Duration) *PriceScheduler {
+	return &PriceScheduler{tenants: tenants, tenantPool: tenantPool, interval: interval}
+}
+
+// Start menjalankan pengecekan jadwal harga di background sampai ctx dibatalkan.
+func (s *PriceScheduler) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+
+		s.run()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				s.run()
+			}
+		}
+	}()
+}
+
+func (s *PriceScheduler) run() {
+	tenants, err := s.tenants.GetAllTenants(false)
+	if err != nil {
+		log.Println("gagal mengambil daftar tenant:", err)
+		return
+	}
+
+	for _, tenant := range tenants {
+		db, err := s.tenantPool.DB(tenant.ID)
+		if err != nil {
+			log.Printf("tenant %s: gagal membuka koneksi: %v", tenant.Code, err)
+			continue
+		}
+
+		service := services.NewPriceService(repositories.NewPriceRepository(db))
+		applied, failed, err := service.ApplyDuePriceChanges(time.Now())
+		if err != nil {
+			log.Printf("tenant %s: gagal menerapkan jadwal harga: %v", tenant.Code, err)
+			continue
+		}
+		if applied > 0 {
+			log.Printf("tenant %s: %d jadwal harga diterapkan", tenant.Code, applied)
+		}
+		if failed > 0 {
+			log.Printf("tenant %s: %d jadwal harga gagal, lihat kolom error", tenant.Code, failed)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package scheduler
+
+import (
+	"context"
+	"database/sql"
+	"kasir-api/config"
+	"kasir-api/database"
+	"kasir-api/models"
+	"kasir-api/notifications"
+	"kasir-api/repositories"
+	"kasir-api/services"
+	"log"
+	"path/filepath"
+	"time"
+)
+
+// ReportScheduler menjalankan laporan terjadwal yang sudah waktunya untuk semua tenant aktif.
+// Aman dijalankan di lebih dari satu instance karena setiap job di-claim dulu sebelum dijalankan.
+type ReportScheduler struct {
+	tenants       *services.TenantService
+	tenantPool    *database.TenantPool
+	notifiers     notifications.ReportNotifiers
+	storeLocation *time.Location
+	cfg           config.Config
+}
+
+func NewReportScheduler(tenants *services.TenantService, tenantPool *database.TenantPool, notifiers notifications.ReportNotifiers, cfg config.Config) (*ReportScheduler, error) {
+	storeLocation, err := time.LoadLocation(cfg.StoreTimezone)
+	if err != nil {
+		return nil, err
+	}
+	return &ReportScheduler{tenants: tenants, tenantPool: tenantPool, notifiers: notifiers, storeLocation: storeLocation, cfg: cfg}, nil
+}
+
+// Start menjalankan pengecekan laporan terjadwal di background sampai ctx dibatalkan.
+func (s *ReportScheduler) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(s.cfg.ReportSchedulerInterval)
+		defer ticker.Stop()
+
+		s.run()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				s.run()
+			}
+		}
+	}()
+}
+
+func (s *ReportScheduler) run() {
+	tenants, err := s.tenants.GetAllTenants(false)
+	if err != nil {
+		log.Println("gagal mengambil daftar tenant:", err)
+		return
+	}
+
+	now := time.Now()
+	for _, tenant := range tenants {
+		db, err := s.tenantPool.DB(tenant.ID)
+		if err != nil {
+			log.Printf("tenant %s: gagal membuka koneksi: %v", tenant.Code, err)
+			continue
+		}
+
+		ran, err := s.service(tenant, db).RunDueJobs(now)
+		if err != nil {
+			log.Printf("tenant %s: gagal menjalankan laporan terjadwal: %v", tenant.Code, err)
+			continue
+		}
+		if ran > 0 {
+			log.Printf("tenant %s: %d laporan terjadwal dijalankan", tenant.Code, ran)
+		}
+	}
+}
+
+// service dibuat dengan aturan yang sama seperti di routes: tenant selain default memakai nama tenant
+// di kop laporan dan folder hasil sendiri.
+func (s *ReportScheduler) service(tenant *models.Tenant, db *sql.DB) *services.ReportJobService {
+	brand, reportDir, reportTenant := s.cfg.ReportBrandName, s.cfg.ReportDir, ""
+	if tenant.ID != models.DefaultTenantID {
+		brand = tenant.Name
+		reportDir, reportTenant = filepath.Join(s.cfg.ReportDir, "tenants", tenant.Code), tenant.Code
+	}
+
+	transactions := services.NewTransactionService(repositories.NewTransactionRepository(db), nil, s.storeLocation, brand)
+	return services.NewReportJobService(repositories.NewReportJobRepository(db), transactions, s.notifiers, reportDir, reportTenant)
+}
@@ -13,8 +13,8 @@ func NewCategoryService(repo *repositories.CategoryRepository) *CategoryService
 	return &CategoryService{repo: repo}
 }
 
-func (s *CategoryService) GetAllCategories() ([]*models.Category, error) {
-	return s.repo.GetAllCategories()
+func (s *CategoryService) GetAllCategories(includeArchived bool) ([]*models.Category, error) {
+	return s.repo.GetAllCategories(includeArchived)
 }
 
 func (s *CategoryService) GetCategoryByID(id int) (*models.Category, error) {
@@ -29,6 +29,10 @@ func (s *CategoryService) UpdateCategory(category *models.Category) error {
 	return s.repo.UpdateCategory(category)
 }
 
-func (s *CategoryService) DeleteCategory(id int) error {
-	return s.repo.DeleteCategory(id)
+func (s *CategoryService) ArchiveCategory(id int, force bool) error {
+	return s.repo.ArchiveCategory(id, force)
+}
+
+func (s *CategoryService) RestoreCategory(id int) error {
+	return s.repo.RestoreCategory(id)
 }
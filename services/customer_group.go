@@ -0,0 +1,34 @@
+package services
+
+import (
+	"kasir-api/models"
+	"kasir-api/repositories"
+)
+
+type CustomerGroupService struct {
+	repo *repositories.CustomerGroupRepository
+}
+
+func NewCustomerGroupService(repo *repositories.CustomerGroupRepository) *CustomerGroupService {
+	return &CustomerGroupService{repo: repo}
+}
+
+func (s *CustomerGroupService) GetAllCustomerGroups() ([]*models.CustomerGroup, error) {
+	return s.repo.GetAllCustomerGroups()
+}
+
+func (s *CustomerGroupService) GetCustomerGroupByID(id int) (*models.CustomerGroup, error) {
+	return s.repo.GetCustomerGroupByID(id)
+}
+
+func (s *CustomerGroupService) CreateCustomerGroup(group *models.CustomerGroup) error {
+	return s.repo.CreateCustomerGroup(group)
+}
+
+func (s *CustomerGroupService) UpdateCustomerGroup(group *models.CustomerGroup) error {
+	return s.repo.UpdateCustomerGroup(group)
+}
+
+func (s *CustomerGroupService) DeleteCustomerGroup(id int) error {
+	return s.repo.DeleteCustomerGroup(id)
+}
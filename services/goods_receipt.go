@@ -0,0 +1,84 @@
+package services
+
+import (
+	"fmt"
+	"kasir-api/models"
+	"kasir-api/repositories"
+)
+
+func (s *PurchaseService) CreateGoodsReceipt(req models.GoodsReceiptRequest, receivedBy string) (*models.GoodsReceipt, error) {
+	if req.SupplierID <= 0 {
+		return nil, fmt.Errorf("%w: supplier_id wajib diisi", repositories.ErrValidation)
+	}
+	if err := validateReceiptLines(req.Lines, true); err != nil {
+		return nil, err
+	}
+
+	receipt := &models.GoodsReceipt{
+		SupplierID: req.SupplierID,
+		OutletID:   req.OutletID,
+		Reference:  req.Reference,
+		Note:       req.Note,
+		ReceivedBy: receivedBy,
+		Lines:      req.Lines,
+	}
+	if err := s.repo.CreateGoodsReceipt(receipt); err != nil {
+		return nil, err
+	}
+
+	return receipt, nil
+}
+
+func (s *PurchaseService) GetGoodsReceipts(supplierID *int) ([]models.GoodsReceipt, error) {
+	return s.repo.GetGoodsReceipts(supplierID)
+}
+
+func (s *PurchaseService) GetGoodsReceiptByID(id int) (*models.GoodsReceipt, error) {
+	return s.repo.GetGoodsReceiptByID(id)
+}
+
+func (s *PurchaseService) CreateSupplierReturn(req models.SupplierReturnRequest, createdBy string) (*models.SupplierReturn, error) {
+	if req.SupplierID <= 0 {
+		return nil, fmt.Errorf("%w: supplier_id wajib diisi", repositories.ErrValidation)
+	}
+	if len(req.Lines) == 0 {
+		return nil, fmt.Errorf("%w: minimal satu line", repositories.ErrValidation)
+	}
+
+	seen := make(map[int]bool)
+	for i, line := range req.Lines {
+		if seen[line.ProductID] {
+			return nil, fmt.Errorf("%w: line %d: produk %d muncul lebih dari sekali", repositories.ErrValidation, i+1, line.ProductID)
+		}
+		seen[line.ProductID] = true
+
+		if line.Quantity <= 0 {
+			return nil, fmt.Errorf("%w: line %d: quantity harus lebih dari 0", repositories.ErrValidation, i+1)
+		}
+		if line.UnitCost != nil && *line.UnitCost < 0 {
+			return nil, fmt.Errorf("%w: line %d: unit_cost tidak boleh negatif", repositories.ErrValidation, i+1)
+		}
+	}
+
+	ret := &models.SupplierReturn{
+		SupplierID: req.SupplierID,
+		OutletID:   req.OutletID,
+		Reference:  req.Reference,
+		Note:       req.Note,
+		CreatedBy:  createdBy,
+		Lines:      req.Lines,
+	}
+	if err := s.repo.CreateSupplierReturn(ret); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (s *PurchaseService) GetSupplierReturns(supplierID *int) ([]models.SupplierReturn, error) {
+	return s.repo.GetSupplierReturns(supplierID)
+}
+
+func (s *PurchaseService) GetSupplierReturnByID(id int) (*models.SupplierReturn, error) {
+	return s.repo.GetSupplierReturnByID(id)
+}
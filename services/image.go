@@ -0,0 +1,158 @@
+package services
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"image"
+	_ "image/gif"
+	"image/jpeg"
+	"image/png"
+	"kasir-api/models"
+	"kasir-api/repositories"
+	"kasir-api/storage"
+	"kasir-api/utils"
+	"net/http"
+)
+
+const (
+	thumbnailSize      = 300
+	maxImageDimensions = 8000
+	//25 MP, hasil decode RGBA-nya sekitar 100 MB
+	maxImagePixels = 25_000_000
+	//decode gambar besar makan memori, jadi dibatasi untuk semua tenant sekaligus
+	maxConcurrentDecodes = 4
+)
+
+var decodeSlots = make(chan struct{}, maxConcurrentDecodes)
+
+var allowedImageTypes = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/gif":  ".gif",
+}
+
+type ImageService struct {
+	repo    *repositories.ImageRepository
+	storage storage.Storage
+}
+
+func NewImageService(repo *repositories.ImageRepository, storage storage.Storage) *ImageService {
+	return &ImageService{repo: repo, storage: storage}
+}
+
+func (s *ImageService) GetProductImages(productID int) ([]*models.ProductImage, error) {
+	return s.repo.GetProductImages(productID)
+}
+
+// UploadProductImage memvalidasi gambar, membuat thumbnail, lalu menyimpan keduanya ke storage.
+func (s *ImageService) UploadProductImage(productID int, data []byte, primary bool) (*models.ProductImage, error) {
+	exists, err := s.repo.ProductExists(productID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, fmt.Errorf("%w: produk %d tidak ditemukan", repositories.ErrNotFound, productID)
+	}
+
+	contentType := http.DetectContentType(data)
+	ext, ok := allowedImageTypes[contentType]
+	if !ok {
+		return nil, fmt.Errorf("%w: tipe file %s tidak didukung, gunakan JPEG, PNG atau GIF", repositories.ErrValidation, contentType)
+	}
+
+	//cek dimensi dulu sebelum decode penuh supaya tidak kena decompression bomb
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: file gambar tidak valid", repositories.ErrValidation)
+	}
+	if cfg.Width > maxImageDimensions || cfg.Height > maxImageDimensions {
+		return nil, fmt.Errorf("%w: dimensi gambar maksimal %dx%d", repositories.ErrValidation, maxImageDimensions, maxImageDimensions)
+	}
+	if cfg.Width*cfg.Height > maxImagePixels {
+		return nil, fmt.Errorf("%w: gambar maksimal %d megapiksel", repositories.ErrValidation, maxImagePixels/1_000_000)
+	}
+
+	thumb, thumbExt, err := makeThumbnail(data, contentType)
+	if err != nil {
+		return nil, err
+	}
+
+	name, err := randomName()
+	if err != nil {
+		return nil, err
+	}
+
+	img := &models.ProductImage{
+		ProductID:    productID,
+		StorageKey:   fmt.Sprintf("products/%d/%s%s", productID, name, ext),
+		ThumbnailKey: fmt.Sprintf("products/%d/%s_thumb%s", productID, name, thumbExt),
+		ContentType:  contentType,
+		SizeBytes:    int64(len(data)),
+		Width:        cfg.Width,
+		Height:       cfg.Height,
+		IsPrimary:    primary,
+	}
+	img.URL = s.storage.URL(img.StorageKey)
+	img.ThumbnailURL = s.storage.URL(img.ThumbnailKey)
+
+	if err := s.storage.Save(img.StorageKey, bytes.NewReader(data)); err != nil {
+		return nil, err
+	}
+	if err := s.storage.Save(img.ThumbnailKey, thumb); err != nil {
+		s.storage.Delete(img.StorageKey)
+		return nil, err
+	}
+
+	if err := s.repo.CreateProductImage(img); err != nil {
+		s.storage.Delete(img.StorageKey)
+		s.storage.Delete(img.ThumbnailKey)
+		return nil, err
+	}
+
+	return img, nil
+}
+
+func (s *ImageService) DeleteProductImage(productID int, imageID int) error {
+	img, err := s.repo.GetProductImageByID(productID, imageID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.DeleteProductImage(img); err != nil {
+		return err
+	}
+
+	s.storage.Delete(img.StorageKey)
+	s.storage.Delete(img.ThumbnailKey)
+	return nil
+}
+
+// makeThumbnail men-decode gambar penuh, jadi menunggu giliran di decodeSlots dulu.
+func makeThumbnail(data []byte, contentType string) (*bytes.Buffer, string, error) {
+	decodeSlots <- struct{}{}
+	defer func() { <-decodeSlots }()
+
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", fmt.Errorf("%w: file gambar tidak valid", repositories.ErrValidation)
+	}
+
+	var thumb bytes.Buffer
+	if contentType == "image/jpeg" {
+		err = jpeg.Encode(&thumb, utils.Thumbnail(src, thumbnailSize), &jpeg.Options{Quality: 85})
+		return &thumb, ".jpg", err
+	}
+	//png dan gif bisa transparan, thumbnail-nya tetap png
+	err = png.Encode(&thumb, utils.Thumbnail(src, thumbnailSize))
+	return &thumb, ".png", err
+}
+
+func randomName() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
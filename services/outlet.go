@@ -0,0 +1,88 @@
+package services
+
+import (
+	"fmt"
+	"kasir-api/models"
+	"kasir-api/repositories"
+	"strings"
+	"time"
+)
+
+type OutletService struct {
+	repo *repositories.OutletRepository
+}
+
+func NewOutletService(repo *repositories.OutletRepository) *OutletService {
+	return &OutletService{repo: repo}
+}
+
+func (s *OutletService) GetAllOutlets(includeArchived bool) ([]*models.Outlet, error) {
+	return s.repo.GetAllOutlets(includeArchived)
+}
+
+func (s *OutletService) GetOutletByID(id int) (*models.Outlet, error) {
+	return s.repo.GetOutletByID(id)
+}
+
+func (s *OutletService) CreateOutlet(outlet *models.Outlet) error {
+	if err := validateOutlet(outlet); err != nil {
+		return err
+	}
+	return s.repo.CreateOutlet(outlet)
+}
+
+func (s *OutletService) UpdateOutlet(outlet *models.Outlet) error {
+	if err := validateOutlet(outlet); err != nil {
+		return err
+	}
+	return s.repo.UpdateOutlet(outlet)
+}
+
+func (s *OutletService) ArchiveOutlet(id int) error {
+	return s.repo.ArchiveOutlet(id)
+}
+
+func (s *OutletService) GetOutletStocks(outletID int, categoryID *int) ([]models.OutletStock, error) {
+	return s.repo.GetOutletStocks(outletID, categoryID)
+}
+
+func (s *OutletService) GetOutletPrices(outletID int) ([]models.OutletPrice, error) {
+	return s.repo.GetOutletPrices(outletID)
+}
+
+func (s *OutletService) SetOutletPrice(outletID int, productID int, req models.OutletPriceRequest, updatedBy string) (*models.OutletPrice, error) {
+	if req.Price < 0 {
+		return nil, fmt.Errorf("%w: harga tidak boleh negatif", repositories.ErrValidation)
+	}
+
+	price := &models.OutletPrice{OutletID: outletID, ProductID: productID, Price: req.Price, UpdatedBy: updatedBy}
+	if err := s.repo.SetOutletPrice(price); err != nil {
+		return nil, err
+	}
+	return price, nil
+}
+
+func (s *OutletService) DeleteOutletPrice(outletID int, productID int) error {
+	return s.repo.DeleteOutletPrice(outletID, productID)
+}
+
+func validateOutlet(outlet *models.Outlet) error {
+	outlet.Code = strings.ToUpper(strings.TrimSpace(outlet.Code))
+	outlet.Name = strings.TrimSpace(outlet.Name)
+	if outlet.Code == "" || outlet.Name == "" {
+		return fmt.Errorf("%w: kode dan nama outlet wajib diisi", repositories.ErrValidation)
+	}
+	if outlet.Type == "" {
+		outlet.Type = models.OutletTypeStore
+	}
+	if outlet.Type != models.OutletTypeStore && outlet.Type != models.OutletTypeWarehouse {
+		return fmt.Errorf("%w: type harus %s atau %s", repositories.ErrValidation, models.OutletTypeStore, models.OutletTypeWarehouse)
+	}
+	outlet.Timezone = strings.TrimSpace(outlet.Timezone)
+	if outlet.Timezone != "" {
+		if _, err := time.LoadLocation(outlet.Timezone); err != nil {
+			return fmt.Errorf("%w: timezone %q tidak dikenal", repositories.ErrValidation, outlet.Timezone)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package services
+
+import (
+	"errors"
+	"kasir-api/models"
+	"kasir-api/repositories"
+	"time"
+)
+
+type PriceService struct {
+	repo *repositories.PriceRepository
+}
+
+func NewPriceService(repo *repositories.PriceRepository) *PriceService {
+	return &PriceService{repo: repo}
+}
+
+func (s *PriceService) GetPriceHistory(productID int) ([]*models.PriceHistory, error) {
+	return s.repo.GetPriceHistory(productID)
+}
+
+func (s *PriceService) GetScheduledPriceChanges(productID int) ([]*models.ScheduledPriceChange, error) {
+	return s.repo.GetScheduledPriceChanges(productID)
+}
+
+func (s *PriceService) SchedulePriceChange(change *models.ScheduledPriceChange) error {
+	if change.NewPrice < 0 {
+		return errors.New("harga tidak boleh negatif")
+	}
+	if !change.EffectiveAt.After(time.Now()) {
+		return errors.New("effective_at harus di masa depan")
+	}
+	return s.repo.CreateScheduledPriceChange(change)
+}
+
+func (s *PriceService) CancelScheduledPriceChange(id int) error {
+	return s.repo.CancelScheduledPriceChange(id)
+}
+
+func (s *PriceService) ApplyDuePriceChanges(now time.Time) (int, int, error) {
+	return s.repo.ApplyDuePriceChanges(now)
+}
+
+func (s *PriceService) GetPriceTiers(productID int) ([]*models.PriceTier, error) {
+	return s.repo.GetPriceTiers(productID)
+}
+
+func (s *PriceService) CreatePriceTier(tier *models.PriceTier) error {
+	if tier.MinQuantity < 1 {
+		return errors.New("min_quantity minimal 1")
+	}
+	if tier.Price < 0 {
+		return errors.New("harga tidak boleh negatif")
+	}
+	return s.repo.CreatePriceTier(tier)
+}
+
+func (s *PriceService) DeletePriceTier(id int) error {
+	return s.repo.DeletePriceTier(id)
+}
@@ -1,8 +1,10 @@
 package services
 
 import (
+	"fmt"
 	"kasir-api/models"
 	"kasir-api/repositories"
+	"strings"
 )
 
 type ProductService struct {
@@ -13,26 +15,71 @@ func NewProductService(repository *repositories.ProductRepository) *ProductServi
 	return &ProductService{repository: repository}
 }
 
-func (s *ProductService) GetAllProducts(name string) ([]*models.ProductWithCategory, error) {
-	return s.repository.GetAllProducts(name)
+const (
+	defaultProductPageSize   = 50
+	maxProductPageSize       = 200
+	defaultLowStockThreshold = 5
+)
+
+func (s *ProductService) GetProducts(filter models.ProductFilter) (*models.ProductPage, error) {
+	if filter.Sort == "" {
+		filter.Sort = "id"
+	}
+	if filter.Order == "" {
+		filter.Order = "asc"
+	}
+	if filter.Order != "asc" && filter.Order != "desc" {
+		return nil, fmt.Errorf("%w: order harus asc atau desc", repositories.ErrInvalidFilter)
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = defaultProductPageSize
+	}
+	if filter.Limit > maxProductPageSize {
+		filter.Limit = maxProductPageSize
+	}
+	if filter.Offset < 0 {
+		return nil, fmt.Errorf("%w: offset tidak boleh negatif", repositories.ErrInvalidFilter)
+	}
+	if filter.LowStock && filter.LowStockThreshold <= 0 {
+		filter.LowStockThreshold = defaultLowStockThreshold
+	}
+	return s.repository.GetProducts(filter)
 }
 
-func (s *ProductService) GetAllProductsByCategoryID(categoryID int) ([]*models.ProductWithCategory, error) {
-	return s.repository.GetAllProductsByCategoryID(categoryID)
+const (
+	defaultSearchLimit = 20
+	maxSearchLimit     = 100
+)
+
+func (s *ProductService) SearchProducts(q string, limit int, categoryID *int, includeArchived bool) ([]*models.ProductSearchResult, error) {
+	if strings.TrimSpace(q) == "" {
+		return nil, fmt.Errorf("%w: q wajib diisi", repositories.ErrInvalidFilter)
+	}
+	if limit <= 0 {
+		limit = defaultSearchLimit
+	}
+	if limit > maxSearchLimit {
+		limit = maxSearchLimit
+	}
+	return s.repository.SearchProducts(q, limit, categoryID, includeArchived)
 }
 
-func (s *ProductService) CreateProduct(product *models.Product) error {
-	return s.repository.CreateProduct(product)
+func (s *ProductService) CreateProduct(product *models.Product, createdBy string) error {
+	return s.repository.CreateProduct(product, createdBy)
 }
 
 func (s *ProductService) GetProductByID(id int) (*models.ProductWithCategory, error) {
 	return s.repository.GetProductByID(id)
 }
 
-func (s *ProductService) UpdateProduct(product *models.Product) error {
-	return s.repository.UpdateProduct(product)
+func (s *ProductService) UpdateProduct(product *models.Product, changedBy string) error {
+	return s.repository.UpdateProduct(product, changedBy)
+}
+
+func (s *ProductService) ArchiveProduct(id int) error {
+	return s.repository.ArchiveProduct(id)
 }
 
-func (s *ProductService) DeleteProduct(id int) error {
-	return s.repository.DeleteProduct(id)
+func (s *ProductService) RestoreProduct(id int) error {
+	return s.repository.RestoreProduct(id)
 }
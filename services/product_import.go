@@ -0,0 +1,219 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"kasir-api/models"
+	"kasir-api/spreadsheet"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// kolom standar file import/export, bisa dipetakan ke header lain lewat mapping
+var productImportFields = []string{"sku", "barcode", "name", "category", "price", "stock"}
+
+var requiredImportFields = map[string]bool{"sku": true, "name": true, "category": true, "price": true}
+
+var thousandsPattern = regexp.MustCompile(`^\d{1,3}(\.\d{3})+$`)
+
+// ImportProducts membaca file csv/xlsx, memvalidasi setiap baris, lalu upsert berdasarkan SKU.
+// Kalau ada baris yang error, tidak ada data yang disimpan dan semua error dikembalikan per baris.
+func (s *ProductService) ImportProducts(format string, data []byte, mapping map[string]string, changedBy string, dryRun bool) (*models.ProductImportResult, error) {
+	records, err := spreadsheet.ReadAll(format, data)
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, errors.New("file import kosong")
+	}
+
+	columns, err := resolveImportColumns(records[0], mapping)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &models.ProductImportResult{
+		DryRun:            dryRun,
+		CategoriesCreated: make([]string, 0),
+		Errors:            make([]models.ProductImportError, 0),
+		Warnings:          make([]models.ProductImportError, 0),
+	}
+
+	rows := make([]models.ProductImportRow, 0, len(records)-1)
+	seenSKU := make(map[string]int)
+	for i, record := range records[1:] {
+		rowNumber := i + 2 //baris 1 adalah header
+		if isBlankRecord(record) {
+			continue
+		}
+
+		cell := func(field string) string {
+			idx, ok := columns[field]
+			if !ok || idx >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[idx])
+		}
+		addError := func(field string, message string) {
+			result.Errors = append(result.Errors, models.ProductImportError{Row: rowNumber, Field: field, Message: message})
+		}
+
+		row := models.ProductImportRow{
+			Row:          rowNumber,
+			SKU:          cell("sku"),
+			Barcode:      cell("barcode"),
+			Name:         cell("name"),
+			CategoryName: cell("category"),
+		}
+
+		if row.SKU == "" {
+			addError("sku", "sku wajib diisi")
+		} else if len(row.SKU) > 64 {
+			addError("sku", "sku maksimal 64 karakter")
+		} else if prev, ok := seenSKU[strings.ToLower(row.SKU)]; ok {
+			addError("sku", fmt.Sprintf("sku duplikat dengan baris %d", prev))
+		} else {
+			seenSKU[strings.ToLower(row.SKU)] = rowNumber
+		}
+		if len(row.Barcode) > 64 {
+			addError("barcode", "barcode maksimal 64 karakter")
+		}
+		if row.Name == "" {
+			addError("name", "nama produk wajib diisi")
+		}
+		if row.CategoryName == "" {
+			addError("category", "kategori wajib diisi")
+		}
+
+		price, err := parseWholeNumber(cell("price"))
+		if err != nil {
+			addError("price", "harga "+err.Error())
+		} else if price < 0 {
+			addError("price", "harga tidak boleh negatif")
+		}
+		row.Price = price
+
+		if raw := cell("stock"); raw != "" {
+			stock, err := parseWholeNumber(raw)
+			if err != nil {
+				addError("stock", "stok "+err.Error())
+			} else if stock < 0 {
+				addError("stock", "stok tidak boleh negatif")
+			}
+			row.Stock = &stock
+		}
+
+		rows = append(rows, row)
+	}
+	result.TotalRows = len(rows)
+
+	if len(result.Errors) > 0 {
+		return result, nil
+	}
+
+	return s.repository.ImportProducts(rows, changedBy, dryRun)
+}
+
+// ExportProducts menulis katalog ke w dalam format csv/xlsx, baris demi baris.
+func (s *ProductService) ExportProducts(format string, w io.Writer, includeArchived bool) error {
+	sw, err := spreadsheet.NewWriter(format, w)
+	if err != nil {
+		return err
+	}
+
+	header := make([]interface{}, len(productImportFields))
+	for i, f := range productImportFields {
+		header[i] = f
+	}
+	if err := sw.WriteBoldRow(header...); err != nil {
+		return err
+	}
+
+	err = s.repository.StreamProducts(includeArchived, func(p *models.ProductWithCategory) error {
+		return sw.WriteRow(p.SKU, p.Barcode, p.Name, p.CategoryName, p.Price, p.Stock)
+	})
+	if err != nil {
+		return err
+	}
+
+	return sw.Close()
+}
+
+func resolveImportColumns(header []string, mapping map[string]string) (map[string]int, error) {
+	index := make(map[string]int)
+	for i, h := range header {
+		key := strings.ToLower(strings.TrimSpace(h))
+		if _, exists := index[key]; !exists {
+			index[key] = i
+		}
+	}
+
+	for field := range mapping {
+		if !isImportField(field) {
+			return nil, fmt.Errorf("mapping untuk field %q tidak dikenal", field)
+		}
+	}
+
+	columns := make(map[string]int)
+	for _, field := range productImportFields {
+		name := field
+		if mapped, ok := mapping[field]; ok && strings.TrimSpace(mapped) != "" {
+			name = mapped
+		}
+
+		idx, ok := index[strings.ToLower(strings.TrimSpace(name))]
+		if !ok {
+			if requiredImportFields[field] {
+				return nil, fmt.Errorf("kolom %q untuk field %s tidak ditemukan di header", name, field)
+			}
+			continue
+		}
+		columns[field] = idx
+	}
+
+	return columns, nil
+}
+
+func isImportField(field string) bool {
+	for _, f := range productImportFields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
+
+func isBlankRecord(record []string) bool {
+	for _, v := range record {
+		if strings.TrimSpace(v) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// parseWholeNumber menerima angka biasa, angka dari cell Excel ("1.5E3") dan format ribuan Indonesia ("12.000").
+func parseWholeNumber(raw string) (int, error) {
+	v := strings.TrimSpace(raw)
+	v = strings.TrimPrefix(strings.TrimPrefix(v, "Rp"), "rp")
+	v = strings.ReplaceAll(strings.TrimSpace(v), " ", "")
+	if v == "" {
+		return 0, errors.New("wajib diisi")
+	}
+
+	if thousandsPattern.MatchString(v) {
+		v = strings.ReplaceAll(v, ".", "")
+	}
+
+	if n, err := strconv.Atoi(v); err == nil {
+		return n, nil
+	}
+
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil || f != float64(int(f)) {
+		return 0, fmt.Errorf("%q bukan bilangan bulat", raw)
+	}
+	return int(f), nil
+}
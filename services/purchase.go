@@ -0,0 +1,164 @@
+package services
+
+import (
+	"fmt"
+	"kasir-api/models"
+	"kasir-api/repositories"
+	"time"
+)
+
+type PurchaseService struct {
+	repo *repositories.PurchaseRepository
+}
+
+func NewPurchaseService(repo *repositories.PurchaseRepository) *PurchaseService {
+	return &PurchaseService{repo: repo}
+}
+
+func (s *PurchaseService) GetPurchaseOrders(status string, supplierID *int) ([]*models.PurchaseOrder, error) {
+	if status != "" && !isPurchaseOrderStatus(status) {
+		return nil, fmt.Errorf("%w: status %q tidak dikenal", repositories.ErrValidation, status)
+	}
+	return s.repo.GetPurchaseOrders(status, supplierID)
+}
+
+func (s *PurchaseService) GetPurchaseOrderByID(id int) (*models.PurchaseOrder, error) {
+	return s.repo.GetPurchaseOrderByID(id)
+}
+
+func (s *PurchaseService) CreatePurchaseOrder(req models.PurchaseOrderRequest, createdBy string) (*models.PurchaseOrder, error) {
+	if err := validatePurchaseOrderRequest(req); err != nil {
+		return nil, err
+	}
+
+	po := &models.PurchaseOrder{
+		SupplierID:   req.SupplierID,
+		OutletID:     req.OutletID,
+		ExpectedDate: req.ExpectedDate,
+		Note:         req.Note,
+		CreatedBy:    createdBy,
+		Lines:        req.Lines,
+	}
+	if err := s.repo.CreatePurchaseOrder(po); err != nil {
+		return nil, err
+	}
+
+	return po, nil
+}
+
+func (s *PurchaseService) UpdatePurchaseOrder(id int, req models.PurchaseOrderRequest) (*models.PurchaseOrder, error) {
+	if err := validatePurchaseOrderRequest(req); err != nil {
+		return nil, err
+	}
+
+	po := &models.PurchaseOrder{
+		ID:           id,
+		SupplierID:   req.SupplierID,
+		OutletID:     req.OutletID,
+		ExpectedDate: req.ExpectedDate,
+		Note:         req.Note,
+		Lines:        req.Lines,
+	}
+	if err := s.repo.UpdatePurchaseOrder(po); err != nil {
+		return nil, err
+	}
+
+	return po, nil
+}
+
+func (s *PurchaseService) SendPurchaseOrder(id int) (*models.PurchaseOrder, error) {
+	return s.repo.SetPurchaseOrderStatus(id, models.PurchaseOrderSent, models.PurchaseOrderDraft)
+}
+
+// CancelPurchaseOrder juga boleh untuk PO yang sudah diterima sebagian, barang yang sudah masuk tidak dikembalikan.
+func (s *PurchaseService) CancelPurchaseOrder(id int) (*models.PurchaseOrder, error) {
+	return s.repo.SetPurchaseOrderStatus(id, models.PurchaseOrderCancelled,
+		models.PurchaseOrderDraft, models.PurchaseOrderSent, models.PurchaseOrderPartiallyReceived)
+}
+
+func (s *PurchaseService) ReceivePurchaseOrder(id int, req models.GoodsReceiptRequest, receivedBy string) (*models.PurchaseOrder, error) {
+	if err := validateReceiptLines(req.Lines, false); err != nil {
+		return nil, err
+	}
+
+	receipt := &models.GoodsReceipt{
+		Reference:  req.Reference,
+		Note:       req.Note,
+		ReceivedBy: receivedBy,
+		Lines:      req.Lines,
+	}
+	return s.repo.ReceivePurchaseOrder(id, receipt)
+}
+
+func validatePurchaseOrderRequest(req models.PurchaseOrderRequest) error {
+	if req.SupplierID <= 0 {
+		return fmt.Errorf("%w: supplier_id wajib diisi", repositories.ErrValidation)
+	}
+	if req.ExpectedDate != "" {
+		if _, err := time.Parse("2006-01-02", req.ExpectedDate); err != nil {
+			return fmt.Errorf("%w: expected_date harus berformat YYYY-MM-DD", repositories.ErrValidation)
+		}
+	}
+	if len(req.Lines) == 0 {
+		return fmt.Errorf("%w: PO minimal punya satu line", repositories.ErrValidation)
+	}
+
+	seen := make(map[int]bool)
+	for i, line := range req.Lines {
+		if seen[line.ProductID] {
+			return fmt.Errorf("%w: line %d: produk %d muncul lebih dari sekali", repositories.ErrValidation, i+1, line.ProductID)
+		}
+		seen[line.ProductID] = true
+
+		if line.Quantity <= 0 {
+			return fmt.Errorf("%w: line %d: quantity harus lebih dari 0", repositories.ErrValidation, i+1)
+		}
+		if line.UnitCost < 0 {
+			return fmt.Errorf("%w: line %d: unit_cost tidak boleh negatif", repositories.ErrValidation, i+1)
+		}
+	}
+
+	return nil
+}
+
+// validateReceiptLines dipakai untuk penerimaan PO dan tanpa PO. Tanpa PO tidak ada harga acuan,
+// jadi unit_cost wajib diisi.
+func validateReceiptLines(lines []models.GoodsReceiptLine, requireUnitCost bool) error {
+	if len(lines) == 0 {
+		return fmt.Errorf("%w: minimal satu line", repositories.ErrValidation)
+	}
+
+	seen := make(map[int]bool)
+	for i, line := range lines {
+		if seen[line.ProductID] {
+			return fmt.Errorf("%w: line %d: produk %d muncul lebih dari sekali", repositories.ErrValidation, i+1, line.ProductID)
+		}
+		seen[line.ProductID] = true
+
+		if line.Quantity <= 0 {
+			return fmt.Errorf("%w: line %d: quantity harus lebih dari 0", repositories.ErrValidation, i+1)
+		}
+		if line.UnitCost == nil && requireUnitCost {
+			return fmt.Errorf("%w: line %d: unit_cost wajib diisi", repositories.ErrValidation, i+1)
+		}
+		if line.UnitCost != nil && *line.UnitCost < 0 {
+			return fmt.Errorf("%w: line %d: unit_cost tidak boleh negatif", repositories.ErrValidation, i+1)
+		}
+		if line.ExpiryDate != "" {
+			if _, err := time.Parse("2006-01-02", line.ExpiryDate); err != nil {
+				return fmt.Errorf("%w: line %d: expiry_date harus berformat YYYY-MM-DD", repositories.ErrValidation, i+1)
+			}
+		}
+	}
+
+	return nil
+}
+
+func isPurchaseOrderStatus(status string) bool {
+	switch status {
+	case models.PurchaseOrderDraft, models.PurchaseOrderSent, models.PurchaseOrderPartiallyReceived,
+		models.PurchaseOrderReceived, models.PurchaseOrderCancelled:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,241 @@
+package services
+
+import (
+	"fmt"
+	"io"
+	"kasir-api/models"
+	"kasir-api/spreadsheet"
+)
+
+// Export laporan ke csv/xlsx/pdf. Urutannya sama untuk semua laporan: baris keterangan (periode, timezone,
+// filter), baris kosong, lalu tabel dengan header dan baris total yang ditulis bold.
+
+func (s *TransactionService) ExportReport(format string, w io.Writer, report *models.Report) error {
+	sw, err := spreadsheet.NewWriterWithOptions(format, w, spreadsheet.Options{Brand: s.reportBrand, Title: "Laporan Penjualan"})
+	if err != nil {
+		return err
+	}
+
+	rows := [][]interface{}{
+		{"Periode", periodLabel(report.From, report.To)},
+		{"Timezone", report.Timezone},
+		{"Outlet", outletLabel(report.OutletID)},
+	}
+	if c := report.Comparison; c != nil {
+		rows = append(rows, []interface{}{"Pembanding", periodLabel(c.From, c.To)})
+	}
+	if err := writeRows(sw, rows); err != nil {
+		return err
+	}
+	if err := sw.WriteRow(); err != nil {
+		return err
+	}
+
+	if c := report.Comparison; c != nil {
+		err = writeRows(sw, [][]interface{}{
+			{"Total revenue", report.TotalRevenue, c.TotalRevenue, c.Revenue.Change, optionalFloat(c.Revenue.Percent)},
+			{"Total transaksi", report.TotalTransaction, c.TotalTransaction, c.Transactions.Change, optionalFloat(c.Transactions.Percent)},
+			{"Rata-rata transaksi", report.AverageBasket, c.AverageBasket, c.AverageBasketDelta.Change, optionalFloat(c.AverageBasketDelta.Percent)},
+			{"Produk terlaris", report.PopularProduct.ProductName, c.PopularProduct.ProductName},
+			{"Jumlah terjual produk terlaris", report.PopularProduct.Quantity, c.PopularProduct.Quantity, c.PopularProductQuantity.Change, optionalFloat(c.PopularProductQuantity.Percent)},
+		}, "Metrik", "Periode ini", "Pembanding", "Selisih", "Selisih %")
+	} else {
+		err = writeRows(sw, [][]interface{}{
+			{"Total revenue", report.TotalRevenue},
+			{"Total transaksi", report.TotalTransaction},
+			{"Rata-rata transaksi", report.AverageBasket},
+			{"Produk terlaris", report.PopularProduct.ProductName},
+			{"Jumlah terjual produk terlaris", report.PopularProduct.Quantity},
+		}, "Metrik", "Nilai")
+	}
+	if err != nil {
+		return err
+	}
+
+	if len(report.Outlets) > 0 {
+		if err := sw.WriteRow(); err != nil {
+			return err
+		}
+		rows = rows[:0]
+		var revenue, transactions int
+		for _, o := range report.Outlets {
+			rows = append(rows, []interface{}{o.OutletName, o.TotalRevenue, o.TotalTransaction})
+			revenue += o.TotalRevenue
+			transactions += o.TotalTransaction
+		}
+		if err := writeRows(sw, rows, "Outlet", "Revenue", "Transaksi"); err != nil {
+			return err
+		}
+		if err := sw.WriteBoldRow("Total", revenue, transactions); err != nil {
+			return err
+		}
+	}
+
+	return sw.Close()
+}
+
+func (s *TransactionService) ExportSalesTimeseries(format string, w io.Writer, report *models.SalesTimeseries) error {
+	sw, err := spreadsheet.NewWriterWithOptions(format, w, spreadsheet.Options{Brand: s.reportBrand, Title: "Grafik Penjualan"})
+	if err != nil {
+		return err
+	}
+
+	err = writeRows(sw, [][]interface{}{
+		{"Periode", periodLabel(report.From, report.To)},
+		{"Granularity", report.Granularity},
+		{"Timezone", report.Timezone},
+		{"Outlet", outletLabel(report.OutletID)},
+		{},
+	})
+	if err != nil {
+		return err
+	}
+
+	layout := "2006-01-02"
+	if report.Granularity == models.GranularityHour {
+		layout = "2006-01-02 15:04"
+	}
+	rows := make([][]interface{}, len(report.Buckets))
+	for i, b := range report.Buckets {
+		rows[i] = []interface{}{b.Start.Format(layout), b.End.Format(layout), b.Revenue, b.TransactionCount, b.ItemsSold, b.AverageBasket}
+	}
+	if err := writeRows(sw, rows, "Mulai", "Sampai", "Revenue", "Transaksi", "Item terjual", "Rata-rata transaksi"); err != nil {
+		return err
+	}
+
+	var average int
+	if report.TransactionCount > 0 {
+		average = report.TotalRevenue / report.TransactionCount
+	}
+	if err := sw.WriteBoldRow("Total", nil, report.TotalRevenue, report.TransactionCount, report.ItemsSold, average); err != nil {
+		return err
+	}
+
+	return sw.Close()
+}
+
+func (s *TransactionService) ExportProductRankings(format string, w io.Writer, report *models.ProductRankingReport) error {
+	sw, err := spreadsheet.NewWriterWithOptions(format, w, spreadsheet.Options{Brand: s.reportBrand, Title: "Ranking Produk"})
+	if err != nil {
+		return err
+	}
+
+	category := "Semua kategori"
+	if report.CategoryID != nil {
+		category = fmt.Sprintf("#%d", *report.CategoryID)
+	}
+	err = writeRows(sw, [][]interface{}{
+		{"Periode", periodLabel(report.From, report.To)},
+		{"Urutan", report.By + " " + report.Order},
+		{"Timezone", report.Timezone},
+		{"Outlet", outletLabel(report.OutletID)},
+		{"Kategori", category},
+		{},
+	})
+	if err != nil {
+		return err
+	}
+
+	rows := make([][]interface{}, len(report.Products))
+	var quantity, revenue, profit int
+	var contribution float64
+	for i, p := range report.Products {
+		rows[i] = []interface{}{p.Rank, p.SKU, p.ProductName, p.CategoryName, p.Quantity, p.Revenue, p.Profit, p.RevenueContribution, p.ABCClass}
+		quantity += p.Quantity
+		revenue += p.Revenue
+		profit += p.Profit
+		contribution += p.RevenueContribution
+	}
+	if err := writeRows(sw, rows, "Rank", "SKU", "Produk", "Kategori", "Quantity", "Revenue", "Profit", "Kontribusi %", "Kelas ABC"); err != nil {
+		return err
+	}
+	if err := sw.WriteBoldRow("Total", nil, nil, nil, quantity, revenue, profit, contribution); err != nil {
+		return err
+	}
+
+	return sw.Close()
+}
+
+func (s *TransactionService) ExportSalesByCategory(format string, w io.Writer, report *models.CategorySalesReport) error {
+	sw, err := spreadsheet.NewWriterWithOptions(format, w, spreadsheet.Options{Brand: s.reportBrand, Title: "Penjualan per Kategori"})
+	if err != nil {
+		return err
+	}
+
+	err = writeRows(sw, [][]interface{}{
+		{"Periode", periodLabel(report.From, report.To)},
+		{"Periode sebelumnya", periodLabel(report.PreviousFrom, report.PreviousTo)},
+		{"Timezone", report.Timezone},
+		{"Outlet", outletLabel(report.OutletID)},
+		{},
+	})
+	if err != nil {
+		return err
+	}
+
+	rows := make([][]interface{}, len(report.Categories))
+	var quantity, previousQuantity int
+	for i, c := range report.Categories {
+		rows[i] = []interface{}{c.CategoryName, c.Quantity, c.Revenue, c.Share, c.PreviousQuantity, c.PreviousRevenue, c.RevenueChange, optionalFloat(c.RevenueGrowth)}
+		quantity += c.Quantity
+		previousQuantity += c.PreviousQuantity
+	}
+	err = writeRows(sw, rows, "Kategori", "Quantity", "Revenue", "Porsi %", "Quantity sebelumnya", "Revenue sebelumnya", "Selisih revenue", "Pertumbuhan %")
+	if err != nil {
+		return err
+	}
+
+	err = sw.WriteBoldRow("Total", quantity, report.TotalRevenue, percentOf(report.TotalRevenue, report.TotalRevenue),
+		previousQuantity, report.PreviousTotalRevenue, report.TotalRevenue-report.PreviousTotalRevenue,
+		optionalFloat(growth(report.TotalRevenue, report.PreviousTotalRevenue)))
+	if err != nil {
+		return err
+	}
+
+	return sw.Close()
+}
+
+// writeRows menulis header (kalau ada) sebagai baris bold, lalu semua baris.
+func writeRows(sw spreadsheet.Writer, rows [][]interface{}, header ...interface{}) error {
+	if len(header) > 0 {
+		if err := sw.WriteBoldRow(header...); err != nil {
+			return err
+		}
+	}
+	for _, row := range rows {
+		if err := sw.WriteRow(row...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ReportFilename contohnya laporan-penjualan-2024-01-01_2024-01-31.xlsx, tanggal cukup sekali untuk laporan satu hari.
+func ReportFilename(name string, from string, to string, format string) string {
+	if from == to {
+		return fmt.Sprintf("%s-%s.%s", name, from, format)
+	}
+	return fmt.Sprintf("%s-%s_%s.%s", name, from, to, format)
+}
+
+func periodLabel(from string, to string) string {
+	if from == to {
+		return from
+	}
+	return from + " s/d " + to
+}
+
+func outletLabel(outletID *int) string {
+	if outletID == nil {
+		return "Semua outlet"
+	}
+	return fmt.Sprintf("#%d", *outletID)
+}
+
+// optionalFloat supaya persentase yang tidak bisa dihitung jadi sel kosong, bukan pointer nil di dalam interface.
+func optionalFloat(v *float64) interface{} {
+	if v == nil {
+		return nil
+	}
+	return *v
+}
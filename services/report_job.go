@@ -0,0 +1,434 @@
+package services
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"kasir-api/cron"
+	"kasir-api/models"
+	"kasir-api/notifications"
+	"kasir-api/repositories"
+	"kasir-api/spreadsheet"
+	"log"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	defaultReportRunLimit = 30
+	maxReportRunLimit     = 200
+)
+
+// prefix nama file hasil job, sama dengan export manual dari endpoint laporan
+var reportFilePrefixes = map[string]string{
+	models.ReportTypeSales:           "laporan-penjualan",
+	models.ReportTypeSalesByCategory: "penjualan-per-kategori",
+	models.ReportTypeProductRankings: "ranking-produk",
+	models.ReportTypeSalesTimeseries: "grafik-penjualan",
+}
+
+type ReportJobService struct {
+	repo         *repositories.ReportJobRepository
+	transactions *TransactionService
+	notifiers    notifications.ReportNotifiers
+	outputDir    string
+	tenantCode   string
+}
+
+// NewReportJobService: outputDir adalah folder hasil laporan milik tenant ini. tenantCode kosong untuk tenant
+// default, selain itu ikut dikirim ke notifier supaya file/payload tenant lain terpisah.
+func NewReportJobService(repo *repositories.ReportJobRepository, transactions *TransactionService, notifiers notifications.ReportNotifiers, outputDir string, tenantCode string) *ReportJobService {
+	return &ReportJobService{repo: repo, transactions: transactions, notifiers: notifiers, outputDir: outputDir, tenantCode: tenantCode}
+}
+
+func (s *ReportJobService) GetReportJobs() ([]models.ReportJob, error) {
+	return s.repo.GetReportJobs()
+}
+
+func (s *ReportJobService) GetReportJobByID(id int) (*models.ReportJob, error) {
+	return s.repo.GetReportJobByID(id)
+}
+
+func (s *ReportJobService) CreateReportJob(req models.ReportJobRequest, createdBy string) (*models.ReportJob, error) {
+	job := reportJobFromRequest(req)
+	job.CreatedBy = createdBy
+	if err := s.prepareReportJob(job); err != nil {
+		return nil, err
+	}
+	if err := s.repo.CreateReportJob(job); err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+// UpdateReportJob menghitung ulang jadwal berikutnya dari sekarang.
+func (s *ReportJobService) UpdateReportJob(id int, req models.ReportJobRequest) (*models.ReportJob, error) {
+	job := reportJobFromRequest(req)
+	job.ID = id
+	if err := s.prepareReportJob(job); err != nil {
+		return nil, err
+	}
+	if err := s.repo.UpdateReportJob(job); err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+func (s *ReportJobService) DeleteReportJob(id int) error {
+	if err := s.repo.DeleteReportJob(id); err != nil {
+		return err
+	}
+	if err := os.RemoveAll(filepath.Join(s.outputDir, strconv.Itoa(id))); err != nil {
+		log.Printf("gagal menghapus file laporan job %d: %v", id, err)
+	}
+	return nil
+}
+
+func (s *ReportJobService) GetReportRuns(jobID int, limit int) ([]models.ReportRun, error) {
+	if limit <= 0 {
+		limit = defaultReportRunLimit
+	}
+	if limit > maxReportRunLimit {
+		limit = maxReportRunLimit
+	}
+	return s.repo.GetReportRuns(jobID, limit)
+}
+
+// OpenReportRunFile membuka file hasil run. Pemanggil wajib menutup file-nya.
+func (s *ReportJobService) OpenReportRunFile(id int) (*models.ReportRun, *os.File, error) {
+	run, err := s.repo.GetReportRunByID(id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if run.FilePath == "" {
+		return nil, nil, fmt.Errorf("%w: report run %d tidak punya file", repositories.ErrNotFound, id)
+	}
+
+	f, err := os.Open(filepath.Join(s.outputDir, run.FilePath))
+	if os.IsNotExist(err) {
+		return nil, nil, fmt.Errorf("%w: file report run %d sudah tidak ada", repositories.ErrNotFound, id)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return run, f, nil
+}
+
+// RunReportJob menjalankan job sekarang di luar jadwal, jadwal berikutnya tidak berubah.
+func (s *ReportJobService) RunReportJob(id int) (*models.ReportRun, error) {
+	job, err := s.repo.GetReportJobByID(id)
+	if err != nil {
+		return nil, err
+	}
+	return s.run(job, time.Now())
+}
+
+// RunDueJobs dipanggil scheduler: menjalankan semua job yang jadwalnya sudah lewat. Job yang terlewat
+// beberapa kali (misalnya server mati) cukup dijalankan sekali, lalu dijadwalkan lagi dari now.
+func (s *ReportJobService) RunDueJobs(now time.Time) (int, error) {
+	jobs, err := s.repo.GetDueReportJobs(now)
+	if err != nil {
+		return 0, err
+	}
+
+	ran := 0
+	for i := range jobs {
+		job := &jobs[i]
+		next, err := s.nextRun(job, now)
+		if err != nil {
+			//cron/timezone yang sudah tidak valid tidak boleh menghentikan job lain. Jadwalnya dikosongkan
+			//sampai job diperbaiki lewat update
+			log.Printf("report job %d dilewati: %v", job.ID, err)
+			if _, err := s.repo.ClaimReportJob(job.ID, *job.NextRunAt, nil); err != nil {
+				return ran, err
+			}
+			continue
+		}
+
+		claimed, err := s.repo.ClaimReportJob(job.ID, *job.NextRunAt, next)
+		if err != nil {
+			return ran, err
+		}
+		if !claimed {
+			continue
+		}
+
+		run, err := s.run(job, now)
+		if err != nil {
+			return ran, err
+		}
+		if run.Status == models.ReportRunFailed {
+			log.Printf("report job %d: %s", job.ID, run.Error)
+		}
+		ran++
+	}
+	return ran, nil
+}
+
+// run mencatat hasilnya di report_runs. Gagal membuat atau mengirim laporan tidak dikembalikan sebagai error,
+// tapi dicatat di run. Error hanya untuk kegagalan database.
+func (s *ReportJobService) run(job *models.ReportJob, now time.Time) (*models.ReportRun, error) {
+	loc, err := s.transactions.location(job.Timezone, job.OutletID)
+	if err != nil {
+		return nil, err
+	}
+	from, to := reportPeriod(job.Range, now.In(loc))
+
+	run := &models.ReportRun{
+		ReportJobID: job.ID,
+		PeriodFrom:  from.Format("2006-01-02"),
+		PeriodTo:    to.Format("2006-01-02"),
+		Status:      models.ReportRunRunning,
+	}
+	if err := s.repo.CreateReportRun(run); err != nil {
+		return nil, err
+	}
+
+	run.Status = models.ReportRunSuccess
+	if err := s.generate(job, run, from, to); err != nil {
+		run.Status = models.ReportRunFailed
+		run.Error = err.Error()
+	}
+
+	if err := s.repo.FinishReportRun(run); err != nil {
+		return nil, err
+	}
+	return run, nil
+}
+
+// generate membuat laporan, menyimpan file-nya lalu mengirim lewat channel job.
+func (s *ReportJobService) generate(job *models.ReportJob, run *models.ReportRun, from time.Time, to time.Time) error {
+	var buf bytes.Buffer
+	if err := s.export(job, from, to, &buf); err != nil {
+		return err
+	}
+
+	name := ReportFilename(reportFilePrefixes[job.ReportType], run.PeriodFrom, run.PeriodTo, job.Format)
+	path := filepath.Join(strconv.Itoa(job.ID), fmt.Sprintf("%d-%s", run.ID, name))
+	if err := os.MkdirAll(filepath.Join(s.outputDir, strconv.Itoa(job.ID)), 0o755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(s.outputDir, path), buf.Bytes(), 0o644); err != nil {
+		return err
+	}
+	run.FileName, run.FilePath, run.FileSize = name, path, buf.Len()
+
+	notifier, ok := s.notifiers[job.Channel]
+	if !ok {
+		return fmt.Errorf("channel %s tidak dikonfigurasi di server", job.Channel)
+	}
+	msg := notifications.ReportMessage{
+		Tenant:      s.tenantCode,
+		Target:      job.Target,
+		Subject:     fmt.Sprintf("%s (%s)", job.Name, periodLabel(run.PeriodFrom, run.PeriodTo)),
+		Body:        fmt.Sprintf("Laporan %s periode %s terlampir.\n", job.Name, periodLabel(run.PeriodFrom, run.PeriodTo)),
+		FileName:    name,
+		ContentType: spreadsheet.ContentType(job.Format),
+		Data:        buf.Bytes(),
+	}
+	if err := notifier.SendReport(msg); err != nil {
+		return fmt.Errorf("gagal mengirim lewat %s: %w", job.Channel, err)
+	}
+	run.Delivered = true
+	return nil
+}
+
+func (s *ReportJobService) export(job *models.ReportJob, from time.Time, to time.Time, w io.Writer) error {
+	switch job.ReportType {
+	case models.ReportTypeSales:
+		report, err := s.transactions.GenerateReport(from, to, job.OutletID, job.Compare, job.Timezone)
+		if err != nil {
+			return err
+		}
+		return s.transactions.ExportReport(job.Format, w, report)
+	case models.ReportTypeSalesByCategory:
+		report, err := s.transactions.GetSalesByCategory(from, to, job.OutletID, job.Timezone)
+		if err != nil {
+			return err
+		}
+		return s.transactions.ExportSalesByCategory(job.Format, w, report)
+	case models.ReportTypeProductRankings:
+		report, err := s.transactions.GetProductRankings(from, to, "", "", 0, nil, job.OutletID, job.Timezone)
+		if err != nil {
+			return err
+		}
+		return s.transactions.ExportProductRankings(job.Format, w, report)
+	case models.ReportTypeSalesTimeseries:
+		granularity := models.GranularityDay
+		if from.Equal(to) {
+			granularity = models.GranularityHour
+		}
+		report, err := s.transactions.GetSalesTimeseries(from, to, granularity, job.OutletID, job.Timezone)
+		if err != nil {
+			return err
+		}
+		return s.transactions.ExportSalesTimeseries(job.Format, w, report)
+	default:
+		return fmt.Errorf("report_type %q tidak dikenal", job.ReportType)
+	}
+}
+
+// nextRun nil kalau job tidak aktif.
+func (s *ReportJobService) nextRun(job *models.ReportJob, after time.Time) (*time.Time, error) {
+	if !job.Active {
+		return nil, nil
+	}
+
+	schedule, err := cron.Parse(job.Cron)
+	if err != nil {
+		return nil, fmt.Errorf("%w: cron: %s", repositories.ErrValidation, err)
+	}
+	loc, err := s.transactions.location(job.Timezone, job.OutletID)
+	if err != nil {
+		return nil, err
+	}
+
+	next := schedule.Next(after.In(loc))
+	if next.IsZero() {
+		return nil, fmt.Errorf("%w: cron %q tidak punya jadwal dalam 5 tahun ke depan", repositories.ErrValidation, job.Cron)
+	}
+	return &next, nil
+}
+
+func reportJobFromRequest(req models.ReportJobRequest) *models.ReportJob {
+	job := &models.ReportJob{
+		Name:       strings.TrimSpace(req.Name),
+		Cron:       strings.TrimSpace(req.Cron),
+		Timezone:   strings.TrimSpace(req.Timezone),
+		ReportType: req.ReportType,
+		Range:      req.Range,
+		Format:     req.Format,
+		OutletID:   req.OutletID,
+		Compare:    req.Compare,
+		Channel:    req.Channel,
+		Target:     strings.TrimSpace(req.Target),
+		Active:     true,
+	}
+	if req.Active != nil {
+		job.Active = *req.Active
+	}
+	return job
+}
+
+// prepareReportJob memvalidasi job, menormalkan target dan mengisi NextRunAt.
+func (s *ReportJobService) prepareReportJob(job *models.ReportJob) error {
+	if job.Name == "" {
+		return fmt.Errorf("%w: name wajib diisi", repositories.ErrValidation)
+	}
+	if _, ok := reportFilePrefixes[job.ReportType]; !ok {
+		return fmt.Errorf("%w: report_type harus sales, sales_by_category, product_rankings atau sales_timeseries", repositories.ErrValidation)
+	}
+
+	switch job.Range {
+	case models.ReportRangeToday, models.ReportRangeYesterday, models.ReportRangeLast7Days,
+		models.ReportRangeLast30Days, models.ReportRangeLastWeek, models.ReportRangeLastMonth:
+	default:
+		return fmt.Errorf("%w: range harus today, yesterday, last_7_days, last_30_days, last_week atau last_month", repositories.ErrValidation)
+	}
+
+	switch job.Format {
+	case spreadsheet.FormatCSV, spreadsheet.FormatXLSX, spreadsheet.FormatPDF:
+	default:
+		return fmt.Errorf("%w: format harus csv, xlsx atau pdf", repositories.ErrValidation)
+	}
+
+	switch job.Compare {
+	case "":
+	case models.ComparePrevious, models.CompareLastWeek, models.CompareLastYear:
+		if job.ReportType != models.ReportTypeSales {
+			return fmt.Errorf("%w: compare hanya untuk report_type sales", repositories.ErrValidation)
+		}
+	default:
+		return fmt.Errorf("%w: compare harus previous, last_week atau last_year", repositories.ErrValidation)
+	}
+
+	if job.Timezone != "" {
+		if _, err := time.LoadLocation(job.Timezone); err != nil {
+			return fmt.Errorf("%w: timezone %q tidak dikenal", repositories.ErrValidation, job.Timezone)
+		}
+	}
+
+	if err := s.validateTarget(job); err != nil {
+		return err
+	}
+
+	//nextRun tidak mem-parse cron untuk job yang tidak aktif, jadi dicek di sini juga
+	if _, err := cron.Parse(job.Cron); err != nil {
+		return fmt.Errorf("%w: cron: %s", repositories.ErrValidation, err)
+	}
+	next, err := s.nextRun(job, time.Now())
+	if err != nil {
+		return err
+	}
+	job.NextRunAt = next
+	return nil
+}
+
+func (s *ReportJobService) validateTarget(job *models.ReportJob) error {
+	if _, ok := s.notifiers[job.Channel]; !ok {
+		switch job.Channel {
+		case models.ReportChannelEmail, models.ReportChannelWebhook, models.ReportChannelDirectory:
+			return fmt.Errorf("%w: channel %s belum dikonfigurasi di server", repositories.ErrValidation, job.Channel)
+		default:
+			return fmt.Errorf("%w: channel harus email, webhook atau directory", repositories.ErrValidation)
+		}
+	}
+
+	switch job.Channel {
+	case models.ReportChannelEmail:
+		var addresses []string
+		for _, part := range strings.Split(job.Target, ",") {
+			if strings.TrimSpace(part) == "" {
+				continue
+			}
+			addr, err := mail.ParseAddress(part)
+			if err != nil {
+				return fmt.Errorf("%w: alamat email %q tidak valid", repositories.ErrValidation, strings.TrimSpace(part))
+			}
+			addresses = append(addresses, addr.Address)
+		}
+		if len(addresses) == 0 {
+			return fmt.Errorf("%w: target wajib berisi minimal satu alamat email", repositories.ErrValidation)
+		}
+		job.Target = strings.Join(addresses, ", ")
+	case models.ReportChannelWebhook:
+		if validator, ok := s.notifiers[job.Channel].(notifications.TargetValidator); ok {
+			if err := validator.ValidateTarget(job.Target); err != nil {
+				return fmt.Errorf("%w: %s", repositories.ErrValidation, err)
+			}
+		}
+	case models.ReportChannelDirectory:
+		if job.Target != "" && (job.Target != filepath.Base(job.Target) || strings.HasPrefix(job.Target, ".")) {
+			return fmt.Errorf("%w: target harus nama folder tanpa path", repositories.ErrValidation)
+		}
+	}
+	return nil
+}
+
+// reportPeriod menghitung tanggal from..to (inklusif) untuk range job. today adalah tanggal now di zona waktu job,
+// range selain today hanya berisi hari yang sudah selesai.
+func reportPeriod(rangeName string, now time.Time) (time.Time, time.Time) {
+	today := calendarDate(now)
+	switch rangeName {
+	case models.ReportRangeYesterday:
+		yesterday := today.AddDate(0, 0, -1)
+		return yesterday, yesterday
+	case models.ReportRangeLast7Days:
+		return today.AddDate(0, 0, -7), today.AddDate(0, 0, -1)
+	case models.ReportRangeLast30Days:
+		return today.AddDate(0, 0, -30), today.AddDate(0, 0, -1)
+	case models.ReportRangeLastWeek:
+		//minggu dimulai Senin, sama seperti bucket week di grafik penjualan
+		monday := today.AddDate(0, 0, -(int(today.Weekday())+6)%7)
+		return monday.AddDate(0, 0, -7), monday.AddDate(0, 0, -1)
+	case models.ReportRangeLastMonth:
+		firstOfMonth := time.Date(today.Year(), today.Month(), 1, 0, 0, 0, 0, time.UTC)
+		return firstOfMonth.AddDate(0, -1, 0), firstOfMonth.AddDate(0, 0, -1)
+	default:
+		return today, today
+	}
+}
@@ -0,0 +1,161 @@
+package services
+
+import (
+	"fmt"
+	"kasir-api/models"
+	"kasir-api/repositories"
+	"math"
+	"time"
+)
+
+// batas jumlah bucket per request, supaya granularity hour tidak dipakai untuk rentang bertahun-tahun
+const maxTimeseriesBuckets = 2000
+
+// GetSalesTimeseries: from dan to adalah tanggal (inklusif), granularity kosong berarti day.
+func (s *TransactionService) GetSalesTimeseries(from time.Time, to time.Time, granularity string, outletID *int, tz string) (*models.SalesTimeseries, error) {
+	if granularity == "" {
+		granularity = models.GranularityDay
+	}
+	if to.Before(from) {
+		return nil, fmt.Errorf("%w: to tidak boleh sebelum from", repositories.ErrValidation)
+	}
+
+	days := int(to.Sub(from).Hours()/24) + 1
+	var buckets int
+	switch granularity {
+	case models.GranularityHour:
+		buckets = days * 24
+	case models.GranularityDay:
+		buckets = days
+	case models.GranularityWeek:
+		buckets = days/7 + 1
+	case models.GranularityMonth:
+		buckets = days/28 + 1
+	default:
+		return nil, fmt.Errorf("%w: granularity harus hour, day, week atau month", repositories.ErrValidation)
+	}
+	if buckets > maxTimeseriesBuckets {
+		return nil, fmt.Errorf("%w: rentang terlalu panjang untuk granularity %s (maksimal %d bucket)", repositories.ErrValidation, granularity, maxTimeseriesBuckets)
+	}
+
+	loc, err := s.location(tz, outletID)
+	if err != nil {
+		return nil, err
+	}
+
+	start, end := dayRange(from, to, loc)
+	series, err := s.repo.GetSalesTimeseries(start, end, granularity, loc.String(), outletID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range series {
+		series[i].Start = series[i].Start.In(loc)
+		series[i].End = series[i].End.In(loc)
+	}
+
+	report := &models.SalesTimeseries{
+		From:        from.Format("2006-01-02"),
+		To:          to.Format("2006-01-02"),
+		Granularity: granularity,
+		Timezone:    loc.String(),
+		OutletID:    outletID,
+		Buckets:     series,
+	}
+	for _, b := range series {
+		report.TotalRevenue += b.Revenue
+		report.TransactionCount += b.TransactionCount
+		report.ItemsSold += b.ItemsSold
+	}
+
+	return report, nil
+}
+
+const (
+	defaultRankingLimit = 10
+	maxRankingLimit     = 500
+)
+
+// GetProductRankings: default ranking revenue terbesar, 10 produk. order asc untuk mencari slow mover.
+func (s *TransactionService) GetProductRankings(from time.Time, to time.Time, by string, order string, limit int, categoryID *int, outletID *int, tz string) (*models.ProductRankingReport, error) {
+	if by == "" {
+		by = models.RankByRevenue
+	}
+	if order == "" {
+		order = "desc"
+	}
+	if limit == 0 {
+		limit = defaultRankingLimit
+	}
+
+	switch by {
+	case models.RankByQuantity, models.RankByRevenue, models.RankByProfit:
+	default:
+		return nil, fmt.Errorf("%w: by harus quantity, revenue atau profit", repositories.ErrValidation)
+	}
+	if order != "asc" && order != "desc" {
+		return nil, fmt.Errorf("%w: order harus asc atau desc", repositories.ErrValidation)
+	}
+	if limit < 1 || limit > maxRankingLimit {
+		return nil, fmt.Errorf("%w: limit harus antara 1 dan %d", repositories.ErrValidation, maxRankingLimit)
+	}
+	if to.Before(from) {
+		return nil, fmt.Errorf("%w: to tidak boleh sebelum from", repositories.ErrValidation)
+	}
+
+	loc, err := s.location(tz, outletID)
+	if err != nil {
+		return nil, err
+	}
+
+	start, end := dayRange(from, to, loc)
+	total, products, err := s.repo.GetProductRankings(start, end, by, order == "asc", limit, categoryID, outletID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.ProductRankingReport{
+		From:         from.Format("2006-01-02"),
+		To:           to.Format("2006-01-02"),
+		By:           by,
+		Order:        order,
+		Timezone:     loc.String(),
+		OutletID:     outletID,
+		CategoryID:   categoryID,
+		TotalRevenue: total,
+		Products:     products,
+	}, nil
+}
+
+// dayRange mengubah tanggal from..to (inklusif) menjadi rentang setengah terbuka [start, end) mulai
+// tengah malam di loc. Hari berikutnya dihitung dengan AddDate, bukan +24 jam, supaya tetap benar saat DST.
+func dayRange(from time.Time, to time.Time, loc *time.Location) (time.Time, time.Time) {
+	start := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, loc)
+	end := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, loc).AddDate(0, 0, 1)
+	return start, end
+}
+
+// calendarDate membuang jam dan zona waktu, supaya selisih hari antar tanggal bisa dihitung tanpa efek DST.
+func calendarDate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// percentOf dibulatkan 2 desimal, 0 kalau total 0.
+func percentOf(value int, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return math.Round(float64(value)/float64(total)*10000) / 100
+}
+
+// growth adalah perubahan dalam persen terhadap previous, nil kalau previous 0 karena tidak bisa dihitung.
+func growth(current int, previous int) *float64 {
+	if previous == 0 {
+		return nil
+	}
+	g := math.Round(float64(current-previous)/float64(previous)*10000) / 100
+	return &g
+}
+
+func delta(current int, previous int) models.ReportDelta {
+	return models.ReportDelta{Change: current - previous, Percent: growth(current, previous)}
+}
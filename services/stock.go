@@ -0,0 +1,195 @@
+package services
+
+import (
+	"fmt"
+	"kasir-api/models"
+	"kasir-api/repositories"
+	"strings"
+	"time"
+)
+
+const (
+	defaultStockMovementPageSize = 50
+	maxStockMovementPageSize     = 200
+	defaultReorderCoverDays      = 14
+	maxReorderDays               = 365
+	defaultExpiryDays            = 30
+)
+
+type StockService struct {
+	repo                      *repositories.StockRepository
+	adjustmentRequireApproval bool
+	adjustmentApprovers       []string
+	reorderWindowDays         int
+}
+
+// NewStockService: adjustmentApprovers adalah daftar user yang boleh approve/reject adjustment pending,
+// kosong berarti tidak ada yang bisa me-review.
+func NewStockService(repo *repositories.StockRepository, adjustmentRequireApproval bool, adjustmentApprovers []string, reorderWindowDays int) *StockService {
+	return &StockService{
+		repo:                      repo,
+		adjustmentRequireApproval: adjustmentRequireApproval,
+		adjustmentApprovers:       adjustmentApprovers,
+		reorderWindowDays:         reorderWindowDays,
+	}
+}
+
+func (s *StockService) GetStockMovements(productID int, limit int, offset int) (*models.StockMovementPage, error) {
+	if limit <= 0 {
+		limit = defaultStockMovementPageSize
+	}
+	if limit > maxStockMovementPageSize {
+		limit = maxStockMovementPageSize
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	return s.repo.GetStockMovements(productID, limit, offset)
+}
+
+func (s *StockService) CheckConsistency(productID *int) (*models.StockConsistencyReport, error) {
+	return s.repo.CheckConsistency(productID)
+}
+
+// CreateStockAdjustment langsung menerapkan adjustment, kecuali approval diwajibkan lewat config
+// atau diminta oleh request. Adjustment yang butuh approval disimpan dengan status pending.
+func (s *StockService) CreateStockAdjustment(req models.StockAdjustmentRequest, createdBy string) (*models.StockAdjustment, error) {
+	if len(req.Lines) == 0 {
+		return nil, fmt.Errorf("%w: adjustment minimal punya satu line", repositories.ErrValidation)
+	}
+
+	seen := make(map[int]bool)
+	for i, line := range req.Lines {
+		if seen[line.ProductID] {
+			return nil, fmt.Errorf("%w: line %d: produk %d muncul lebih dari sekali", repositories.ErrValidation, i+1, line.ProductID)
+		}
+		seen[line.ProductID] = true
+
+		if err := validateAdjustmentLine(line); err != nil {
+			return nil, fmt.Errorf("%w: line %d: %s", repositories.ErrValidation, i+1, err)
+		}
+	}
+
+	adj := &models.StockAdjustment{
+		OutletID:  req.OutletID,
+		Note:      req.Note,
+		CreatedBy: createdBy,
+		Lines:     req.Lines,
+	}
+	apply := !s.adjustmentRequireApproval && !req.RequireApproval
+	if err := s.repo.CreateStockAdjustment(adj, apply); err != nil {
+		return nil, err
+	}
+
+	return adj, nil
+}
+
+func validateAdjustmentLine(line models.StockAdjustmentLine) error {
+	if line.ProductID <= 0 {
+		return fmt.Errorf("product_id wajib diisi")
+	}
+	if line.Quantity == 0 {
+		return fmt.Errorf("quantity tidak boleh 0")
+	}
+
+	switch line.Reason {
+	case models.AdjustmentReasonDamaged, models.AdjustmentReasonExpired, models.AdjustmentReasonLost:
+		if line.Quantity > 0 {
+			return fmt.Errorf("reason %s harus mengurangi stok (quantity negatif)", line.Reason)
+		}
+	case models.AdjustmentReasonFound:
+		if line.Quantity < 0 {
+			return fmt.Errorf("reason found harus menambah stok (quantity positif)")
+		}
+	case models.AdjustmentReasonCorrection:
+	default:
+		return fmt.Errorf("reason %q tidak dikenal", line.Reason)
+	}
+
+	return nil
+}
+
+func (s *StockService) GetStockAdjustments(status string) ([]*models.StockAdjustment, error) {
+	switch status {
+	case "", models.AdjustmentStatusPending, models.AdjustmentStatusApplied, models.AdjustmentStatusRejected:
+	default:
+		return nil, fmt.Errorf("%w: status %q tidak dikenal", repositories.ErrValidation, status)
+	}
+	return s.repo.GetStockAdjustments(status)
+}
+
+func (s *StockService) GetStockAdjustmentByID(id int) (*models.StockAdjustment, error) {
+	return s.repo.GetStockAdjustmentByID(id)
+}
+
+func (s *StockService) ApproveStockAdjustment(id int, reviewedBy string, note string) (*models.StockAdjustment, error) {
+	if err := s.checkApprover(reviewedBy); err != nil {
+		return nil, err
+	}
+	return s.repo.ReviewStockAdjustment(id, true, reviewedBy, note)
+}
+
+func (s *StockService) RejectStockAdjustment(id int, reviewedBy string, note string) (*models.StockAdjustment, error) {
+	if err := s.checkApprover(reviewedBy); err != nil {
+		return nil, err
+	}
+	return s.repo.ReviewStockAdjustment(id, false, reviewedBy, note)
+}
+
+func (s *StockService) checkApprover(user string) error {
+	if len(s.adjustmentApprovers) == 0 {
+		return fmt.Errorf("%w: belum ada approver, isi STOCK_ADJUSTMENT_APPROVERS", repositories.ErrForbidden)
+	}
+	for _, approver := range s.adjustmentApprovers {
+		if strings.EqualFold(approver, user) {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s bukan approver stock adjustment", repositories.ErrForbidden, user)
+}
+
+func (s *StockService) GetLowStockProducts(categoryID *int) ([]models.LowStockProduct, error) {
+	return s.repo.GetLowStockProducts(categoryID)
+}
+
+// GetReorderSuggestions memakai window dari config kalau windowDays tidak diisi.
+func (s *StockService) GetReorderSuggestions(windowDays int, coverDays int, categoryID *int) (*models.ReorderSuggestionReport, error) {
+	if windowDays == 0 {
+		windowDays = s.reorderWindowDays
+	}
+	if coverDays == 0 {
+		coverDays = defaultReorderCoverDays
+	}
+	if windowDays < 1 || windowDays > maxReorderDays {
+		return nil, fmt.Errorf("%w: window_days harus antara 1 dan %d", repositories.ErrValidation, maxReorderDays)
+	}
+	if coverDays < 1 || coverDays > maxReorderDays {
+		return nil, fmt.Errorf("%w: cover_days harus antara 1 dan %d", repositories.ErrValidation, maxReorderDays)
+	}
+	return s.repo.GetReorderSuggestions(windowDays, coverDays, categoryID)
+}
+
+func (s *StockService) GetProductBatches(productID int, outletID *int, includeEmpty bool) ([]models.ProductBatch, error) {
+	return s.repo.GetProductBatches(productID, outletID, includeEmpty)
+}
+
+func (s *StockService) GetExpiringBatches(days int, outletID *int, includeExpired bool) (*models.ExpiryReport, error) {
+	if days == 0 {
+		days = defaultExpiryDays
+	}
+	if days < 0 || days > maxReorderDays {
+		return nil, fmt.Errorf("%w: days harus antara 1 dan %d", repositories.ErrValidation, maxReorderDays)
+	}
+	return s.repo.GetExpiringBatches(days, outletID, includeExpired)
+}
+
+// GetInventoryValuation default-nya per hari ini. asOf berformat YYYY-MM-DD.
+func (s *StockService) GetInventoryValuation(asOf string, categoryID *int) (*models.InventoryValuation, error) {
+	if asOf == "" {
+		asOf = time.Now().Format("2006-01-02")
+	}
+	if _, err := time.Parse("2006-01-02", asOf); err != nil {
+		return nil, fmt.Errorf("%w: as_of harus berformat YYYY-MM-DD", repositories.ErrValidation)
+	}
+	return s.repo.GetInventoryValuation(asOf, categoryID)
+}
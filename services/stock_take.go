@@ -0,0 +1,85 @@
+package services
+
+import (
+	"fmt"
+	"kasir-api/models"
+	"kasir-api/repositories"
+)
+
+func (s *StockService) StartStockTake(req models.StockTakeRequest, createdBy string) (*models.StockTake, error) {
+	seen := make(map[int]bool)
+	categoryIDs := make([]int, 0, len(req.CategoryIDs))
+	for _, id := range req.CategoryIDs {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		categoryIDs = append(categoryIDs, id)
+	}
+
+	st := &models.StockTake{
+		OutletID:    req.OutletID,
+		Note:        req.Note,
+		CategoryIDs: categoryIDs,
+		CreatedBy:   createdBy,
+	}
+	if err := s.repo.StartStockTake(st); err != nil {
+		return nil, err
+	}
+
+	return st, nil
+}
+
+func (s *StockService) GetStockTakes(status string) ([]*models.StockTake, error) {
+	switch status {
+	case "", models.StockTakeStatusOpen, models.StockTakeStatusFinalized, models.StockTakeStatusCancelled:
+	default:
+		return nil, fmt.Errorf("%w: status %q tidak dikenal", repositories.ErrValidation, status)
+	}
+	return s.repo.GetStockTakes(status)
+}
+
+func (s *StockService) GetStockTakeByID(id int) (*models.StockTake, error) {
+	return s.repo.GetStockTakeByID(id)
+}
+
+func (s *StockService) RecordStockTakeCounts(id int, req models.StockTakeCountRequest, countedBy string) ([]models.StockTakeItem, error) {
+	if len(req.Counts) == 0 {
+		return nil, fmt.Errorf("%w: counts tidak boleh kosong", repositories.ErrValidation)
+	}
+
+	for i := range req.Counts {
+		c := &req.Counts[i]
+		if c.Mode == "" {
+			c.Mode = models.StockTakeCountSet
+		}
+
+		switch c.Mode {
+		case models.StockTakeCountSet:
+			if c.Quantity < 0 {
+				return nil, fmt.Errorf("%w: count %d: quantity tidak boleh negatif", repositories.ErrValidation, i+1)
+			}
+		case models.StockTakeCountAdd:
+			//add boleh negatif untuk mengoreksi hitungan yang kelebihan
+			if c.Quantity == 0 {
+				return nil, fmt.Errorf("%w: count %d: quantity tidak boleh 0", repositories.ErrValidation, i+1)
+			}
+		default:
+			return nil, fmt.Errorf("%w: count %d: mode %q tidak dikenal", repositories.ErrValidation, i+1, c.Mode)
+		}
+	}
+
+	return s.repo.RecordStockTakeCounts(id, req, countedBy)
+}
+
+func (s *StockService) GetStockTakeVariance(id int, onlyDiscrepancies bool) (*models.StockTakeVariance, error) {
+	return s.repo.GetStockTakeVariance(id, onlyDiscrepancies)
+}
+
+func (s *StockService) FinalizeStockTake(id int, req models.StockTakeFinalizeRequest, finalizedBy string) (*models.StockTakeVariance, error) {
+	return s.repo.FinalizeStockTake(id, req.ZeroUncounted, finalizedBy)
+}
+
+func (s *StockService) CancelStockTake(id int) error {
+	return s.repo.CancelStockTake(id)
+}
@@ -0,0 +1,99 @@
+package services
+
+import (
+	"fmt"
+	"kasir-api/models"
+	"kasir-api/repositories"
+)
+
+// CreateStockTransfer: source_outlet_id kosong berarti outlet default, destination_outlet_id wajib diisi.
+func (s *StockService) CreateStockTransfer(req models.StockTransferRequest, requestedBy string) (*models.StockTransfer, error) {
+	if req.DestinationOutletID <= 0 {
+		return nil, fmt.Errorf("%w: destination_outlet_id wajib diisi", repositories.ErrValidation)
+	}
+	if len(req.Lines) == 0 {
+		return nil, fmt.Errorf("%w: transfer minimal punya satu line", repositories.ErrValidation)
+	}
+
+	seen := make(map[int]bool)
+	for i, line := range req.Lines {
+		if line.ProductID <= 0 {
+			return nil, fmt.Errorf("%w: line %d: product_id wajib diisi", repositories.ErrValidation, i+1)
+		}
+		if seen[line.ProductID] {
+			return nil, fmt.Errorf("%w: line %d: produk %d muncul lebih dari sekali", repositories.ErrValidation, i+1, line.ProductID)
+		}
+		seen[line.ProductID] = true
+
+		if line.Quantity <= 0 {
+			return nil, fmt.Errorf("%w: line %d: quantity harus lebih dari 0", repositories.ErrValidation, i+1)
+		}
+	}
+
+	t := &models.StockTransfer{
+		SourceOutletID:      req.SourceOutletID,
+		DestinationOutletID: req.DestinationOutletID,
+		Note:                req.Note,
+		RequestedBy:         requestedBy,
+		Lines:               req.Lines,
+	}
+	if err := s.repo.CreateStockTransfer(t); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+func (s *StockService) GetStockTransfers(status string, outletID *int) ([]*models.StockTransfer, error) {
+	switch status {
+	case "", models.StockTransferRequested, models.StockTransferShipped, models.StockTransferReceived, models.StockTransferCancelled:
+	default:
+		return nil, fmt.Errorf("%w: status %q tidak dikenal", repositories.ErrValidation, status)
+	}
+	return s.repo.GetStockTransfers(status, outletID)
+}
+
+func (s *StockService) GetStockTransferByID(id int) (*models.StockTransfer, error) {
+	return s.repo.GetStockTransferByID(id)
+}
+
+func (s *StockService) ShipStockTransfer(id int, req models.StockTransferActionRequest, shippedBy string) (*models.StockTransfer, error) {
+	quantities, err := transferQuantities(req.Lines)
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.ShipStockTransfer(id, quantities, shippedBy)
+}
+
+func (s *StockService) ReceiveStockTransfer(id int, req models.StockTransferActionRequest, receivedBy string) (*models.StockTransfer, error) {
+	quantities, err := transferQuantities(req.Lines)
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.ReceiveStockTransfer(id, quantities, receivedBy)
+}
+
+func (s *StockService) CancelStockTransfer(id int) (*models.StockTransfer, error) {
+	return s.repo.CancelStockTransfer(id)
+}
+
+func (s *StockService) GetInTransitStock(outletID *int) (*models.InTransitReport, error) {
+	return s.repo.GetInTransitStock(outletID)
+}
+
+func transferQuantities(lines []models.StockTransferQuantity) (map[int]models.StockTransferQuantity, error) {
+	quantities := make(map[int]models.StockTransferQuantity)
+	for i, line := range lines {
+		if line.ProductID <= 0 {
+			return nil, fmt.Errorf("%w: line %d: product_id wajib diisi", repositories.ErrValidation, i+1)
+		}
+		if _, ok := quantities[line.ProductID]; ok {
+			return nil, fmt.Errorf("%w: line %d: produk %d muncul lebih dari sekali", repositories.ErrValidation, i+1, line.ProductID)
+		}
+		if line.Quantity < 0 {
+			return nil, fmt.Errorf("%w: line %d: quantity tidak boleh negatif", repositories.ErrValidation, i+1)
+		}
+		quantities[line.ProductID] = line
+	}
+	return quantities, nil
+}
@@ -0,0 +1,44 @@
+package services
+
+import (
+	"fmt"
+	"kasir-api/models"
+	"kasir-api/repositories"
+	"strings"
+)
+
+type SupplierService struct {
+	repo *repositories.SupplierRepository
+}
+
+func NewSupplierService(repo *repositories.SupplierRepository) *SupplierService {
+	return &SupplierService{repo: repo}
+}
+
+func (s *SupplierService) GetAllSuppliers(includeArchived bool) ([]*models.Supplier, error) {
+	return s.repo.GetAllSuppliers(includeArchived)
+}
+
+func (s *SupplierService) GetSupplierByID(id int) (*models.Supplier, error) {
+	return s.repo.GetSupplierByID(id)
+}
+
+func (s *SupplierService) CreateSupplier(supplier *models.Supplier) error {
+	supplier.Name = strings.TrimSpace(supplier.Name)
+	if supplier.Name == "" {
+		return fmt.Errorf("%w: nama supplier wajib diisi", repositories.ErrValidation)
+	}
+	return s.repo.CreateSupplier(supplier)
+}
+
+func (s *SupplierService) UpdateSupplier(supplier *models.Supplier) error {
+	supplier.Name = strings.TrimSpace(supplier.Name)
+	if supplier.Name == "" {
+		return fmt.Errorf("%w: nama supplier wajib diisi", repositories.ErrValidation)
+	}
+	return s.repo.UpdateSupplier(supplier)
+}
+
+func (s *SupplierService) ArchiveSupplier(id int) error {
+	return s.repo.ArchiveSupplier(id)
+}
@@ -0,0 +1,100 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"kasir-api/models"
+	"kasir-api/repositories"
+	"regexp"
+	"strings"
+)
+
+// kode tenant juga dipakai sebagai subdomain, jadi dibatasi huruf kecil, angka dan tanda minus
+var tenantCodePattern = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]*[a-z0-9])?$`)
+
+type TenantService struct {
+	repo         *repositories.TenantRepository
+	requireToken bool
+}
+
+// NewTenantService: kalau requireToken true, tenant hanya bisa ditentukan lewat api token,
+// header/subdomain saja tidak cukup.
+func NewTenantService(repo *repositories.TenantRepository, requireToken bool) *TenantService {
+	return &TenantService{repo: repo, requireToken: requireToken}
+}
+
+// ResolveTenant menentukan tenant dari api token. Kode tenant (header/subdomain) hanya petunjuk: kalau diisi
+// harus sama dengan tenant pemilik token, selain itu ditolak 403. Tanpa token, kode saja hanya diterima
+// kalau requireToken dimatikan.
+func (s *TenantService) ResolveTenant(token string, code string) (*models.Tenant, error) {
+	var tenant *models.Tenant
+	var err error
+	switch {
+	case token != "":
+		tenant, err = s.repo.GetTenantByToken(token)
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, repositories.ErrUnauthorized
+		}
+		if err == nil && code != "" && code != tenant.Code {
+			return nil, fmt.Errorf("%w: token bukan milik tenant %s", repositories.ErrForbidden, code)
+		}
+	case s.requireToken:
+		return nil, fmt.Errorf("%w: api token wajib diisi", repositories.ErrUnauthorized)
+	case code != "":
+		tenant, err = s.repo.GetTenantByCode(code)
+	default:
+		return nil, fmt.Errorf("%w: tenant tidak diketahui, isi api token, header X-Tenant atau pakai subdomain", repositories.ErrUnauthorized)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if tenant.ArchivedAt != nil {
+		return nil, fmt.Errorf("%w: tenant %s sudah nonaktif", repositories.ErrForbidden, tenant.Code)
+	}
+	return tenant, nil
+}
+
+func (s *TenantService) GetAllTenants(includeArchived bool) ([]*models.Tenant, error) {
+	return s.repo.GetAllTenants(includeArchived)
+}
+
+func (s *TenantService) GetTenantByID(id int) (*models.Tenant, error) {
+	return s.repo.GetTenantByID(id)
+}
+
+func (s *TenantService) CreateTenant(req models.TenantRequest) (*models.Tenant, error) {
+	tenant := &models.Tenant{Code: strings.ToLower(strings.TrimSpace(req.Code)), Name: strings.TrimSpace(req.Name)}
+	if !tenantCodePattern.MatchString(tenant.Code) || len(tenant.Code) > 50 {
+		return nil, fmt.Errorf("%w: code hanya boleh huruf kecil, angka dan '-', maksimal 50 karakter", repositories.ErrValidation)
+	}
+	if tenant.Name == "" {
+		return nil, fmt.Errorf("%w: name wajib diisi", repositories.ErrValidation)
+	}
+
+	if err := s.repo.CreateTenant(tenant); err != nil {
+		return nil, err
+	}
+	return tenant, nil
+}
+
+// UpdateTenant hanya mengubah nama, kode tidak bisa diganti karena dipakai sebagai subdomain.
+func (s *TenantService) UpdateTenant(id int, req models.TenantRequest) (*models.Tenant, error) {
+	tenant := &models.Tenant{ID: id, Name: strings.TrimSpace(req.Name)}
+	if tenant.Name == "" {
+		return nil, fmt.Errorf("%w: name wajib diisi", repositories.ErrValidation)
+	}
+
+	if err := s.repo.UpdateTenant(tenant); err != nil {
+		return nil, err
+	}
+	return tenant, nil
+}
+
+func (s *TenantService) ArchiveTenant(id int) error {
+	return s.repo.ArchiveTenant(id)
+}
+
+func (s *TenantService) RotateTenantToken(id int) (*models.Tenant, error) {
+	return s.repo.RotateTenantToken(id)
+}
@@ -1,24 +1,209 @@
 package services
 
 import (
+	"fmt"
 	"kasir-api/models"
+	"kasir-api/notifications"
 	"kasir-api/repositories"
+	"log"
 	"time"
 )
 
 type TransactionService struct {
-	repo *repositories.TransactionRepository
+	repo            *repositories.TransactionRepository
+	lowStockWebhook *notifications.Webhook
+	storeLocation   *time.Location
+	reportBrand     string
 }
 
-func NewTransactionService(repo *repositories.TransactionRepository) *TransactionService {
-	return &TransactionService{repo: repo}
+// NewTransactionService: lowStockWebhook boleh nil kalau notifikasi stok menipis tidak dipakai.
+// storeLocation adalah zona waktu laporan kalau request dan outlet tidak menentukan timezone sendiri.
+// reportBrand dicetak di kop laporan pdf.
+func NewTransactionService(repo *repositories.TransactionRepository, lowStockWebhook *notifications.Webhook, storeLocation *time.Location, reportBrand string) *TransactionService {
+	return &TransactionService{repo: repo, lowStockWebhook: lowStockWebhook, storeLocation: storeLocation, reportBrand: reportBrand}
 }
 
-func (s *TransactionService) Checkout(items []models.CheckoutItem) (*models.Transaction, error) {
+func (s *TransactionService) Checkout(req models.CheckoutRequest) (*models.Transaction, error) {
+	if err := validateCheckoutItems(req.Items); err != nil {
+		return nil, err
+	}
+
 	// helper.ExecuteTransaction(func() error)
-	return s.repo.CreateTransaction(items)
+	transaction, err := s.repo.CreateTransaction(req)
+	if err != nil {
+		return nil, err
+	}
+
+	//dikirim di background supaya checkout tidak menunggu webhook
+	if s.lowStockWebhook != nil && len(transaction.LowStock) > 0 {
+		alert := models.LowStockAlert{TransactionID: transaction.ID, Products: transaction.LowStock}
+		go func() {
+			if err := s.lowStockWebhook.Send("stock.low", alert); err != nil {
+				log.Println("gagal mengirim notifikasi stok menipis:", err)
+			}
+		}()
+	}
+
+	return transaction, nil
 }
 
-func (s *TransactionService) GenerateReport(fromDate *time.Time, toDate *time.Time) (*models.Report, error) {
-	return s.repo.GenerateReport(fromDate, toDate)
+// validateCheckoutItems: quantity negatif akan tercatat sebagai penjualan yang justru menambah stok
+// dan menghasilkan revenue negatif, jadi ditolak di sini.
+func validateCheckoutItems(items []models.CheckoutItem) error {
+	if len(items) == 0 {
+		return fmt.Errorf("%w: minimal satu item", repositories.ErrValidation)
+	}
+	for i, item := range items {
+		if item.ProductID <= 0 {
+			return fmt.Errorf("%w: item %d: product_id wajib diisi", repositories.ErrValidation, i+1)
+		}
+		if item.Quantity < 1 {
+			return fmt.Errorf("%w: item %d: quantity harus lebih dari 0", repositories.ErrValidation, i+1)
+		}
+	}
+	return nil
+}
+
+// GenerateTodayReport memakai tanggal hari ini menurut zona waktu toko, bukan zona waktu server.
+func (s *TransactionService) GenerateTodayReport(outletID *int, compare string, tz string) (*models.Report, error) {
+	loc, err := s.location(tz, outletID)
+	if err != nil {
+		return nil, err
+	}
+
+	today := time.Now().In(loc)
+	return s.generateReport(today, today, outletID, compare, loc)
+}
+
+// GenerateReport: from dan to adalah tanggal kalender (inklusif) di zona waktu toko, compare kosong berarti
+// tanpa pembanding. previous adalah periode tepat sebelumnya dengan jumlah hari yang sama, last_week dan
+// last_year menggeser rentang yang sama 7 hari / 1 tahun ke belakang.
+func (s *TransactionService) GenerateReport(from time.Time, to time.Time, outletID *int, compare string, tz string) (*models.Report, error) {
+	if to.Before(from) {
+		return nil, fmt.Errorf("%w: end_date tidak boleh sebelum start_date", repositories.ErrValidation)
+	}
+
+	loc, err := s.location(tz, outletID)
+	if err != nil {
+		return nil, err
+	}
+	return s.generateReport(from, to, outletID, compare, loc)
+}
+
+func (s *TransactionService) generateReport(from time.Time, to time.Time, outletID *int, compare string, loc *time.Location) (*models.Report, error) {
+	from, to = calendarDate(from), calendarDate(to)
+
+	var compareFrom, compareTo time.Time
+	switch compare {
+	case "":
+	case models.ComparePrevious:
+		days := int(to.Sub(from).Hours()/24) + 1
+		compareFrom, compareTo = from.AddDate(0, 0, -days), from.AddDate(0, 0, -1)
+	case models.CompareLastWeek:
+		compareFrom, compareTo = from.AddDate(0, 0, -7), to.AddDate(0, 0, -7)
+	case models.CompareLastYear:
+		compareFrom, compareTo = from.AddDate(-1, 0, 0), to.AddDate(-1, 0, 0)
+	default:
+		return nil, fmt.Errorf("%w: compare harus previous, last_week atau last_year", repositories.ErrValidation)
+	}
+
+	start, end := dayRange(from, to, loc)
+	report, err := s.repo.GenerateReport(start, end, outletID)
+	if err != nil {
+		return nil, err
+	}
+	report.From = from.Format("2006-01-02")
+	report.To = to.Format("2006-01-02")
+	report.Timezone = loc.String()
+	if compare == "" {
+		return report, nil
+	}
+
+	start, end = dayRange(compareFrom, compareTo, loc)
+	previous, err := s.repo.GenerateReport(start, end, outletID)
+	if err != nil {
+		return nil, err
+	}
+
+	report.Comparison = &models.ReportComparison{
+		Period:                 compare,
+		From:                   compareFrom.Format("2006-01-02"),
+		To:                     compareTo.Format("2006-01-02"),
+		TotalRevenue:           previous.TotalRevenue,
+		TotalTransaction:       previous.TotalTransaction,
+		AverageBasket:          previous.AverageBasket,
+		PopularProduct:         previous.PopularProduct,
+		Revenue:                delta(report.TotalRevenue, previous.TotalRevenue),
+		Transactions:           delta(report.TotalTransaction, previous.TotalTransaction),
+		AverageBasketDelta:     delta(report.AverageBasket, previous.AverageBasket),
+		PopularProductQuantity: delta(report.PopularProduct.Quantity, previous.PopularProduct.Quantity),
+		PopularProductChanged:  report.PopularProduct.ProductName != previous.PopularProduct.ProductName,
+	}
+
+	return report, nil
+}
+
+// location menentukan zona waktu laporan: tz dari request, lalu timezone outlet (outlet default untuk
+// laporan gabungan), lalu STORE_TIMEZONE.
+func (s *TransactionService) location(tz string, outletID *int) (*time.Location, error) {
+	if tz == "" {
+		var err error
+		tz, err = s.repo.GetReportTimezone(outletID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if tz == "" {
+		return s.storeLocation, nil
+	}
+
+	loc, err := time.LoadLocation(tz)
+	if err != nil {
+		return nil, fmt.Errorf("%w: timezone %q tidak dikenal", repositories.ErrValidation, tz)
+	}
+	return loc, nil
+}
+
+// GetSalesByCategory membandingkan from..to dengan periode tepat sebelumnya yang jumlah harinya sama.
+func (s *TransactionService) GetSalesByCategory(from time.Time, to time.Time, outletID *int, tz string) (*models.CategorySalesReport, error) {
+	if to.Before(from) {
+		return nil, fmt.Errorf("%w: to tidak boleh sebelum from", repositories.ErrValidation)
+	}
+
+	loc, err := s.location(tz, outletID)
+	if err != nil {
+		return nil, err
+	}
+
+	from, to = calendarDate(from), calendarDate(to)
+	days := int(to.Sub(from).Hours()/24) + 1
+	prevFrom := from.AddDate(0, 0, -days)
+	prevStart, _ := dayRange(prevFrom, prevFrom, loc)
+	start, end := dayRange(from, to, loc)
+	categories, err := s.repo.GetSalesByCategory(prevStart, start, end, outletID)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &models.CategorySalesReport{
+		From:         from.Format("2006-01-02"),
+		To:           to.Format("2006-01-02"),
+		PreviousFrom: prevFrom.Format("2006-01-02"),
+		PreviousTo:   from.AddDate(0, 0, -1).Format("2006-01-02"),
+		Timezone:     loc.String(),
+		OutletID:     outletID,
+		Categories:   categories,
+	}
+	for _, c := range categories {
+		report.TotalRevenue += c.Revenue
+		report.PreviousTotalRevenue += c.PreviousRevenue
+	}
+	for i := range report.Categories {
+		c := &report.Categories[i]
+		c.Share = percentOf(c.Revenue, report.TotalRevenue)
+		c.RevenueChange = c.Revenue - c.PreviousRevenue
+		c.RevenueGrowth = growth(c.Revenue, c.PreviousRevenue)
+	}
+
+	return report, nil
 }
@@ -0,0 +1,337 @@
+package spreadsheet
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Implementasi pdf minimal: baris yang ditulis dirender sebagai tabel dengan kop brand di setiap halaman.
+// Memakai font standar Helvetica supaya tidak perlu embed font maupun dependency tambahan.
+
+const (
+	pdfMargin     = 40.0
+	pdfFontSize   = 9.0
+	pdfLineHeight = 15.0
+	pdfCellPad    = 4.0
+	pdfBarHeight  = 34.0
+	pdfMinColumn  = 30.0
+	// tabel dengan kolom lebih dari ini dicetak landscape
+	pdfPortraitColumns = 6
+)
+
+// lebar karakter ASCII 32..126 dari metrik AFM Helvetica dan Helvetica-Bold, per 1000 unit font size
+var (
+	helveticaWidths = [95]int{
+		278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278,
+		556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556,
+		1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778,
+		667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556,
+		333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556,
+		556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584,
+	}
+	helveticaBoldWidths = [95]int{
+		278, 333, 474, 556, 556, 889, 722, 238, 333, 333, 389, 584, 278, 333, 278, 278,
+		556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 333, 333, 584, 584, 584, 611,
+		975, 722, 722, 722, 722, 667, 611, 778, 722, 278, 556, 722, 611, 833, 722, 778,
+		667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 333, 278, 333, 584, 556,
+		333, 556, 611, 556, 611, 556, 333, 611, 611, 278, 278, 556, 278, 889, 611, 611,
+		611, 611, 389, 556, 333, 611, 556, 778, 556, 556, 500, 389, 280, 389, 584,
+	}
+)
+
+type pdfRow struct {
+	bold   bool
+	values []interface{}
+}
+
+// pdfWriter menampung semua baris dan baru menulis dokumen saat Close, karena lebar kolom dan
+// jumlah halaman baru diketahui setelah semua baris masuk.
+type pdfWriter struct {
+	w    io.Writer
+	opts Options
+	rows []pdfRow
+}
+
+func newPDFWriter(w io.Writer, opts Options) *pdfWriter {
+	return &pdfWriter{w: w, opts: opts}
+}
+
+func (p *pdfWriter) WriteRow(values ...interface{}) error {
+	p.rows = append(p.rows, pdfRow{values: values})
+	return nil
+}
+
+func (p *pdfWriter) WriteBoldRow(values ...interface{}) error {
+	p.rows = append(p.rows, pdfRow{bold: true, values: values})
+	return nil
+}
+
+func (p *pdfWriter) Close() error {
+	columns := 0
+	for _, row := range p.rows {
+		if len(row.values) > columns {
+			columns = len(row.values)
+		}
+	}
+
+	pageW, pageH := 595.0, 842.0
+	if columns > pdfPortraitColumns {
+		pageW, pageH = pageH, pageW
+	}
+
+	widths := p.columnWidths(columns, pageW-2*pdfMargin)
+	return p.writeDocument(p.layout(widths, pageW, pageH), pageW, pageH)
+}
+
+// columnWidths membagi lebar tabel menurut isi terpanjang tiap kolom. Sel terakhir di baris yang lebih
+// pendek dari jumlah kolom (misalnya baris keterangan periode) boleh melebar ke kolom sisanya, jadi tidak ikut dihitung.
+func (p *pdfWriter) columnWidths(columns int, available float64) []float64 {
+	widths := make([]float64, columns)
+	for _, row := range p.rows {
+		for i, v := range row.values {
+			if i == len(row.values)-1 && len(row.values) < columns {
+				break
+			}
+			text, _ := pdfCell(v)
+			if w := textWidth(text, row.bold, pdfFontSize) + 2*pdfCellPad; w > widths[i] {
+				widths[i] = w
+			}
+		}
+	}
+
+	total := 0.0
+	for i := range widths {
+		widths[i] = math.Max(widths[i], pdfMinColumn)
+		total += widths[i]
+	}
+	if total > 0 {
+		for i := range widths {
+			widths[i] *= available / total
+		}
+	}
+	return widths
+}
+
+// layout mengembalikan content stream per halaman. Footer nomor halaman ditambahkan di writeDocument.
+func (p *pdfWriter) layout(widths []float64, pageW float64, pageH float64) []*bytes.Buffer {
+	tableW := pageW - 2*pdfMargin
+	var pages []*bytes.Buffer
+	var page *bytes.Buffer
+	var y float64
+
+	newPage := func() {
+		page = &bytes.Buffer{}
+		pages = append(pages, page)
+
+		top := pageH - pdfMargin
+		fmt.Fprintf(page, "0.13 0.36 0.62 rg %.2f %.2f %.2f %.2f re f\n", pdfMargin, top-pdfBarHeight, tableW, pdfBarHeight)
+		pdfText(page, "F2", 16, pdfMargin+10, top-pdfBarHeight+11, "1 g", p.opts.Brand)
+		y = top - pdfBarHeight - 24
+		if p.opts.Title != "" {
+			pdfText(page, "F2", 13, pdfMargin, y, "0 g", p.opts.Title)
+			y -= 22
+		}
+	}
+	newPage()
+
+	for _, row := range p.rows {
+		if isEmptyRow(row.values) {
+			y -= pdfLineHeight / 2
+			continue
+		}
+		if y < pdfMargin+pdfLineHeight {
+			newPage()
+		}
+
+		font := "F1"
+		if row.bold {
+			font = "F2"
+			fmt.Fprintf(page, "0.9 g %.2f %.2f %.2f %.2f re f\n", pdfMargin, y-4, tableW, pdfLineHeight)
+		}
+
+		x := pdfMargin
+		for i, v := range row.values {
+			cellW := widths[i]
+			if i == len(row.values)-1 {
+				for _, w := range widths[i+1:] {
+					cellW += w
+				}
+			}
+
+			text, right := pdfCell(v)
+			text = truncateText(text, row.bold, cellW-2*pdfCellPad)
+			if text != "" {
+				tx := x + pdfCellPad
+				if right {
+					tx = x + cellW - pdfCellPad - textWidth(text, row.bold, pdfFontSize)
+				}
+				pdfText(page, font, pdfFontSize, tx, y, "0 g", text)
+			}
+			x += widths[i]
+		}
+		y -= pdfLineHeight
+	}
+
+	return pages
+}
+
+func (p *pdfWriter) writeDocument(pages []*bytes.Buffer, pageW float64, pageH float64) error {
+	var out bytes.Buffer
+	var offsets []int
+	object := func(body string) {
+		offsets = append(offsets, out.Len())
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	out.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	//object 1-4 tetap, lalu page dan content stream berselang-seling mulai object 5
+	kids := make([]string, len(pages))
+	for i := range pages {
+		kids[i] = fmt.Sprintf("%d 0 R", 5+2*i)
+	}
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(pages)))
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+
+	for i, content := range pages {
+		footer := fmt.Sprintf("Halaman %d dari %d", i+1, len(pages))
+		pdfText(content, "F1", 8, pageW-pdfMargin-textWidth(footer, false, 8), pdfMargin/2, "0.4 g", footer)
+
+		var compressed bytes.Buffer
+		zw := zlib.NewWriter(&compressed)
+		if _, err := zw.Write(content.Bytes()); err != nil {
+			return err
+		}
+		if err := zw.Close(); err != nil {
+			return err
+		}
+
+		object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.0f %.0f] /Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>",
+			pageW, pageH, 6+2*i))
+		offsets = append(offsets, out.Len())
+		fmt.Fprintf(&out, "%d 0 obj\n<< /Length %d /Filter /FlateDecode >>\nstream\n", len(offsets), compressed.Len())
+		out.Write(compressed.Bytes())
+		out.WriteString("\nendstream\nendobj\n")
+	}
+	object(fmt.Sprintf("<< /Title (%s) /Producer (kasir-api) >>", pdfEscape(p.opts.Title)))
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R /Info %d 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, len(offsets), xref)
+
+	_, err := out.WriteTo(p.w)
+	return err
+}
+
+func pdfText(buf *bytes.Buffer, font string, size float64, x float64, y float64, color string, text string) {
+	fmt.Fprintf(buf, "BT %s /%s %.1f Tf %.2f %.2f Td (%s) Tj ET\n", color, font, size, x, y, pdfEscape(text))
+}
+
+// pdfEscape mengubah teks ke WinAnsiEncoding. Karakter di luar Latin-1 diganti "?".
+func pdfEscape(text string) string {
+	var b strings.Builder
+	for _, r := range text {
+		switch {
+		case r == '\\' || r == '(' || r == ')':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r < 32:
+			b.WriteByte(' ')
+		case r < 127:
+			b.WriteRune(r)
+		case r >= 160 && r <= 255:
+			b.WriteByte(byte(r))
+		default:
+			b.WriteByte('?')
+		}
+	}
+	return b.String()
+}
+
+// pdfCell memformat nilai sel dengan gaya Indonesia (1.234.567,89). Angka dicetak rata kanan.
+func pdfCell(v interface{}) (string, bool) {
+	switch n := v.(type) {
+	case nil:
+		return "", false
+	case int:
+		return formatThousands(int64(n)), true
+	case int64:
+		return formatThousands(n), true
+	case float64:
+		cents := int64(math.Round(math.Abs(n) * 100))
+		text := fmt.Sprintf("%s,%02d", formatThousands(cents/100), cents%100)
+		if n < 0 && cents > 0 {
+			text = "-" + text
+		}
+		return text, true
+	case time.Time:
+		return n.Format("2006-01-02 15:04"), false
+	default:
+		return fmt.Sprint(v), false
+	}
+}
+
+func formatThousands(n int64) string {
+	digits := strconv.FormatInt(n, 10)
+	sign := ""
+	if n < 0 {
+		sign, digits = "-", digits[1:]
+	}
+
+	var b strings.Builder
+	for i, d := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			b.WriteByte('.')
+		}
+		b.WriteRune(d)
+	}
+	return sign + b.String()
+}
+
+func textWidth(text string, bold bool, size float64) float64 {
+	widths := &helveticaWidths
+	if bold {
+		widths = &helveticaBoldWidths
+	}
+
+	units := 0
+	for _, r := range text {
+		if r >= 32 && r <= 126 {
+			units += widths[r-32]
+		} else {
+			units += 556
+		}
+	}
+	return float64(units) * size / 1000
+}
+
+func truncateText(text string, bold bool, max float64) string {
+	if textWidth(text, bold, pdfFontSize) <= max {
+		return text
+	}
+	runes := []rune(text)
+	for len(runes) > 0 && textWidth(string(runes)+"...", bold, pdfFontSize) > max {
+		runes = runes[:len(runes)-1]
+	}
+	return string(runes) + "..."
+}
+
+func isEmptyRow(values []interface{}) bool {
+	for _, v := range values {
+		if v != nil && v != "" {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,289 @@
+package spreadsheet
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestPDFStructure(t *testing.T) {
+	tests := []struct {
+		name     string
+		columns  int
+		mediaBox string
+	}{
+		{name: "portrait", columns: pdfPortraitColumns, mediaBox: "[0 0 595 842]"},
+		{name: "landscape", columns: pdfPortraitColumns + 1, mediaBox: "[0 0 842 595]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := make([]interface{}, tt.columns)
+			row := make([]interface{}, tt.columns)
+			for i := range header {
+				header[i] = fmt.Sprintf("Kolom %d", i+1)
+				row[i] = i * 1000
+			}
+			doc := parsePDF(t, writePDF(t, Options{Brand: "Kasir", Title: "Laporan (Harian)"}, [][]interface{}{header, row}))
+
+			//catalog, pages, 2 font, 1 page + content, info
+			if len(doc.objects) != 7 {
+				t.Fatalf("got %d object, want 7", len(doc.objects))
+			}
+			if !strings.Contains(doc.objects[1], "/Type /Catalog /Pages 2 0 R") {
+				t.Errorf("object 1 bukan catalog: %s", doc.objects[1])
+			}
+			if !strings.Contains(doc.objects[2], "/Kids [5 0 R] /Count 1") {
+				t.Errorf("object 2 bukan pages dengan 1 halaman: %s", doc.objects[2])
+			}
+			if !strings.Contains(doc.objects[5], "/MediaBox "+tt.mediaBox) || !strings.Contains(doc.objects[5], "/Contents 6 0 R") {
+				t.Errorf("object 5 bukan page %s: %s", tt.mediaBox, doc.objects[5])
+			}
+			if got := pdfStrings(t, doc.objects[7]); len(got) != 2 || got[0] != "Laporan (Harian)" || got[1] != "kasir-api" {
+				t.Errorf("info berisi %q", got)
+			}
+
+			texts := pdfStrings(t, doc.streams[6])
+			if len(texts) < 3 || texts[0] != "Kasir" || texts[1] != "Laporan (Harian)" || texts[2] != "Kolom 1" {
+				t.Errorf("teks halaman %q, want diawali kop, judul lalu header tabel", texts)
+			}
+		})
+	}
+}
+
+func TestPDFEscaping(t *testing.T) {
+	values := []string{
+		`Kopi (Susu)`,
+		`a) b( c`,
+		`C:\laporan\`,
+		`\(`,
+		`))((`,
+		"baris\nbaru",
+		"Caf\u00e9 \u65e5\u672c",
+	}
+	rows := make([][]interface{}, len(values))
+	for i, v := range values {
+		rows[i] = []interface{}{v}
+	}
+	doc := parsePDF(t, writePDF(t, Options{Brand: "Toko (Utama)", Title: `Judul \ (x`}, rows))
+
+	got := pdfStrings(t, doc.streams[6])
+	want := []string{"Toko (Utama)", `Judul \ (x`, `Kopi (Susu)`, `a) b( c`, `C:\laporan\`, `\(`, `))((`, "baris baru", "Caf\xe9 ??", "Halaman 1 dari 1"}
+	if len(got) != len(want) {
+		t.Fatalf("got %d teks %q, want %d teks %q", len(got), got, len(want), want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("teks %d = %q, want %q", i, got[i], want[i])
+		}
+	}
+	if title := pdfStrings(t, doc.objects[len(doc.objects)]); len(title) == 0 || title[0] != `Judul \ (x` {
+		t.Errorf("judul info = %q", title)
+	}
+}
+
+func TestPDFMultiPage(t *testing.T) {
+	const count = 250
+	rows := [][]interface{}{{"No", "Produk", "Terjual"}}
+	for i := 1; i <= count; i++ {
+		rows = append(rows, []interface{}{i, fmt.Sprintf("Produk %03d", i), i * 2})
+	}
+	doc := parsePDF(t, writePDF(t, Options{Brand: "Kasir", Title: "Ranking Produk"}, rows))
+
+	match := regexp.MustCompile(`/Kids \[([^\]]*)\] /Count (\d+)`).FindStringSubmatch(doc.objects[2])
+	if match == nil {
+		t.Fatalf("object pages tidak valid: %s", doc.objects[2])
+	}
+	pages, _ := strconv.Atoi(match[2])
+	if pages < 2 {
+		t.Fatalf("got %d halaman, want lebih dari 1", pages)
+	}
+	if kids := strings.Fields(match[1]); len(kids) != 3*pages {
+		t.Fatalf("kids %q tidak sesuai /Count %d", match[1], pages)
+	}
+	if len(doc.objects) != 4+2*pages+1 {
+		t.Fatalf("got %d object, want %d", len(doc.objects), 4+2*pages+1)
+	}
+
+	next := 1
+	for i := 0; i < pages; i++ {
+		page := doc.objects[5+2*i]
+		if !strings.Contains(page, "/Type /Page /Parent 2 0 R") || !strings.Contains(page, fmt.Sprintf("/Contents %d 0 R", 6+2*i)) {
+			t.Fatalf("object %d bukan page: %s", 5+2*i, page)
+		}
+
+		texts := pdfStrings(t, doc.streams[6+2*i])
+		if texts[0] != "Kasir" || texts[1] != "Ranking Produk" {
+			t.Errorf("halaman %d tanpa kop: %q", i+1, texts[:2])
+		}
+		if footer := texts[len(texts)-1]; footer != fmt.Sprintf("Halaman %d dari %d", i+1, pages) {
+			t.Errorf("footer halaman %d = %q", i+1, footer)
+		}
+		for _, text := range texts {
+			if strings.HasPrefix(text, "Produk ") {
+				if text != fmt.Sprintf("Produk %03d", next) {
+					t.Fatalf("halaman %d: got %q, want Produk %03d", i+1, text, next)
+				}
+				next++
+			}
+		}
+	}
+	if next != count+1 {
+		t.Fatalf("hanya %d dari %d baris yang tercetak", next-1, count)
+	}
+}
+
+func writePDF(t *testing.T, opts Options, rows [][]interface{}) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w, err := NewWriterWithOptions(FormatPDF, &buf, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, row := range rows {
+		write := w.WriteRow
+		if i == 0 {
+			write = w.WriteBoldRow
+		}
+		if err := write(row...); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+type parsedPDF struct {
+	objects map[int]string
+	streams map[int]string
+}
+
+// parsePDF membaca dokumen lewat tabel xref seperti pdf reader: setiap offset harus menunjuk tepat ke awal
+// object-nya, dan stream dibaca sepanjang /Length lalu di-inflate.
+func parsePDF(t *testing.T, data []byte) parsedPDF {
+	t.Helper()
+	if !bytes.HasPrefix(data, []byte("%PDF-1.4\n")) {
+		t.Fatalf("header pdf tidak valid: %q", data[:min(len(data), 16)])
+	}
+
+	tail := regexp.MustCompile(`startxref\n(\d+)\n%%EOF\n$`).FindSubmatch(data)
+	if tail == nil {
+		t.Fatal("startxref tidak ditemukan di akhir file")
+	}
+	xref, _ := strconv.Atoi(string(tail[1]))
+	if xref >= len(data) || !bytes.HasPrefix(data[xref:], []byte("xref\n")) {
+		t.Fatalf("startxref %d tidak menunjuk ke tabel xref", xref)
+	}
+
+	var size int
+	rest := string(data[xref+len("xref\n"):])
+	if _, err := fmt.Sscanf(rest, "0 %d\n", &size); err != nil {
+		t.Fatalf("subsection xref tidak valid: %v", err)
+	}
+	rest = rest[strings.Index(rest, "\n")+1:]
+	if len(rest) < 20*size {
+		t.Fatalf("tabel xref terpotong")
+	}
+	//setiap entry xref tepat 20 byte
+	if entry := rest[:20]; entry != "0000000000 65535 f \n" {
+		t.Fatalf("entry xref 0 = %q", entry)
+	}
+
+	doc := parsedPDF{objects: make(map[int]string), streams: make(map[int]string)}
+	previous := 0
+	for i := 1; i < size; i++ {
+		entry := rest[20*i : 20*i+20]
+		if !strings.HasSuffix(entry, " 00000 n \n") {
+			t.Fatalf("entry xref %d = %q", i, entry)
+		}
+		offset, err := strconv.Atoi(entry[:10])
+		if err != nil || offset <= previous || offset >= xref {
+			t.Fatalf("offset object %d tidak valid: %q", i, entry)
+		}
+		previous = offset
+
+		prefix := fmt.Sprintf("%d 0 obj\n", i)
+		if !bytes.HasPrefix(data[offset:], []byte(prefix)) {
+			t.Fatalf("offset object %d (%d) menunjuk ke %q", i, offset, data[offset:min(len(data), offset+20)])
+		}
+		body := data[offset+len(prefix) : xref]
+
+		if !bytes.HasPrefix(body, []byte("<< /Length ")) {
+			end := bytes.Index(body, []byte("\nendobj\n"))
+			if end < 0 {
+				t.Fatalf("object %d tanpa endobj", i)
+			}
+			doc.objects[i] = string(body[:end])
+			continue
+		}
+
+		var length int
+		if _, err := fmt.Sscanf(string(body), "<< /Length %d /Filter /FlateDecode >>\nstream\n", &length); err != nil {
+			t.Fatalf("dictionary stream object %d tidak valid: %v", i, err)
+		}
+		start := bytes.Index(body, []byte("stream\n")) + len("stream\n")
+		if start+length > len(body) || !bytes.HasPrefix(body[start+length:], []byte("\nendstream\nendobj\n")) {
+			t.Fatalf("/Length %d object %d tidak sesuai isi stream", length, i)
+		}
+		zr, err := zlib.NewReader(bytes.NewReader(body[start : start+length]))
+		if err != nil {
+			t.Fatalf("stream object %d: %v", i, err)
+		}
+		content, err := io.ReadAll(zr)
+		if err != nil {
+			t.Fatalf("stream object %d: %v", i, err)
+		}
+		doc.objects[i] = string(body[:start])
+		doc.streams[i] = string(content)
+	}
+
+	trailer := fmt.Sprintf("trailer\n<< /Size %d /Root 1 0 R /Info %d 0 R >>\n", size, size-1)
+	if !bytes.Contains(data[xref:], []byte(trailer)) {
+		t.Fatalf("trailer tidak sesuai, want %q", trailer)
+	}
+	return doc
+}
+
+// pdfStrings mengambil semua string literal (...) seperti pdf reader: \x meng-escape karakter berikutnya dan
+// kurung tanpa escape harus berpasangan. Kurung yang salah di-escape membuat teks bergeser atau tidak tertutup.
+func pdfStrings(t *testing.T, content string) []string {
+	t.Helper()
+	var texts []string
+	for i := 0; i < len(content); i++ {
+		if content[i] != '(' {
+			continue
+		}
+
+		var b strings.Builder
+		depth := 1
+		for i++; ; i++ {
+			if i >= len(content) {
+				t.Fatalf("string literal tidak tertutup: %q", content)
+			}
+			c := content[i]
+			switch {
+			case c == '\\' && i+1 < len(content):
+				i++
+				b.WriteByte(content[i])
+				continue
+			case c == '(':
+				depth++
+			case c == ')':
+				depth--
+			}
+			if depth == 0 {
+				break
+			}
+			b.WriteByte(c)
+		}
+		texts = append(texts, b.String())
+	}
+	return texts
+}
@@ -0,0 +1,82 @@
+package spreadsheet
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+const (
+	FormatCSV  = "csv"
+	FormatXLSX = "xlsx"
+	FormatPDF  = "pdf"
+)
+
+// Writer menulis baris demi baris supaya data besar bisa langsung di-stream ke response.
+type Writer interface {
+	WriteRow(values ...interface{}) error
+	// WriteBoldRow dipakai untuk header dan baris total. Di CSV sama saja dengan WriteRow.
+	WriteBoldRow(values ...interface{}) error
+	Close() error
+}
+
+// Options hanya dipakai format pdf: Brand dan Title dicetak sebagai kop di setiap halaman.
+type Options struct {
+	Brand string
+	Title string
+}
+
+func NewWriter(format string, w io.Writer) (Writer, error) {
+	return NewWriterWithOptions(format, w, Options{})
+}
+
+func NewWriterWithOptions(format string, w io.Writer, opts Options) (Writer, error) {
+	switch format {
+	case FormatCSV:
+		return newCSVWriter(w), nil
+	case FormatXLSX:
+		return newXLSXWriter(w)
+	case FormatPDF:
+		return newPDFWriter(w, opts), nil
+	default:
+		return nil, fmt.Errorf("format %q tidak didukung", format)
+	}
+}
+
+// ReadAll membaca seluruh baris dari sheet pertama (xlsx) atau file csv.
+func ReadAll(format string, data []byte) ([][]string, error) {
+	switch format {
+	case FormatCSV:
+		return readCSV(data)
+	case FormatXLSX:
+		return readXLSX(data)
+	default:
+		return nil, fmt.Errorf("format %q tidak didukung", format)
+	}
+}
+
+func ContentType(format string) string {
+	switch format {
+	case FormatCSV:
+		return "text/csv; charset=utf-8"
+	case FormatXLSX:
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	case FormatPDF:
+		return "application/pdf"
+	default:
+		return "application/octet-stream"
+	}
+}
+
+// FormatFromFilename menebak format dari ekstensi file upload.
+func FormatFromFilename(filename string) string {
+	lower := strings.ToLower(filename)
+	switch {
+	case strings.HasSuffix(lower, ".csv"):
+		return FormatCSV
+	case strings.HasSuffix(lower, ".xlsx"):
+		return FormatXLSX
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,376 @@
+package spreadsheet
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Implementasi xlsx minimal (satu sheet, inline string) supaya tidak perlu dependency tambahan.
+
+const (
+	xlsxContentTypes = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">
+<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>
+<Default Extension="xml" ContentType="application/xml"/>
+<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>
+<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>
+<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>
+</Types>`
+	xlsxRootRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>
+</Relationships>`
+	xlsxWorkbook = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+<sheets><sheet name="Sheet1" sheetId="1" r:id="rId1"/></sheets>
+</workbook>`
+	xlsxWorkbookRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>
+<Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>
+</Relationships>`
+	// style 0 normal, style 1 bold, style 2 angka dengan pemisah ribuan, style 3 angka bold,
+	// style 4 dan 5 sama seperti 2 dan 3 tapi dengan 2 desimal untuk float
+	xlsxStyles = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+<fonts count="2"><font><sz val="11"/><name val="Calibri"/></font><font><b/><sz val="11"/><name val="Calibri"/></font></fonts>
+<fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills>
+<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>
+<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>
+<cellXfs count="6">
+<xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/>
+<xf numFmtId="0" fontId="1" fillId="0" borderId="0" xfId="0" applyFont="1"/>
+<xf numFmtId="3" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/>
+<xf numFmtId="3" fontId="1" fillId="0" borderId="0" xfId="0" applyFont="1" applyNumberFormat="1"/>
+<xf numFmtId="4" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/>
+<xf numFmtId="4" fontId="1" fillId="0" borderId="0" xfId="0" applyFont="1" applyNumberFormat="1"/>
+</cellXfs>
+</styleSheet>`
+	xlsxSheetHeader = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`
+	xlsxSheetFooter = `</sheetData></worksheet>`
+)
+
+// Batas saat membaca file upload. Nomor baris, kolom dan ukuran isi zip datang dari file, jadi tanpa batas
+// file kecil yang sengaja dibuat bisa membuat server mengalokasikan memori bergiga-giga.
+const (
+	//batas baris dan kolom Excel
+	maxXLSXRows    = 1 << 20
+	maxXLSXColumns = 1 << 14
+	//jumlah cell termasuk cell kosong pengisi, supaya cell di kolom jauh di setiap baris tetap terbatas
+	maxXLSXCells = 5_000_000
+	//ukuran setiap file xml di dalam zip setelah di-extract
+	maxXLSXEntrySize = 100 << 20
+)
+
+var errXLSXTooLarge = errors.New("file xlsx terlalu besar")
+
+type xlsxWriter struct {
+	zw    *zip.Writer
+	sheet io.Writer
+	row   int
+}
+
+func newXLSXWriter(w io.Writer) (*xlsxWriter, error) {
+	zw := zip.NewWriter(w)
+	parts := []struct{ name, body string }{
+		{"[Content_Types].xml", xlsxContentTypes},
+		{"_rels/.rels", xlsxRootRels},
+		{"xl/workbook.xml", xlsxWorkbook},
+		{"xl/_rels/workbook.xml.rels", xlsxWorkbookRels},
+		{"xl/styles.xml", xlsxStyles},
+	}
+	for _, p := range parts {
+		f, err := zw.Create(p.name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := io.WriteString(f, p.body); err != nil {
+			return nil, err
+		}
+	}
+
+	//sheet ditulis terakhir supaya barisnya bisa di-stream
+	sheet, err := zw.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return nil, err
+	}
+	if _, err := io.WriteString(sheet, xlsxSheetHeader); err != nil {
+		return nil, err
+	}
+
+	return &xlsxWriter{zw: zw, sheet: sheet}, nil
+}
+
+func (x *xlsxWriter) WriteRow(values ...interface{}) error {
+	return x.writeRow(false, values)
+}
+
+func (x *xlsxWriter) WriteBoldRow(values ...interface{}) error {
+	return x.writeRow(true, values)
+}
+
+func (x *xlsxWriter) writeRow(bold bool, values []interface{}) error {
+	x.row++
+	var b strings.Builder
+	fmt.Fprintf(&b, `<row r="%d">`, x.row)
+	for i, v := range values {
+		if v == nil {
+			continue
+		}
+		ref := columnName(i) + strconv.Itoa(x.row)
+
+		var number string
+		style := 2
+		switch n := v.(type) {
+		case int:
+			number = strconv.Itoa(n)
+		case int64:
+			number = strconv.FormatInt(n, 10)
+		case float64:
+			number = strconv.FormatFloat(n, 'f', -1, 64)
+			style = 4
+		}
+
+		if number != "" {
+			if bold {
+				style++
+			}
+			fmt.Fprintf(&b, `<c r="%s" s="%d"><v>%s</v></c>`, ref, style, number)
+			continue
+		}
+
+		text := fmt.Sprint(v)
+		if t, ok := v.(time.Time); ok {
+			text = t.Format("2006-01-02 15:04:05")
+		}
+		style = 0
+		if bold {
+			style = 1
+		}
+		fmt.Fprintf(&b, `<c r="%s" s="%d" t="inlineStr"><is><t xml:space="preserve">`, ref, style)
+		xml.EscapeText(&b, []byte(text))
+		b.WriteString(`</t></is></c>`)
+	}
+	b.WriteString(`</row>`)
+
+	_, err := io.WriteString(x.sheet, b.String())
+	return err
+}
+
+func (x *xlsxWriter) Close() error {
+	if _, err := io.WriteString(x.sheet, xlsxSheetFooter); err != nil {
+		return err
+	}
+	return x.zw.Close()
+}
+
+// columnName mengubah index 0-based jadi nama kolom Excel (0 -> A, 26 -> AA).
+func columnName(index int) string {
+	name := ""
+	for index >= 0 {
+		name = string(rune('A'+index%26)) + name
+		index = index/26 - 1
+	}
+	return name
+}
+
+// columnIndex kebalikan dari columnName, menerima cell reference seperti "AB12". Kolom di luar batas Excel
+// dikembalikan sebagai maxXLSXColumns supaya tidak overflow.
+func columnIndex(ref string) int {
+	index := 0
+	for _, r := range ref {
+		if r < 'A' || r > 'Z' {
+			break
+		}
+		index = index*26 + int(r-'A'+1)
+		if index > maxXLSXColumns {
+			return maxXLSXColumns
+		}
+	}
+	return index - 1
+}
+
+type xlsxText struct {
+	T    string `xml:"t"`
+	Runs []struct {
+		T string `xml:"t"`
+	} `xml:"r"`
+}
+
+func (t xlsxText) String() string {
+	if len(t.Runs) == 0 {
+		return t.T
+	}
+	var b strings.Builder
+	for _, r := range t.Runs {
+		b.WriteString(r.T)
+	}
+	return b.String()
+}
+
+type xlsxRels struct {
+	Relationships []struct {
+		ID     string `xml:"Id,attr"`
+		Target string `xml:"Target,attr"`
+	} `xml:"Relationship"`
+}
+
+func readXLSX(data []byte) ([][]string, error) {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, errors.New("file xlsx tidak valid")
+	}
+
+	files := make(map[string]*zip.File)
+	for _, f := range zr.File {
+		files[f.Name] = f
+	}
+
+	var sharedStrings []string
+	if f, ok := files["xl/sharedStrings.xml"]; ok {
+		var sst struct {
+			Items []xlsxText `xml:"si"`
+		}
+		if err := decodeZipXML(f, &sst); err != nil {
+			return nil, err
+		}
+		for _, item := range sst.Items {
+			sharedStrings = append(sharedStrings, item.String())
+		}
+	}
+
+	sheetFile, err := firstSheet(files)
+	if err != nil {
+		return nil, err
+	}
+
+	var sheet struct {
+		Rows []struct {
+			R     int `xml:"r,attr"`
+			Cells []struct {
+				Ref    string   `xml:"r,attr"`
+				Type   string   `xml:"t,attr"`
+				Value  string   `xml:"v"`
+				Inline xlsxText `xml:"is"`
+			} `xml:"c"`
+		} `xml:"sheetData>row"`
+	}
+	if err := decodeZipXML(sheetFile, &sheet); err != nil {
+		return nil, err
+	}
+
+	rows := make([][]string, 0, len(sheet.Rows))
+	cells := 0
+	for i, row := range sheet.Rows {
+		//baris kosong di tengah tetap dipertahankan supaya nomor baris sesuai dengan yang dilihat user
+		rowNumber := row.R
+		if rowNumber == 0 {
+			rowNumber = i + 1
+		}
+		if rowNumber < 0 || rowNumber > maxXLSXRows {
+			return nil, fmt.Errorf("nomor baris %d tidak valid", rowNumber)
+		}
+		//baris kosong pengisi ikut dihitung, masing-masing dianggap satu cell
+		if gap := rowNumber - 1 - len(rows); gap > 0 {
+			cells += gap
+			if cells > maxXLSXCells {
+				return nil, errXLSXTooLarge
+			}
+		}
+		for len(rows) < rowNumber-1 {
+			rows = append(rows, []string{})
+		}
+
+		record := []string{}
+		for j, c := range row.Cells {
+			col := j
+			if c.Ref != "" {
+				col = columnIndex(c.Ref)
+			}
+			if col < 0 || col >= maxXLSXColumns {
+				return nil, fmt.Errorf("kolom cell %q tidak valid", c.Ref)
+			}
+			if col >= len(record) {
+				cells += col + 1 - len(record)
+				if cells > maxXLSXCells {
+					return nil, errXLSXTooLarge
+				}
+			}
+			for len(record) <= col {
+				record = append(record, "")
+			}
+
+			switch c.Type {
+			case "s":
+				idx, err := strconv.Atoi(c.Value)
+				if err != nil || idx < 0 || idx >= len(sharedStrings) {
+					return nil, fmt.Errorf("shared string tidak valid di cell %s", c.Ref)
+				}
+				record[col] = sharedStrings[idx]
+			case "inlineStr":
+				record[col] = c.Inline.String()
+			default:
+				record[col] = c.Value
+			}
+		}
+		rows = append(rows, record)
+	}
+
+	return rows, nil
+}
+
+func firstSheet(files map[string]*zip.File) (*zip.File, error) {
+	wb, ok := files["xl/workbook.xml"]
+	rels, relsOK := files["xl/_rels/workbook.xml.rels"]
+	if ok && relsOK {
+		var workbook struct {
+			Sheets []struct {
+				RID string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+			} `xml:"sheets>sheet"`
+		}
+		var relationships xlsxRels
+		if decodeZipXML(wb, &workbook) == nil && decodeZipXML(rels, &relationships) == nil && len(workbook.Sheets) > 0 {
+			for _, rel := range relationships.Relationships {
+				if rel.ID != workbook.Sheets[0].RID {
+					continue
+				}
+				target := strings.TrimPrefix(rel.Target, "/")
+				if !strings.HasPrefix(target, "xl/") {
+					target = path.Join("xl", target)
+				}
+				if f, ok := files[target]; ok {
+					return f, nil
+				}
+			}
+		}
+	}
+
+	if f, ok := files["xl/worksheets/sheet1.xml"]; ok {
+		return f, nil
+	}
+	return nil, errors.New("sheet tidak ditemukan di file xlsx")
+}
+
+// decodeZipXML menolak entry yang ukurannya melebihi maxXLSXEntrySize. Ukuran di header zip bisa dipalsukan,
+// tapi archive/zip sendiri menolak membaca lebih dari ukuran yang tertulis di header, dan LimitReader
+// menjaga kalau keduanya lolos.
+func decodeZipXML(f *zip.File, v interface{}) error {
+	if f.UncompressedSize64 > maxXLSXEntrySize {
+		return errXLSXTooLarge
+	}
+	rc, err := f.Open()
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+	return xml.NewDecoder(io.LimitReader(rc, maxXLSXEntrySize)).Decode(v)
+}
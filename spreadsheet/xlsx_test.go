@@ -0,0 +1,152 @@
+package spreadsheet
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestXLSXRoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := NewWriter(FormatXLSX, &buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rows := [][]interface{}{
+		{"sku", "name", "price", "margin", "created_at"},
+		{"A-1", `Kopi <Susu> & "Gula"`, 15000, 12.5, time.Date(2026, 1, 2, 15, 4, 5, 0, time.UTC)},
+		{"", "  spasi di awal", int64(-3), nil, "x"},
+		{},
+		{"Total", nil, 14997},
+	}
+	for i, row := range rows {
+		write := w.WriteRow
+		if i == 0 || i == len(rows)-1 {
+			write = w.WriteBoldRow
+		}
+		if err := write(row...); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := ReadAll(FormatXLSX, buf.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := [][]string{
+		{"sku", "name", "price", "margin", "created_at"},
+		{"A-1", `Kopi <Susu> & "Gula"`, "15000", "12.5", "2026-01-02 15:04:05"},
+		{"", "  spasi di awal", "-3", "", "x"},
+		{},
+		{"Total", "", "14997"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("round trip\n got: %q\nwant: %q", got, want)
+	}
+}
+
+func TestReadXLSXSharedStringsAndGaps(t *testing.T) {
+	sheet := `<row r="1"><c r="A1" t="s"><v>0</v></c><c r="C1" t="s"><v>1</v></c></row>` +
+		`<row r="3"><c r="B3"><v>42</v></c></row>`
+	shared := `<?xml version="1.0"?><sst xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">` +
+		`<si><t>nama</t></si><si><r><t>har</t></r><r><t>ga</t></r></si></sst>`
+
+	got, err := ReadAll(FormatXLSX, buildXLSX(t, sheet, map[string]string{"xl/sharedStrings.xml": shared}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := [][]string{{"nama", "", "harga"}, {}, {"", "42"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
+
+func TestReadXLSXMalformed(t *testing.T) {
+	manyFarCells := strings.Builder{}
+	for i := 1; i <= 400; i++ {
+		manyFarCells.WriteString(`<row><c r="XFD1"><v>1</v></c></row>`)
+	}
+
+	tests := []struct {
+		name    string
+		data    []byte
+		wantErr error
+		message string
+	}{
+		{name: "bukan zip", data: []byte("bukan file xlsx"), message: "tidak valid"},
+		{name: "tanpa sheet", data: buildZip(t, map[string]string{"xl/workbook.xml": "<workbook/>"}), message: "sheet tidak ditemukan"},
+		{name: "shared string di luar jangkauan", data: buildXLSX(t, `<row r="1"><c r="A1" t="s"><v>5</v></c></row>`, nil), message: "shared string"},
+		{name: "nomor baris raksasa", data: buildXLSX(t, `<row r="100000000"><c r="A100000000"><v>1</v></c></row>`, nil), message: "nomor baris"},
+		{name: "nomor baris negatif", data: buildXLSX(t, `<row r="-1"><c><v>1</v></c></row>`, nil), message: "nomor baris"},
+		{name: "kolom di luar batas", data: buildXLSX(t, `<row r="1"><c r="XFDZZZZ1"><v>1</v></c></row>`, nil), message: "kolom cell"},
+		{name: "kolom sangat panjang", data: buildXLSX(t, `<row r="1"><c r="`+strings.Repeat("Z", 100)+`1"><v>1</v></c></row>`, nil), message: "kolom cell"},
+		{name: "terlalu banyak cell", data: buildXLSX(t, manyFarCells.String(), nil), wantErr: errXLSXTooLarge},
+		{name: "zip bomb", data: buildZip(t, map[string]string{"xl/worksheets/sheet1.xml": strings.Repeat(" ", maxXLSXEntrySize+1)}), wantErr: errXLSXTooLarge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ReadAll(FormatXLSX, tt.data)
+			if err == nil {
+				t.Fatal("expected error, got nil")
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got error %v, want %v", err, tt.wantErr)
+			}
+			if tt.message != "" && !strings.Contains(err.Error(), tt.message) {
+				t.Fatalf("got error %q, want it to contain %q", err, tt.message)
+			}
+		})
+	}
+}
+
+func TestColumnNameIndex(t *testing.T) {
+	for _, index := range []int{0, 25, 26, 701, 702, maxXLSXColumns - 1} {
+		name := columnName(index)
+		if got := columnIndex(name + "1"); got != index {
+			t.Errorf("columnIndex(%q) = %d, want %d", name, got, index)
+		}
+	}
+	if got := columnName(maxXLSXColumns - 1); got != "XFD" {
+		t.Errorf("last column = %q, want XFD", got)
+	}
+}
+
+// buildXLSX membuat xlsx minimal tanpa workbook.xml, jadi reader memakai fallback xl/worksheets/sheet1.xml.
+func buildXLSX(t *testing.T, sheetData string, extra map[string]string) []byte {
+	t.Helper()
+	files := map[string]string{
+		"xl/worksheets/sheet1.xml": xlsxSheetHeader + sheetData + xlsxSheetFooter,
+	}
+	for name, body := range extra {
+		files[name] = body
+	}
+	return buildZip(t, files)
+}
+
+func buildZip(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, body := range files {
+		f, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := f.Write([]byte(body)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}